@@ -0,0 +1,235 @@
+// Package app runs a service until it is asked to stop (SIGINT, SIGTERM) or one of its servers fails, then shuts it
+// down in order within a timeout:
+//  1. the context of the background tasks is cancelled, and the HTTP servers stop accepting requests and drain the
+//     in-flight ones
+//  2. the background tasks are waited for
+//  3. the shutdown hooks are run, in reverse order of registration (e.g. connections are closed after the components
+//     using them). Once the timeout expires, the remaining hooks are skipped.
+package app
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/logger"
+)
+
+const (
+	// DefaultShutdownTimeout : within Kubernetes' default termination grace period
+	DefaultShutdownTimeout = 25 * time.Second
+
+	// ReadHeaderTimeout : protects against slow clients holding connections (Slowloris)
+	ReadHeaderTimeout = 5 * time.Second
+	ReadTimeout       = 15 * time.Second
+	WriteTimeout      = 30 * time.Second
+	IdleTimeout       = 2 * time.Minute
+)
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+type App struct {
+	// Name : of the service, for logging
+	Name string
+	// ShutdownTimeout : optional, defaults to DefaultShutdownTimeout
+	ShutdownTimeout time.Duration
+	// Signals : optional, defaults to SIGINT and SIGTERM
+	Signals []os.Signal
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	// stop : asks Run to shut down, with the cause of the shutdown if it is a failure
+	stop     chan error
+	stopOnce sync.Once
+
+	mu      sync.Mutex
+	servers []*http.Server
+	hooks   []hook
+	tasks   sync.WaitGroup
+}
+
+func New(name string) *App {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &App{
+		Name:   name,
+		ctx:    ctx,
+		cancel: cancel,
+		stop:   make(chan error, 1),
+	}
+}
+
+// Context : cancelled when the shutdown starts
+func (a *App) Context() context.Context {
+	return a.ctx
+}
+
+// Go : runs a background task, which must return once the context is done. Tasks are waited for before running the
+// shutdown hooks.
+func (a *App) Go(name string, task func(ctx context.Context)) {
+	a.tasks.Add(1)
+
+	go func() {
+		defer a.tasks.Done()
+
+		task(a.ctx)
+
+		logger.Debug("%s: task %s stopped", a.Name, name)
+	}()
+}
+
+// OnShutdown : registers a hook run during the shutdown, after the hooks registered later
+func (a *App) OnShutdown(name string, fn func(ctx context.Context) error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.hooks = append(a.hooks, hook{name: name, fn: fn})
+}
+
+// Serve : starts the server in the background. If it fails, the app is shut down.
+func (a *App) Serve(srv *http.Server) {
+	a.mu.Lock()
+	a.servers = append(a.servers, srv)
+	a.mu.Unlock()
+
+	go func() {
+		logger.Info("%s: listening on %s", a.Name, srv.Addr)
+
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.Stop(errors.Wrapf(err, "server on %s failed", srv.Addr))
+		}
+	}()
+}
+
+// Stop : shuts the app down, err being the cause of the shutdown if it is a failure
+func (a *App) Stop(err error) {
+	a.stopOnce.Do(func() {
+		a.stop <- err
+	})
+}
+
+// Run : blocks until the app is stopped, then shuts it down. Returns the cause of the shutdown if it is a failure, or
+// the first error of the shutdown itself.
+func (a *App) Run() error {
+	signals := a.Signals
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, signals...)
+
+	defer signal.Stop(sig)
+
+	var cause error
+
+	select {
+	case s := <-sig:
+		logger.Info("%s: received %s, shutting down", a.Name, s.String())
+	case cause = <-a.stop:
+		if cause != nil {
+			logger.WithError(cause).Error("%s: shutting down", a.Name)
+		} else {
+			logger.Info("%s: shutting down", a.Name)
+		}
+	}
+
+	if err := a.shutdown(); cause == nil {
+		cause = err
+	}
+
+	return cause
+}
+
+func (a *App) shutdown() error {
+	timeout := a.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	a.cancel()
+
+	a.mu.Lock()
+	servers := a.servers
+	hooks := a.hooks
+	a.mu.Unlock()
+
+	var firstErr error
+
+	fail := func(err error) {
+		logger.WithError(err).Error("%s: shutdown error", a.Name)
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for _, srv := range servers {
+		if err := srv.Shutdown(ctx); err != nil {
+			fail(errors.Wrapf(err, "cannot shut down server on %s", srv.Addr))
+		}
+	}
+
+	if err := wait(ctx, func() error { a.tasks.Wait(); return nil }); err != nil {
+		fail(errors.Wrap(err, "background tasks did not stop"))
+	}
+
+	for idx := len(hooks) - 1; idx >= 0; idx-- {
+		h := hooks[idx]
+
+		// the process is about to be killed anyway
+		if ctx.Err() != nil {
+			fail(errors.Wrapf(ctx.Err(), "shutdown hook %s skipped", h.name))
+
+			continue
+		}
+
+		if err := wait(ctx, func() error { return h.fn(ctx) }); err != nil {
+			fail(errors.Wrapf(err, "shutdown hook %s", h.name))
+		}
+	}
+
+	logger.Info("%s: shut down", a.Name)
+
+	return firstErr
+}
+
+// wait : runs fn, giving up once the context is done
+func wait(ctx context.Context, fn func() error) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NewServer : HTTP server with timeouts, so that slow or idle clients cannot exhaust its connections
+func NewServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: ReadHeaderTimeout,
+		ReadTimeout:       ReadTimeout,
+		WriteTimeout:      WriteTimeout,
+		IdleTimeout:       IdleTimeout,
+	}
+}
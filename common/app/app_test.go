@@ -0,0 +1,193 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/logger/zap"
+)
+
+func TestApp_Run(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	testErr := errors.New("error")
+
+	tests := []struct {
+		name      string
+		setup     func(a *App, steps chan<- string)
+		stop      func(a *App)
+		assertion func(t *testing.T, err error, steps []string)
+	}{
+		{
+			name: "ordered-shutdown",
+			setup: func(a *App, steps chan<- string) {
+				a.OnShutdown("redis", func(ctx context.Context) error {
+					steps <- "redis"
+					return nil
+				})
+				a.OnShutdown("mysql", func(ctx context.Context) error {
+					steps <- "mysql"
+					return nil
+				})
+				a.Go("loop", func(ctx context.Context) {
+					<-ctx.Done()
+					steps <- "loop"
+				})
+			},
+			stop: func(a *App) {
+				a.Stop(nil)
+			},
+			assertion: func(t *testing.T, err error, steps []string) {
+				assert.Nil(t, err)
+				// background tasks stop before the hooks, run in reverse order of registration
+				assert.Equal(t, []string{"loop", "mysql", "redis"}, steps)
+			},
+		},
+		{
+			name: "signal",
+			setup: func(a *App, steps chan<- string) {
+				a.Signals = []os.Signal{syscall.SIGUSR1}
+				a.OnShutdown("mysql", func(ctx context.Context) error {
+					steps <- "mysql"
+					return nil
+				})
+			},
+			stop: func(a *App) {
+				assert.Nil(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+			},
+			assertion: func(t *testing.T, err error, steps []string) {
+				assert.Nil(t, err)
+				assert.Equal(t, []string{"mysql"}, steps)
+			},
+		},
+		{
+			name: "error-failure",
+			setup: func(a *App, steps chan<- string) {
+				a.OnShutdown("mysql", func(ctx context.Context) error {
+					steps <- "mysql"
+					return nil
+				})
+			},
+			stop: func(a *App) {
+				a.Stop(testErr)
+			},
+			assertion: func(t *testing.T, err error, steps []string) {
+				assert.ErrorIs(t, err, testErr)
+				assert.Equal(t, []string{"mysql"}, steps)
+			},
+		},
+		{
+			name: "error-hook-timeout",
+			setup: func(a *App, steps chan<- string) {
+				a.ShutdownTimeout = 10 * time.Millisecond
+				a.OnShutdown("redis", func(ctx context.Context) error {
+					steps <- "redis"
+					return nil
+				})
+				a.OnShutdown("stuck", func(ctx context.Context) error {
+					time.Sleep(time.Second)
+					return nil
+				})
+			},
+			stop: func(a *App) {
+				a.Stop(nil)
+			},
+			assertion: func(t *testing.T, err error, steps []string) {
+				// hooks are given up once the timeout expires
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				assert.Empty(t, steps)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			a := New("test")
+			steps := make(chan string, 10)
+
+			tc.setup(a, steps)
+
+			// the signal is sent once Run is listening
+			time.AfterFunc(10*time.Millisecond, func() {
+				tc.stop(a)
+			})
+
+			err := a.Run()
+			close(steps)
+
+			var collected []string
+			for step := range steps {
+				collected = append(collected, step)
+			}
+
+			tc.assertion(t, err, collected)
+		})
+	}
+}
+
+func TestApp_Serve(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	addr := listener.Addr().String()
+	assert.Nil(t, listener.Close())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	a := New("test")
+	a.Serve(NewServer(addr, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	assert.Eventually(t, func() bool {
+		conn, dialErr := net.Dial("tcp", addr)
+		if dialErr != nil {
+			return false
+		}
+
+		return conn.Close() == nil
+	}, time.Second, 5*time.Millisecond)
+
+	status := make(chan int)
+
+	go func() {
+		res, getErr := http.Get("http://" + addr)
+		if getErr != nil {
+			status <- 0
+			return
+		}
+
+		_ = res.Body.Close()
+		status <- res.StatusCode
+	}()
+
+	<-started
+
+	// the in-flight request is drained before the app stops
+	a.Stop(nil)
+	time.AfterFunc(20*time.Millisecond, func() { close(release) })
+
+	assert.Nil(t, a.Run())
+	assert.Equal(t, http.StatusOK, <-status)
+
+	// a server that cannot listen stops the app
+	b := New("test")
+	b.Serve(NewServer("invalid-address", http.NotFoundHandler()))
+
+	assert.Error(t, b.Run())
+}
@@ -8,10 +8,20 @@ import (
 )
 
 const (
-	PrefixAPIKey = "api_key"
-	PrefixRate   = "rate"
+	PrefixAPIKey       = "api_key"
+	PrefixBudget       = "budget"
+	PrefixLease        = "lease"
+	PrefixOAuthClient  = "oauth_client"
+	PrefixOrganization = "organization"
+	PrefixPlan         = "plan"
+	PrefixQuota        = "quota"
+	PrefixRate         = "rate"
+	PrefixRateHistory  = "rate_history"
+	PrefixRateSnapshot = "rate_snapshot"
 
 	MaxCacheLifetime = 10 * time.Minute
+	// HistoryLifetime : how long daily rates are kept
+	HistoryLifetime = 366 * 24 * time.Hour
 )
 
 // Cache is an interface of a service that can cache the data
@@ -36,12 +46,70 @@ type Cache interface {
 
 	// Remove removes the value from the cache
 	Remove(ctx context.Context, key string) error
+
+	// Increment atomically adds delta to the counter stored at key, creating it if missing, and returns the new value.
+	// The expiration is refreshed at every call.
+	Increment(
+		ctx context.Context,
+		key string,
+		delta int64,
+		expiration time.Duration,
+	) (value int64, err error)
+
+	// SetFenced sets the value to the cache unless the stored one was written with a greater fencing token, so that a
+	// writer that lost its lease without noticing cannot overwrite the values of its successor. The value must be
+	// encoded with the token in its "fencing-token" field. Returns whether the value was set.
+	SetFenced(
+		ctx context.Context,
+		key string,
+		value interface{},
+		token int64,
+		expiration time.Duration,
+	) (set bool, err error)
 }
 
 func GenerateCacheKeyAPIKey(apiKeyID string) string {
 	return fmt.Sprintf("%s_%s", PrefixAPIKey, apiKeyID)
 }
 
+// GenerateCacheKeyBudget : counter of the calls made to a paid FX source within a calendar period
+// (e.g. "month_2023-10")
+func GenerateCacheKeyBudget(source, period string) string {
+	return fmt.Sprintf("%s_%s_%s", PrefixBudget, source, period)
+}
+
+// GenerateCacheKeyLease : holder of the lease of a task. The name is hash tagged, so that the lease and its fencing
+// counter are stored on the same Redis Cluster slot.
+func GenerateCacheKeyLease(name string) string {
+	return fmt.Sprintf("{%s_%s}", PrefixLease, name)
+}
+
+// GenerateCacheKeyLeaseFencing : counter of the acquisitions of the lease of a task
+func GenerateCacheKeyLeaseFencing(name string) string {
+	return fmt.Sprintf("%s_fencing", GenerateCacheKeyLease(name))
+}
+
+// GenerateCacheKeyOAuthClient : usages of OAuth2 clients are kept apart from API keys, so that a client ID can never
+// be mistaken for a cached API key
+func GenerateCacheKeyOAuthClient(clientID string) string {
+	return fmt.Sprintf("%s_%s", PrefixOAuthClient, clientID)
+}
+
+// GenerateCacheKeyOrganization : usages of the keys owned by an organization are pooled under this key
+func GenerateCacheKeyOrganization(organizationID string) string {
+	return fmt.Sprintf("%s_%s", PrefixOrganization, organizationID)
+}
+
+func GenerateCacheKeyPlan(planID string) string {
+	return fmt.Sprintf("%s_%s", PrefixPlan, planID)
+}
+
+// GenerateCacheKeyQuota : counter of the requests made within a calendar period (e.g. "day_2023-10-14") by the
+// credentials whose usages are tracked under usagesKey
+func GenerateCacheKeyQuota(usagesKey, period string) string {
+	return fmt.Sprintf("%s_%s_%s", PrefixQuota, period, usagesKey)
+}
+
 func GenerateCacheKeyRate(fromCurrency, toCurrency string) string {
 	return fmt.Sprintf("%s_%s_%s",
 		PrefixRate,
@@ -49,3 +117,23 @@ func GenerateCacheKeyRate(fromCurrency, toCurrency string) string {
 		strings.ToLower(toCurrency),
 	)
 }
+
+// GenerateCacheKeyRateSnapshot : rates served to plans that are not real-time, refreshed once per freshness period
+func GenerateCacheKeyRateSnapshot(fromCurrency, toCurrency string, freshness time.Duration) string {
+	return fmt.Sprintf("%s_%d_%s_%s",
+		PrefixRateSnapshot,
+		int64(freshness.Seconds()),
+		strings.ToLower(fromCurrency),
+		strings.ToLower(toCurrency),
+	)
+}
+
+// GenerateCacheKeyRateHistory : rate of the pair on a past day (UTC)
+func GenerateCacheKeyRateHistory(fromCurrency, toCurrency string, day time.Time) string {
+	return fmt.Sprintf("%s_%s_%s_%s",
+		PrefixRateHistory,
+		strings.ToLower(fromCurrency),
+		strings.ToLower(toCurrency),
+		day.UTC().Format(time.DateOnly),
+	)
+}
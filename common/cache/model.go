@@ -1,16 +1,29 @@
 package cache
 
 type CachedAPIKey struct {
-	APIKeyID string              `json:"api-key-id"`
-	Type     uint8               `json:"type"`
-	Usages   []CachedAPIKeyUsage `json:"usages"`
+	APIKeyID       string `json:"api-key-id"`
+	UserID         string `json:"user-id"`
+	OrganizationID string `json:"organization-id,omitempty"`
+	PlanID         string `json:"plan-id"`
+	// ValidatedAt : last time the key was checked against the DB, so that revocations and plan changes are picked up
+	ValidatedAt int64 `json:"validated-at"` // unix (s)
+
+	Usages []CachedAPIKeyUsage `json:"usages"`
 }
 
 type CachedAPIKeyUsage struct {
 	Timestamp int64 `json:"timestamp"` // unix (s)
+	// Units : cost of the request, 0 for usages tracked before requests had a cost, which count as 1 unit
+	Units int `json:"units,omitempty"`
 }
 
 type CachedRate struct {
-	Rate      float64 `json:"rate"`
+	// Rate : mid rate
+	Rate float64 `json:"rate"`
+	// Bid, Ask : set only if provided by the source
+	Bid       float64 `json:"bid,omitempty"`
+	Ask       float64 `json:"ask,omitempty"`
 	Timestamp int64   `json:"timestamp"`
+	// FencingToken : of the leader that wrote the rate, see Cache.SetFenced
+	FencingToken int64 `json:"fencing-token,omitempty"`
 }
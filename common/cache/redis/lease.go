@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/lruggieri/fxnow/common/cache"
+)
+
+var (
+	// acquireScript : renews the lease if held by the holder, otherwise takes it if free, with a new fencing token.
+	// Returns the fencing token, 0 if the lease is held by someone else.
+	acquireScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if current then
+	local holder, token = string.match(current, '^(.*):(%d+)$')
+	if holder ~= ARGV[1] then
+		return 0
+	end
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return tonumber(token)
+end
+local token = redis.call('INCR', KEYS[2])
+redis.call('SET', KEYS[1], ARGV[1] .. ':' .. token, 'PX', ARGV[2])
+return token
+`)
+
+	// releaseScript : frees the lease only if still held with the fencing token
+	releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+)
+
+// Lease : lock expiring unless renewed, so that a single replica performs a task at a time. Every acquisition is
+// given a fencing token greater than the previous ones, so that a holder that lost the lease without noticing (e.g.
+// after a long GC pause) can tell before writing.
+type Lease struct {
+	Client UniversalClient
+	// Name : of the task, replicas competing for it share the name
+	Name string
+	// Holder : unique identifier of the replica
+	Holder string
+}
+
+// Acquire : takes the lease if free, or renews it if already held. Returns the fencing token of the holding, 0 if
+// the lease is held by another replica.
+func (l *Lease) Acquire(ctx context.Context, ttl time.Duration) (int64, error) {
+	token, err := acquireScript.Run(ctx, l.Client,
+		[]string{cache.GenerateCacheKeyLease(l.Name), cache.GenerateCacheKeyLeaseFencing(l.Name)},
+		l.Holder, ttl.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot acquire lease")
+	}
+
+	return token, nil
+}
+
+// Release : frees the lease, so that another replica can take over without waiting for it to expire
+func (l *Lease) Release(ctx context.Context, token int64) error {
+	err := releaseScript.Run(ctx, l.Client, []string{cache.GenerateCacheKeyLease(l.Name)}, l.value(token)).Err()
+
+	return errors.Wrap(err, "cannot release lease")
+}
+
+// Check : whether the lease is still held with the fencing token
+func (l *Lease) Check(ctx context.Context, token int64) (bool, error) {
+	value, err := l.Client.Get(ctx, cache.GenerateCacheKeyLease(l.Name)).Result()
+	if err != nil && err != redis.Nil {
+		return false, errors.Wrap(err, "cannot check lease")
+	}
+
+	return value == l.value(token), nil
+}
+
+func (l *Lease) value(token int64) string {
+	return fmt.Sprintf("%s:%d", l.Holder, token)
+}
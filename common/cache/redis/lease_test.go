@@ -0,0 +1,63 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lruggieri/fxnow/common/cache"
+	cRedis "github.com/lruggieri/fxnow/common/cache/redis"
+	mockredis "github.com/lruggieri/fxnow/common/mock/cache/redis"
+)
+
+func TestLease(t *testing.T) {
+	testErr := errors.New("error")
+	ctx := context.Background()
+	keys := []string{"{lease_fxupdate}", "{lease_fxupdate}_fencing"}
+
+	c := mockredis.NewUniversalClient(t)
+	l := cRedis.Lease{Client: c, Name: "fxupdate", Holder: "replica-1"}
+
+	assert.Equal(t, keys, []string{cache.GenerateCacheKeyLease("fxupdate"), cache.GenerateCacheKeyLeaseFencing("fxupdate")})
+
+	c.EXPECT().EvalSha(ctx, mock.Anything, keys, "replica-1", int64(10000)).
+		Return(redis.NewCmdResult(int64(7), nil)).Once()
+
+	token, err := l.Acquire(ctx, 10*time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), token)
+
+	c.EXPECT().EvalSha(ctx, mock.Anything, keys, "replica-1", int64(10000)).
+		Return(redis.NewCmdResult(nil, testErr)).Once()
+
+	_, err = l.Acquire(ctx, 10*time.Second)
+	assert.ErrorIs(t, err, testErr)
+
+	c.EXPECT().Get(ctx, keys[0]).Return(redis.NewStringResult("replica-1:7", nil)).Once()
+
+	held, err := l.Check(ctx, 7)
+	assert.Nil(t, err)
+	assert.True(t, held)
+
+	// taken over by another replica
+	c.EXPECT().Get(ctx, keys[0]).Return(redis.NewStringResult("replica-2:8", nil)).Once()
+
+	held, err = l.Check(ctx, 7)
+	assert.Nil(t, err)
+	assert.False(t, held)
+
+	c.EXPECT().Get(ctx, keys[0]).Return(redis.NewStringResult("", redis.Nil)).Once()
+
+	held, err = l.Check(ctx, 7)
+	assert.Nil(t, err)
+	assert.False(t, held)
+
+	c.EXPECT().EvalSha(ctx, mock.Anything, keys[:1], "replica-1:7").Return(redis.NewCmdResult(int64(1), nil)).Once()
+
+	assert.Nil(t, l.Release(ctx, 7))
+}
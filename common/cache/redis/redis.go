@@ -9,6 +9,19 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// setFencedScript : sets the value unless the stored one has a greater fencing token. Returns 1 if set, 0 otherwise.
+var setFencedScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if current then
+	local ok, decoded = pcall(cjson.decode, current)
+	if ok and type(decoded) == 'table' and tonumber(decoded['fencing-token'] or 0) > tonumber(ARGV[2]) then
+		return 0
+	end
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+return 1
+`)
+
 type Cacher struct {
 	Client UniversalClient
 }
@@ -31,6 +44,11 @@ func (c *Cacher) Get(ctx context.Context, key string, value interface{}) (exist
 	return true, nil
 }
 
+// Ping : checks that Redis can be reached
+func (c *Cacher) Ping(ctx context.Context) error {
+	return c.Client.Ping(ctx).Err()
+}
+
 // Remove implements cache.Cacher
 func (c *Cacher) Remove(ctx context.Context, key string) error {
 	err := c.Client.Del(ctx, key).Err()
@@ -50,6 +68,42 @@ func (c *Cacher) Set(ctx context.Context, key string, value interface{}, expirat
 	return errors.Wrap(err, "cannot set key to redis")
 }
 
+// Increment implements cache.Cacher
+func (c *Cacher) Increment(ctx context.Context, key string, delta int64, expiration time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+
+	// the counter and its expiration are set in the same transaction, so that counters never outlive their period
+	_, err := c.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.IncrBy(ctx, key, delta)
+		pipe.Expire(ctx, key, expiration)
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot increment key in redis")
+	}
+
+	return incr.Val(), nil
+}
+
+// SetFenced implements cache.Cacher. The token is compared and the value set within a script, so that no other
+// write can happen in between.
+func (c *Cacher) SetFenced(
+	ctx context.Context, key string, value interface{}, token int64, expiration time.Duration,
+) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := setFencedScript.Run(ctx, c.Client, []string{key}, string(data), token, expiration.Milliseconds()).Int64()
+	if err != nil {
+		return false, errors.Wrap(err, "cannot set fenced key to redis")
+	}
+
+	return set == 1, nil
+}
+
 type UniversalClient interface {
 	redis.UniversalClient
 }
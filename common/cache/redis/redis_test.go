@@ -0,0 +1,48 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lruggieri/fxnow/common/cache"
+	cRedis "github.com/lruggieri/fxnow/common/cache/redis"
+	mockredis "github.com/lruggieri/fxnow/common/mock/cache/redis"
+)
+
+func TestCacher_SetFenced(t *testing.T) {
+	testErr := errors.New("error")
+	ctx := context.Background()
+	keys := []string{"rate_usd_jpy"}
+	data := `{"rate":149.8,"timestamp":1697796000,"fencing-token":7}`
+	rate := cache.CachedRate{Rate: 149.8, Timestamp: 1697796000, FencingToken: 7}
+
+	c := mockredis.NewUniversalClient(t)
+	cacher := cRedis.Cacher{Client: c}
+
+	c.EXPECT().EvalSha(ctx, mock.Anything, keys, data, int64(7), int64(600000)).
+		Return(redis.NewCmdResult(int64(1), nil)).Once()
+
+	set, err := cacher.SetFenced(ctx, keys[0], rate, 7, 10*time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, set)
+
+	// written meanwhile by a leader with a greater fencing token
+	c.EXPECT().EvalSha(ctx, mock.Anything, keys, data, int64(7), int64(600000)).
+		Return(redis.NewCmdResult(int64(0), nil)).Once()
+
+	set, err = cacher.SetFenced(ctx, keys[0], rate, 7, 10*time.Minute)
+	assert.Nil(t, err)
+	assert.False(t, set)
+
+	c.EXPECT().EvalSha(ctx, mock.Anything, keys, data, int64(7), int64(600000)).
+		Return(redis.NewCmdResult(nil, testErr)).Once()
+
+	_, err = cacher.SetFenced(ctx, keys[0], rate, 7, 10*time.Minute)
+	assert.ErrorIs(t, err, testErr)
+}
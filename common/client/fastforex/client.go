@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/lruggieri/fxnow/common/client/httpclient"
+	"github.com/lruggieri/fxnow/common/currency"
 	"github.com/lruggieri/fxnow/common/fxsource"
 	"github.com/lruggieri/fxnow/common/logger"
 	"github.com/lruggieri/fxnow/common/util"
@@ -21,6 +24,17 @@ const (
 	APIURL           = "https://api.fastforex.io"
 	FetchOneEndpoint = "fetch-one"
 	FetchAllEndpoint = "fetch-all"
+	// FetchMultiEndpoint : rates of a base currency against a list of quote currencies
+	FetchMultiEndpoint = "fetch-multi"
+	// HistoricalEndpoint : rates of a base currency on a past day
+	HistoricalEndpoint = "historical"
+	// TimeSeriesEndpoint : daily rates of a base currency within a range of days
+	TimeSeriesEndpoint = "time-series"
+	// UsageEndpoint : usage of the API key, not metered
+	UsageEndpoint = "usage"
+
+	// DefaultMaxConcurrency : base currencies fetched in parallel by FetchAllRates when MaxConcurrency is not set
+	DefaultMaxConcurrency = 4
 )
 
 type clientFetchOneResponse struct {
@@ -42,9 +56,21 @@ type clientFetchAllResponse struct {
 type Client struct {
 	APIKey     string
 	HTTPClient httpclient.Client
+	// Meter : optional, counts the calls answered by fastforex. It must be beneath the retries of HTTPClient (e.g.
+	// httpclient.Resilient), so that every attempt is counted. Calls is always 0 without it.
+	Meter *httpclient.Meter
+	// PingClient : optional, client of the health checks, defaults to http.DefaultClient. It should not share the
+	// circuit breaker of HTTPClient, so that failing readiness probes cannot stop the updates.
+	PingClient httpclient.Client
+	// MaxConcurrency : optional, maximum number of base currencies fetched in parallel
+	MaxConcurrency int
 }
 
 func (i *Client) FetchRate(ctx context.Context, req fxsource.FetchRateRequest) (*fxsource.FetchRateResponse, error) {
+	if currency.IsCrypto(req.From) || currency.IsCrypto(req.To) {
+		return i.fetchCryptoRate(ctx, req)
+	}
+
 	url := fmt.Sprintf("%s/%s?from=%s&to=%s&api_key=%s",
 		APIURL,
 		FetchOneEndpoint,
@@ -82,66 +108,121 @@ func (i *Client) FetchRate(ctx context.Context, req fxsource.FetchRateRequest) (
 	}, nil
 }
 
+// FetchAllRates : cryptocurrencies are fetched only if listed in the limit, against the fiat currencies listed along
+// with them (USD and EUR if none)
 func (i *Client) FetchAllRates(
 	ctx context.Context, req fxsource.FetchAllRatesRequest,
 ) (*fxsource.FetchAllRatesResponse, error) {
-	currenciesToFetch := fiatCurrencies
+	limitMap := util.SliceToMap(req.Limit)
 
-	if len(req.Limit) > 0 {
-		limitMap := util.SliceToMap(req.Limit)
+	currencies := make([]string, 0, len(fiatCurrencies))
 
-		for currency := range currenciesToFetch {
-			if _, ok := limitMap[currency]; !ok {
-				delete(currenciesToFetch, currency)
-			}
+	for code := range fiatCurrencies {
+		if _, ok := limitMap[code]; ok || len(req.Limit) == 0 {
+			currencies = append(currencies, code)
+		}
+	}
+
+	for code := range limitMap {
+		if currency.IsCrypto(code) {
+			currencies = append(currencies, code)
 		}
 	}
 
-	rates := make([]fxsource.Rate, 0)
+	quotes := cryptoQuotes(req.Limit)
+
+	// results are collected by position, so that their order does not depend on which fetch completes first
+	sort.Strings(currencies)
+
+	results := make([][]fxsource.Rate, len(currencies))
+	errs := make([]error, len(currencies))
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < i.maxConcurrency(len(currencies)); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range jobs {
+				if currency.IsCrypto(currencies[idx]) {
+					results[idx], errs[idx] = i.fetchCryptoCurrency(ctx, currencies[idx], quotes)
+				} else {
+					results[idx], errs[idx] = i.fetchAllRateCurrency(ctx, currencies[idx])
+				}
+			}
+		}()
+	}
+
+	for idx := range currencies {
+		jobs <- idx
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	res := &fxsource.FetchAllRatesResponse{
+		Rates: make([]fxsource.Rate, 0),
+	}
+
+	for idx, code := range currencies {
+		if errs[idx] != nil {
+			res.Errors = append(res.Errors, fxsource.CurrencyError{Currency: code, Err: errs[idx]})
 
-	for currency := range currenciesToFetch {
-		if err := i.fetchAllRateCurrency(ctx, currency, func(rate fxsource.Rate) {
-			rates = append(rates, rate)
-		}); err != nil {
-			return nil, err
+			continue
 		}
+
+		res.Rates = append(res.Rates, results[idx]...)
 	}
 
-	return &fxsource.FetchAllRatesResponse{
-		Rates: rates,
-	}, nil
+	if len(currencies) > 0 && len(res.Errors) == len(currencies) {
+		return nil, errors.Wrap(res.Errors[0], "cannot fetch any base currency")
+	}
+
+	// cryptocurrencies come with the inverse of their prices, quoted in fiat currencies
+	sortRates(res.Rates)
+
+	return res, nil
+}
+
+func (i *Client) maxConcurrency(jobs int) int {
+	maxConcurrency := i.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+
+	if jobs < maxConcurrency {
+		return jobs
+	}
+
+	return maxConcurrency
 }
 
-func (i *Client) fetchAllRateCurrency(ctx context.Context, currency string, addRate func(fxsource.Rate)) error {
+// fetchAllRateCurrency : rates from the currency, sorted by quote currency
+func (i *Client) fetchAllRateCurrency(ctx context.Context, code string) ([]fxsource.Rate, error) {
 	url := fmt.Sprintf("%s/%s?from=%s&api_key=%s",
 		APIURL,
 		FetchAllEndpoint,
-		currency,
+		code,
 		i.APIKey,
 	)
 
 	var response clientFetchAllResponse
 	if err := i.fetch(ctx, url, &response); err != nil {
-		return errors.Wrap(err, "cannot fetch rate")
+		return nil, errors.Wrap(err, "cannot fetch rate")
 	}
 
 	timestamp, err := time.Parse(time.DateTime, response.Updated)
 	if err != nil {
 		logger.WithError(err).WithField("response", response).Error("invalid timestamp")
 
-		return errors.Wrap(err, "invalid timestamp")
-	}
-
-	for toCurrency, rate := range response.Results {
-		addRate(fxsource.Rate{
-			From:      currency,
-			To:        toCurrency,
-			Rate:      rate,
-			Timestamp: timestamp.UTC().Unix(),
-		})
+		return nil, errors.Wrap(err, "invalid timestamp")
 	}
 
-	return nil
+	return ratesFromResults(code, response.Results, timestamp.UTC().Unix()), nil
 }
 
 func (i *Client) fetch(ctx context.Context, url string, resp interface{}) error {
@@ -151,15 +232,16 @@ func (i *Client) fetch(ctx context.Context, url string, resp interface{}) error
 
 	httpResp, err := i.HTTPClient.Do(httpReq)
 	if err != nil {
-		return err
+		return redactURL(err)
 	}
 
 	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != 200 {
+	// retries and Retry-After are handled by the HTTP client, see httpclient.Resilient
+	if httpResp.StatusCode != http.StatusOK {
 		logger.WithField("status-code", httpResp.StatusCode).Error("status code != 200")
 
-		return errors.New("status code != 200")
+		return errors.Errorf("status code %d != 200", httpResp.StatusCode)
 	}
 
 	body, err := io.ReadAll(httpResp.Body)
@@ -176,6 +258,41 @@ func (i *Client) fetch(ctx context.Context, url string, resp interface{}) error
 	return nil
 }
 
+// Ping : checks that the API can be reached with the API key, through the usage endpoint so that no call is charged
+func (i *Client) Ping(ctx context.Context) error {
+	url := fmt.Sprintf("%s/%s?api_key=%s", APIURL, UsageEndpoint, i.APIKey)
+
+	httpReq, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+
+	httpReq.Header.Add("accept", "application/json")
+
+	pingClient := i.PingClient
+	if pingClient == nil {
+		pingClient = http.DefaultClient
+	}
+
+	httpResp, err := pingClient.Do(httpReq)
+	if err != nil {
+		return errors.Wrap(redactURL(err), "cannot reach fastforex")
+	}
+
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return errors.Errorf("status code %d != 200", httpResp.StatusCode)
+	}
+
+	return nil
+}
+
+func (i *Client) Calls() int64 {
+	if i.Meter == nil {
+		return 0
+	}
+
+	return i.Meter.Calls()
+}
+
 func NewClient(apiKey string, httpClient httpclient.Client) *Client {
 	return &Client{
 		APIKey:     apiKey,
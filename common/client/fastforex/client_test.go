@@ -2,9 +2,11 @@ package fastforex
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/lruggieri/fxnow/common/client/httpclient"
 	"github.com/lruggieri/fxnow/common/fxsource"
 	"github.com/lruggieri/fxnow/common/logger"
 	"github.com/lruggieri/fxnow/common/logger/zap"
@@ -263,9 +266,11 @@ func TestImpl_FetchAll(t *testing.T) {
 			res *fxsource.FetchAllRatesResponse,
 			err error,
 		)
+		expectedCalls int64
 	}{
 		{
-			name: "error-wrong-response",
+			name:          "error-wrong-response",
+			expectedCalls: 1,
 			args: args{
 				ctx: context.Background(),
 				req: fxsource.FetchAllRatesRequest{
@@ -291,7 +296,8 @@ func TestImpl_FetchAll(t *testing.T) {
 			},
 		},
 		{
-			name: "error-timestamp-format",
+			name:          "error-timestamp-format",
+			expectedCalls: 1,
 			args: args{
 				ctx: context.Background(),
 				req: fxsource.FetchAllRatesRequest{
@@ -325,7 +331,8 @@ func TestImpl_FetchAll(t *testing.T) {
 			},
 		},
 		{
-			name: "happy-path",
+			name:          "happy-path",
+			expectedCalls: 1,
 			args: args{
 				ctx: context.Background(),
 				req: fxsource.FetchAllRatesRequest{
@@ -374,6 +381,52 @@ func TestImpl_FetchAll(t *testing.T) {
 				))
 			},
 		},
+		{
+			// failed calls are charged too
+			name:          "partial-results",
+			expectedCalls: 3,
+			args: args{
+				ctx: context.Background(),
+				req: fxsource.FetchAllRatesRequest{
+					Limit: []string{"USD", "GBP", "EUR"},
+				},
+			},
+			mock: func(args args, d deps) {
+				d.httpClient.EXPECT().Do(mock.Anything).RunAndReturn(func(req *http.Request) (*http.Response, error) {
+					from := req.URL.Query().Get("from")
+					if from == "GBP" {
+						return &http.Response{
+							StatusCode: http.StatusInternalServerError,
+							Body:       io.NopCloser(strings.NewReader(``)),
+						}, nil
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body: io.NopCloser(strings.NewReader(`{
+							  "base": "` + from + `",
+							  "results": {
+								"JPY": 42.42,
+								"CHF": 42.43
+							  },
+							  "updated": "` + nowFormatted + `",
+							  "ms": 11
+							}`)),
+					}, nil
+				}).Times(3)
+			},
+			assertion: func(t *testing.T, res *fxsource.FetchAllRatesResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []fxsource.Rate{
+					{From: "EUR", To: "CHF", Rate: 42.43, Timestamp: now.Unix()},
+					{From: "EUR", To: "JPY", Rate: 42.42, Timestamp: now.Unix()},
+					{From: "USD", To: "CHF", Rate: 42.43, Timestamp: now.Unix()},
+					{From: "USD", To: "JPY", Rate: 42.42, Timestamp: now.Unix()},
+				}, res.Rates)
+				assert.Len(t, res.Errors, 1)
+				assert.Equal(t, "GBP", res.Errors[0].Currency)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -383,9 +436,12 @@ func TestImpl_FetchAll(t *testing.T) {
 				httpClient: mockhttpclient.NewClient(t),
 			}
 
+			meter := &httpclient.Meter{Client: d.httpClient}
+
 			l := Client{
 				APIKey:     apiKey,
-				HTTPClient: d.httpClient,
+				HTTPClient: meter,
+				Meter:      meter,
 			}
 
 			tc.mock(tc.args, d)
@@ -394,7 +450,51 @@ func TestImpl_FetchAll(t *testing.T) {
 
 			tc.assertion(t, res, err)
 
+			assert.Equal(t, tc.expectedCalls, l.Calls())
 			assert.True(t, d.httpClient.AssertExpectations(t))
 		})
 	}
 }
+
+func TestImpl_Ping(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	httpClient := mockhttpclient.NewClient(t)
+	meter := &httpclient.Meter{Client: mockhttpclient.NewClient(t)}
+
+	// probes do not go through the client of the updates, nor its circuit breaker
+	l := &Client{
+		APIKey:     "api-key",
+		HTTPClient: meter,
+		Meter:      meter,
+		PingClient: httpClient,
+	}
+
+	httpClient.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		assert.Equal(t, fmt.Sprintf("%s/%s?api_key=api-key", APIURL, UsageEndpoint), req.URL.String())
+	}).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"usage":{}}`)),
+	}, nil).Once()
+
+	assert.Nil(t, l.Ping(context.Background()))
+
+	httpClient.EXPECT().Do(mock.Anything).Return(&http.Response{
+		StatusCode: http.StatusUnauthorized,
+		Body:       io.NopCloser(strings.NewReader(`{"error":"invalid api key"}`)),
+	}, nil).Once()
+
+	assert.Error(t, l.Ping(context.Background()))
+
+	// transport errors must not leak the API key
+	httpClient.EXPECT().Do(mock.Anything).RunAndReturn(func(req *http.Request) (*http.Response, error) {
+		return nil, &url.Error{Op: "Get", URL: req.URL.String(), Err: errors.New("connection refused")}
+	}).Once()
+
+	err := l.Ping(context.Background())
+	assert.ErrorContains(t, err, fmt.Sprintf("%s/%s", APIURL, UsageEndpoint))
+	assert.NotContains(t, err.Error(), "api-key")
+
+	// the usage endpoint is not charged
+	assert.Equal(t, int64(0), l.Calls())
+}
@@ -0,0 +1,122 @@
+package fastforex
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/currency"
+	"github.com/lruggieri/fxnow/common/fxsource"
+)
+
+const (
+	// CryptoFetchPricesEndpoint : prices of cryptocurrency pairs (e.g. "BTC/USD")
+	CryptoFetchPricesEndpoint = "crypto/fetch-prices"
+)
+
+// defaultCryptoQuotes : quote currencies of the cryptocurrencies requested to FetchAllRates without any fiat currency
+var defaultCryptoQuotes = []string{"EUR", "USD"}
+
+type clientCryptoPricesResponse struct {
+	Error  string             `json:"error"`
+	Prices map[string]float64 `json:"prices"` // "BTC/USD" -> price
+	Ms     int                `json:"ms"`
+}
+
+// fetchCryptoCurrency : prices of the cryptocurrency in the quote currencies, along with their inverse, sorted by base
+// and quote currency. The provider does not date crypto prices, so they are timestamped on receipt.
+func (i *Client) fetchCryptoCurrency(ctx context.Context, crypto string, quotes []string) ([]fxsource.Rate, error) {
+	pairs := make([]string, 0, len(quotes))
+
+	for _, quote := range quotes {
+		if quote != crypto {
+			pairs = append(pairs, crypto+"/"+quote)
+		}
+	}
+
+	if len(pairs) == 0 {
+		return nil, errors.New("no quote currencies")
+	}
+
+	query := url.Values{}
+	query.Set("pairs", strings.Join(pairs, ","))
+
+	var response clientCryptoPricesResponse
+	if err := i.fetch(ctx, i.endpointURL(CryptoFetchPricesEndpoint, query), &response); err != nil {
+		return nil, errors.Wrap(err, "cannot fetch crypto prices")
+	}
+
+	timestamp := time.Now().UTC().Unix()
+	rates := make([]fxsource.Rate, 0, 2*len(response.Prices))
+
+	for pair, price := range response.Prices {
+		from, to, ok := strings.Cut(pair, "/")
+		if !ok || price <= 0 {
+			return nil, errors.Errorf("invalid price of '%s'", pair)
+		}
+
+		rates = append(rates,
+			fxsource.Rate{From: from, To: to, Rate: price, Timestamp: timestamp},
+			fxsource.Rate{From: to, To: from, Rate: currency.RoundRate(1 / price), Timestamp: timestamp},
+		)
+	}
+
+	sortRates(rates)
+
+	return rates, nil
+}
+
+// cryptoQuotes : quote currencies of the cryptocurrencies, the fiat ones requested along with them if any
+func cryptoQuotes(limit []string) []string {
+	quotes := make([]string, 0)
+
+	for _, c := range limit {
+		if _, ok := fiatCurrencies[c]; ok {
+			quotes = append(quotes, c)
+		}
+	}
+
+	if len(quotes) == 0 {
+		return defaultCryptoQuotes
+	}
+
+	sort.Strings(quotes)
+
+	return quotes
+}
+
+// sortRates : sorts by base and quote currency
+func sortRates(rates []fxsource.Rate) {
+	sort.Slice(rates, func(a, b int) bool {
+		if rates[a].From != rates[b].From {
+			return rates[a].From < rates[b].From
+		}
+
+		return rates[a].To < rates[b].To
+	})
+}
+
+// fetchCryptoRate : the provider quotes cryptocurrencies only as base currency, other rates are inverted
+func (i *Client) fetchCryptoRate(ctx context.Context, req fxsource.FetchRateRequest) (*fxsource.FetchRateResponse, error) {
+	crypto, quote := req.From, req.To
+	if !currency.IsCrypto(crypto) {
+		crypto, quote = quote, crypto
+	}
+
+	rates, err := i.fetchCryptoCurrency(ctx, crypto, []string{quote})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot fetch rate")
+	}
+
+	for _, rate := range rates {
+		if rate.From == req.From && rate.To == req.To {
+			return &fxsource.FetchRateResponse{Rate: rate}, nil
+		}
+	}
+
+	return nil, errors.New("result is incorrect")
+}
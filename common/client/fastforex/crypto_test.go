@@ -0,0 +1,170 @@
+package fastforex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lruggieri/fxnow/common/currency"
+	"github.com/lruggieri/fxnow/common/fxsource"
+	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/logger/zap"
+	mockhttpclient "github.com/lruggieri/fxnow/common/mock/client/httpclient"
+)
+
+// withoutTimestamps : crypto prices are timestamped on receipt
+func withoutTimestamps(rates []fxsource.Rate) []fxsource.Rate {
+	res := make([]fxsource.Rate, 0, len(rates))
+
+	for _, rate := range rates {
+		rate.Timestamp = 0
+		res = append(res, rate)
+	}
+
+	return res
+}
+
+func TestClient_FetchRate_crypto(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	tests := []struct {
+		name      string
+		req       fxsource.FetchRateRequest
+		mock      func(c *mockhttpclient.Client)
+		assertion func(t *testing.T, res *fxsource.FetchRateResponse, err error)
+	}{
+		{
+			name: "error-invalid-price",
+			req:  fxsource.FetchRateRequest{From: "BTC", To: "USD"},
+			mock: func(c *mockhttpclient.Client) {
+				mockFetch(c, t, APIURL+"/crypto/fetch-prices?api_key=api-key&pairs=BTC%2FUSD",
+					`{"prices": {"BTC/USD": 0}, "ms": 4}`)
+			},
+			assertion: func(t *testing.T, res *fxsource.FetchRateResponse, err error) {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			},
+		},
+		{
+			name: "crypto-base",
+			req:  fxsource.FetchRateRequest{From: "BTC", To: "USD"},
+			mock: func(c *mockhttpclient.Client) {
+				mockFetch(c, t, APIURL+"/crypto/fetch-prices?api_key=api-key&pairs=BTC%2FUSD",
+					`{"prices": {"BTC/USD": 67123.45}, "ms": 4}`)
+			},
+			assertion: func(t *testing.T, res *fxsource.FetchRateResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "BTC", res.From)
+				assert.Equal(t, "USD", res.To)
+				assert.Equal(t, 67123.45, res.Rate.Rate)
+				assert.NotZero(t, res.Timestamp)
+			},
+		},
+		{
+			name: "crypto-quote",
+			req:  fxsource.FetchRateRequest{From: "USD", To: "BTC"},
+			mock: func(c *mockhttpclient.Client) {
+				mockFetch(c, t, APIURL+"/crypto/fetch-prices?api_key=api-key&pairs=BTC%2FUSD",
+					`{"prices": {"BTC/USD": 67123.45}, "ms": 4}`)
+			},
+			assertion: func(t *testing.T, res *fxsource.FetchRateResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "USD", res.From)
+				assert.Equal(t, "BTC", res.To)
+				assert.Equal(t, currency.RoundRate(1/67123.45), res.Rate.Rate)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			c := mockhttpclient.NewClient(t)
+			l := Client{APIKey: "api-key", HTTPClient: c}
+
+			tc.mock(c)
+
+			res, err := l.FetchRate(context.Background(), tc.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestClient_FetchAllRates_crypto(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	tests := []struct {
+		name      string
+		req       fxsource.FetchAllRatesRequest
+		mock      func(c *mockhttpclient.Client)
+		assertion func(t *testing.T, res *fxsource.FetchAllRatesResponse, err error)
+	}{
+		{
+			name: "default-quotes",
+			req:  fxsource.FetchAllRatesRequest{Limit: []string{"ETH"}},
+			mock: func(c *mockhttpclient.Client) {
+				mockFetch(c, t, APIURL+"/crypto/fetch-prices?api_key=api-key&pairs=ETH%2FEUR%2CETH%2FUSD",
+					`{"prices": {"ETH/USD": 2000, "ETH/EUR": 1600}, "ms": 4}`)
+			},
+			assertion: func(t *testing.T, res *fxsource.FetchAllRatesResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []fxsource.Rate{
+					{From: "ETH", To: "EUR", Rate: 1600},
+					{From: "ETH", To: "USD", Rate: 2000},
+					{From: "EUR", To: "ETH", Rate: 0.000625},
+					{From: "USD", To: "ETH", Rate: 0.0005},
+				}, withoutTimestamps(res.Rates))
+			},
+		},
+		{
+			name: "crypto-and-fiat",
+			req:  fxsource.FetchAllRatesRequest{Limit: []string{"USDT", "JPY"}},
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).RunAndReturn(func(req *http.Request) (*http.Response, error) {
+					body := `{"prices": {"USDT/JPY": 150}, "ms": 4}`
+
+					if req.URL.Path == "/"+FetchAllEndpoint {
+						assert.Equal(t, "JPY", req.URL.Query().Get("from"))
+
+						body = `{"base": "JPY", "results": {"USD": 0.0067}, "updated": "2023-10-02 10:00:00", "ms": 3}`
+					} else {
+						assert.Equal(t, "USDT/JPY", req.URL.Query().Get("pairs"))
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(body)),
+					}, nil
+				}).Twice()
+			},
+			assertion: func(t *testing.T, res *fxsource.FetchAllRatesResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []fxsource.Rate{
+					{From: "JPY", To: "USD", Rate: 0.0067},
+					{From: "JPY", To: "USDT", Rate: currency.RoundRate(1.0 / 150)},
+					{From: "USDT", To: "JPY", Rate: 150},
+				}, withoutTimestamps(res.Rates))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			c := mockhttpclient.NewClient(t)
+			l := Client{APIKey: "api-key", HTTPClient: c}
+
+			tc.mock(c)
+
+			res, err := l.FetchAllRates(context.Background(), tc.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package fastforex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/fxsource"
+)
+
+const (
+	// MaxTimeSeriesDays : longest range of days of a time-series request
+	MaxTimeSeriesDays = 365
+)
+
+type clientHistoricalResponse struct {
+	Error   string             `json:"error"`
+	Date    string             `json:"date"`
+	Base    string             `json:"base"`
+	Results map[string]float64 `json:"results"`
+	Ms      int                `json:"ms"`
+}
+
+type clientTimeSeriesResponse struct {
+	Error    string                        `json:"error"`
+	Start    string                        `json:"start"`
+	End      string                        `json:"end"`
+	Interval string                        `json:"interval"`
+	Base     string                        `json:"base"`
+	Results  map[string]map[string]float64 `json:"results"` // quote currency -> day -> rate
+	Ms       int                           `json:"ms"`
+}
+
+func (i *Client) FetchMultiRates(
+	ctx context.Context, req fxsource.FetchMultiRatesRequest,
+) (*fxsource.FetchMultiRatesResponse, error) {
+	if len(req.To) == 0 {
+		return nil, errors.New("no quote currencies")
+	}
+
+	query := url.Values{}
+	query.Set("from", req.From)
+	query.Set("to", strings.Join(req.To, ","))
+
+	var response clientFetchAllResponse
+	if err := i.fetch(ctx, i.endpointURL(FetchMultiEndpoint, query), &response); err != nil {
+		return nil, errors.Wrap(err, "cannot fetch rates")
+	}
+
+	timestamp, err := time.Parse(time.DateTime, response.Updated)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid timestamp")
+	}
+
+	return &fxsource.FetchMultiRatesResponse{
+		Rates: ratesFromResults(req.From, response.Results, timestamp.UTC().Unix()),
+	}, nil
+}
+
+func (i *Client) FetchHistoricalRates(
+	ctx context.Context, req fxsource.FetchHistoricalRatesRequest,
+) (*fxsource.FetchHistoricalRatesResponse, error) {
+	query := url.Values{}
+	query.Set("date", req.Date.UTC().Format(time.DateOnly))
+	query.Set("from", req.From)
+
+	if len(req.To) > 0 {
+		query.Set("to", strings.Join(req.To, ","))
+	}
+
+	var response clientHistoricalResponse
+	if err := i.fetch(ctx, i.endpointURL(HistoricalEndpoint, query), &response); err != nil {
+		return nil, errors.Wrap(err, "cannot fetch historical rates")
+	}
+
+	day, err := time.Parse(time.DateOnly, response.Date)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid date")
+	}
+
+	return &fxsource.FetchHistoricalRatesResponse{
+		Rates: ratesFromResults(req.From, response.Results, day.Unix()),
+	}, nil
+}
+
+func (i *Client) FetchTimeSeries(
+	ctx context.Context, req fxsource.FetchTimeSeriesRequest,
+) (*fxsource.FetchTimeSeriesResponse, error) {
+	if len(req.To) == 0 {
+		return nil, errors.New("no quote currencies")
+	}
+
+	start := req.Start.UTC().Truncate(24 * time.Hour)
+	end := req.End.UTC().Truncate(24 * time.Hour)
+
+	if end.Before(start) || end.Sub(start) >= MaxTimeSeriesDays*24*time.Hour {
+		return nil, errors.Errorf("the range must be between 1 and %d days", MaxTimeSeriesDays)
+	}
+
+	query := url.Values{}
+	query.Set("from", req.From)
+	query.Set("to", strings.Join(req.To, ","))
+	query.Set("start", start.Format(time.DateOnly))
+	query.Set("end", end.Format(time.DateOnly))
+
+	var response clientTimeSeriesResponse
+	if err := i.fetch(ctx, i.endpointURL(TimeSeriesEndpoint, query), &response); err != nil {
+		return nil, errors.Wrap(err, "cannot fetch time series")
+	}
+
+	rates := make([]fxsource.Rate, 0)
+
+	for to, days := range response.Results {
+		for day, rate := range days {
+			date, err := time.Parse(time.DateOnly, day)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid date")
+			}
+
+			rates = append(rates, fxsource.Rate{
+				From:      req.From,
+				To:        to,
+				Rate:      rate,
+				Timestamp: date.Unix(),
+			})
+		}
+	}
+
+	sort.Slice(rates, func(a, b int) bool {
+		if rates[a].To != rates[b].To {
+			return rates[a].To < rates[b].To
+		}
+
+		return rates[a].Timestamp < rates[b].Timestamp
+	})
+
+	return &fxsource.FetchTimeSeriesResponse{
+		Rates: rates,
+	}, nil
+}
+
+func (i *Client) endpointURL(endpoint string, query url.Values) string {
+	query.Set("api_key", i.APIKey)
+
+	return fmt.Sprintf("%s/%s?%s", APIURL, endpoint, query.Encode())
+}
+
+// redactURL : strips the query from the URL reported by transport errors, as it carries the API key
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL, _, _ = strings.Cut(urlErr.URL, "?")
+	}
+
+	return err
+}
+
+// ratesFromResults : rates sorted by quote currency
+func ratesFromResults(from string, results map[string]float64, timestamp int64) []fxsource.Rate {
+	rates := make([]fxsource.Rate, 0, len(results))
+
+	for to, rate := range results {
+		rates = append(rates, fxsource.Rate{
+			From:      from,
+			To:        to,
+			Rate:      rate,
+			Timestamp: timestamp,
+		})
+	}
+
+	sort.Slice(rates, func(a, b int) bool {
+		return rates[a].To < rates[b].To
+	})
+
+	return rates
+}
@@ -0,0 +1,225 @@
+package fastforex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lruggieri/fxnow/common/fxsource"
+	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/logger/zap"
+	mockhttpclient "github.com/lruggieri/fxnow/common/mock/client/httpclient"
+)
+
+func mockFetch(c *mockhttpclient.Client, t *testing.T, expectedURL, body string) {
+	c.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		assert.Equal(t, expectedURL, req.URL.String())
+	}).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil).Once()
+}
+
+func TestClient_FetchMultiRates(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name      string
+		req       fxsource.FetchMultiRatesRequest
+		mock      func(c *mockhttpclient.Client)
+		assertion func(t *testing.T, res *fxsource.FetchMultiRatesResponse, err error)
+	}{
+		{
+			name: "error-no-quote-currencies",
+			req:  fxsource.FetchMultiRatesRequest{From: "USD"},
+			mock: func(c *mockhttpclient.Client) {},
+			assertion: func(t *testing.T, res *fxsource.FetchMultiRatesResponse, err error) {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			},
+		},
+		{
+			name: "happy-path",
+			req:  fxsource.FetchMultiRatesRequest{From: "USD", To: []string{"JPY", "EUR"}},
+			mock: func(c *mockhttpclient.Client) {
+				mockFetch(c, t, APIURL+"/fetch-multi?api_key=api-key&from=USD&to=JPY%2CEUR", `{
+					"base": "USD",
+					"results": {"JPY": 149.8, "EUR": 0.94},
+					"updated": "`+now.Format(time.DateTime)+`",
+					"ms": 5
+				}`)
+			},
+			assertion: func(t *testing.T, res *fxsource.FetchMultiRatesResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []fxsource.Rate{
+					{From: "USD", To: "EUR", Rate: 0.94, Timestamp: now.Unix()},
+					{From: "USD", To: "JPY", Rate: 149.8, Timestamp: now.Unix()},
+				}, res.Rates)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			c := mockhttpclient.NewClient(t)
+			l := Client{APIKey: "api-key", HTTPClient: c}
+
+			tc.mock(c)
+
+			res, err := l.FetchMultiRates(context.Background(), tc.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestClient_FetchHistoricalRates(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	day := time.Date(2023, time.October, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		req       fxsource.FetchHistoricalRatesRequest
+		mock      func(c *mockhttpclient.Client)
+		assertion func(t *testing.T, res *fxsource.FetchHistoricalRatesResponse, err error)
+	}{
+		{
+			name: "error-invalid-date",
+			req:  fxsource.FetchHistoricalRatesRequest{From: "USD", Date: day},
+			mock: func(c *mockhttpclient.Client) {
+				mockFetch(c, t, APIURL+"/historical?api_key=api-key&date=2023-10-02&from=USD",
+					`{"date": "2 October", "base": "USD", "results": {"EUR": 0.95}}`)
+			},
+			assertion: func(t *testing.T, res *fxsource.FetchHistoricalRatesResponse, err error) {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			},
+		},
+		{
+			name: "happy-path",
+			req:  fxsource.FetchHistoricalRatesRequest{From: "USD", To: []string{"EUR"}, Date: day.Add(15 * time.Hour)},
+			mock: func(c *mockhttpclient.Client) {
+				mockFetch(c, t, APIURL+"/historical?api_key=api-key&date=2023-10-02&from=USD&to=EUR",
+					`{"date": "2023-10-02", "base": "USD", "results": {"EUR": 0.95}, "ms": 3}`)
+			},
+			assertion: func(t *testing.T, res *fxsource.FetchHistoricalRatesResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []fxsource.Rate{
+					{From: "USD", To: "EUR", Rate: 0.95, Timestamp: day.Unix()},
+				}, res.Rates)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			c := mockhttpclient.NewClient(t)
+			l := Client{APIKey: "api-key", HTTPClient: c}
+
+			tc.mock(c)
+
+			res, err := l.FetchHistoricalRates(context.Background(), tc.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestClient_FetchTimeSeries(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	start := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		req       fxsource.FetchTimeSeriesRequest
+		mock      func(c *mockhttpclient.Client)
+		assertion func(t *testing.T, res *fxsource.FetchTimeSeriesResponse, err error)
+	}{
+		{
+			name: "error-range-too-long",
+			req: fxsource.FetchTimeSeriesRequest{
+				From:  "USD",
+				To:    []string{"EUR"},
+				Start: start,
+				End:   start.AddDate(1, 0, 0),
+			},
+			mock: func(c *mockhttpclient.Client) {},
+			assertion: func(t *testing.T, res *fxsource.FetchTimeSeriesResponse, err error) {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			},
+		},
+		{
+			name: "error-inverted-range",
+			req: fxsource.FetchTimeSeriesRequest{
+				From:  "USD",
+				To:    []string{"EUR"},
+				Start: start,
+				End:   start.AddDate(0, 0, -1),
+			},
+			mock: func(c *mockhttpclient.Client) {},
+			assertion: func(t *testing.T, res *fxsource.FetchTimeSeriesResponse, err error) {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			},
+		},
+		{
+			name: "happy-path",
+			req: fxsource.FetchTimeSeriesRequest{
+				From:  "USD",
+				To:    []string{"JPY", "EUR"},
+				Start: start,
+				End:   start.AddDate(0, 0, 1),
+			},
+			mock: func(c *mockhttpclient.Client) {
+				mockFetch(c, t,
+					APIURL+"/time-series?api_key=api-key&end=2023-10-02&from=USD&start=2023-10-01&to=JPY%2CEUR", `{
+						"start": "2023-10-01",
+						"end": "2023-10-02",
+						"interval": "P1D",
+						"base": "USD",
+						"results": {
+							"JPY": {"2023-10-02": 149.9, "2023-10-01": 149.3},
+							"EUR": {"2023-10-01": 0.94, "2023-10-02": 0.95}
+						},
+						"ms": 7
+					}`)
+			},
+			assertion: func(t *testing.T, res *fxsource.FetchTimeSeriesResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []fxsource.Rate{
+					{From: "USD", To: "EUR", Rate: 0.94, Timestamp: start.Unix()},
+					{From: "USD", To: "EUR", Rate: 0.95, Timestamp: start.AddDate(0, 0, 1).Unix()},
+					{From: "USD", To: "JPY", Rate: 149.3, Timestamp: start.Unix()},
+					{From: "USD", To: "JPY", Rate: 149.9, Timestamp: start.AddDate(0, 0, 1).Unix()},
+				}, res.Rates)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			c := mockhttpclient.NewClient(t)
+			l := Client{APIKey: "api-key", HTTPClient: c}
+
+			tc.mock(c)
+
+			res, err := l.FetchTimeSeries(context.Background(), tc.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
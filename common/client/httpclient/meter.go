@@ -0,0 +1,28 @@
+package httpclient
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// Meter : Client decorator counting the requests answered by the server, whatever their status, as metered APIs
+// charge them. Placed beneath Resilient, every attempt is counted, retries included.
+type Meter struct {
+	Client Client
+
+	calls atomic.Int64
+}
+
+func (m *Meter) Do(req *http.Request) (*http.Response, error) {
+	resp, err := m.Client.Do(req)
+	if err == nil {
+		m.calls.Add(1)
+	}
+
+	return resp, err
+}
+
+// Calls : requests answered since the meter was created
+func (m *Meter) Calls() int64 {
+	return m.calls.Load()
+}
@@ -0,0 +1,39 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockhttpclient "github.com/lruggieri/fxnow/common/mock/client/httpclient"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+)
+
+func TestMeter_Do(t *testing.T) {
+	c := mockhttpclient.NewClient(t)
+	clk := mockclock.NewClock(t)
+	clk.EXPECT().Now().Return(time.Unix(1700000000, 0)).Maybe()
+
+	meter := &Meter{Client: c}
+
+	r := NewResilient(meter, ResilientConfig{MaxAttempts: 3}, clk)
+	r.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.fastforex.io/fetch-all", nil)
+	assert.Nil(t, err)
+
+	// every answered attempt is counted, unanswered ones are not
+	c.EXPECT().Do(mock.Anything).Return(nil, errors.New("connection reset")).Once()
+	c.EXPECT().Do(mock.Anything).Return(response(http.StatusBadGateway), nil).Once()
+	c.EXPECT().Do(mock.Anything).Return(response(http.StatusOK), nil).Once()
+
+	resp, err := r.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(2), meter.Calls())
+}
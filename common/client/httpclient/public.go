@@ -0,0 +1,55 @@
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrForbiddenAddress : the destination is not a public address
+var ErrForbiddenAddress = errors.New("forbidden address")
+
+// sharedAddressSpace : carrier-grade NAT range (RFC 6598), used internally by some cloud providers
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicIP : whether the address is reachable on the internet, as opposed to private, loopback, link-local (e.g.
+// cloud metadata endpoints) and other reserved addresses
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified() && !sharedAddressSpace.Contains(ip)
+}
+
+// NewPublic : HTTP client for URLs provided by users (e.g. webhooks), refusing to connect to non-public addresses to
+// prevent server-side request forgery. Addresses are checked when dialing, after the DNS resolution, so that checks
+// cannot be bypassed by DNS rebinding or redirects.
+func NewPublic() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   publicOnly,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be dialed in place of the destination
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Transport: transport}
+}
+
+// publicOnly : called with the resolved address of every connection
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(ErrForbiddenAddress, err.Error())
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+		return errors.Wrapf(ErrForbiddenAddress, "cannot connect to %s", host)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip       string
+		expected bool
+	}{
+		{ip: "93.184.216.34", expected: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", expected: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "100.64.0.1"},
+		{ip: "0.0.0.0"},
+		{ip: "fd00:ec2::254"},
+		{ip: "fe80::1"},
+		{ip: "::ffff:127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.ip, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsPublicIP(net.ParseIP(tc.ip)))
+		})
+	}
+}
+
+func TestNewPublic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// the server listens on a loopback address
+	_, err := NewPublic().Get(server.URL)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+
+	_, err = NewPublic().Get("http://localhost:1/hook")
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+}
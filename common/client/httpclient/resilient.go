@@ -0,0 +1,331 @@
+package httpclient
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/clock"
+)
+
+const (
+	DefaultMaxAttempts      = 3
+	DefaultBaseBackoff      = 200 * time.Millisecond
+	DefaultMaxBackoff       = 5 * time.Second
+	DefaultMaxRetryAfter    = 30 * time.Second
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = 30 * time.Second
+)
+
+const (
+	CircuitClosed CircuitState = iota
+	// CircuitOpen : requests to the host are rejected without being sent
+	CircuitOpen
+	// CircuitHalfOpen : a single probe request is let through, to decide whether the circuit can close again
+	CircuitHalfOpen
+)
+
+// ErrCircuitOpen : the request was not sent, because its host keeps failing
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+type CircuitState uint8
+
+func (cs CircuitState) String() string {
+	switch cs {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "undefined"
+	}
+}
+
+type ResilientConfig struct {
+	// MaxAttempts : attempts of idempotent requests, including the first one
+	MaxAttempts int
+	// BaseBackoff : maximum delay before the first retry, doubled at every attempt up to MaxBackoff. The actual delay
+	// is a random value up to this maximum.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// MaxRetryAfter : longest Retry-After honored, responses asking to wait longer are returned as they are
+	MaxRetryAfter time.Duration
+	// FailureThreshold : consecutive failures opening the circuit of a host
+	FailureThreshold int
+	// OpenTimeout : how long a circuit stays open before a probe request is let through
+	OpenTimeout time.Duration
+}
+
+// Resilient : Client decorator retrying failed idempotent requests, and rejecting the requests to hosts that keep
+// failing through a circuit breaker per host. Failures are transport errors and 5xx responses.
+type Resilient struct {
+	client Client
+	config ResilientConfig
+	clock  clock.Clock
+
+	// sleep : replaceable for testing
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	// probing : a probe request is in flight while half-open
+	probing bool
+}
+
+// NewResilient : zero values of the configuration are replaced by the defaults
+func NewResilient(client Client, config ResilientConfig, clk clock.Clock) *Resilient {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = DefaultBaseBackoff
+	}
+
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultMaxBackoff
+	}
+
+	if config.MaxRetryAfter <= 0 {
+		config.MaxRetryAfter = DefaultMaxRetryAfter
+	}
+
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultFailureThreshold
+	}
+
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = DefaultOpenTimeout
+	}
+
+	return &Resilient{
+		client:   client,
+		config:   config,
+		clock:    clk,
+		sleep:    sleep,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+func (r *Resilient) Do(req *http.Request) (*http.Response, error) {
+	attempts := 1
+	if isRetryable(req) {
+		attempts = r.config.MaxAttempts
+	}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if req, err = rewind(req); err != nil {
+				return nil, err
+			}
+		}
+
+		if err = r.acquire(req.URL.Host); err != nil {
+			return nil, err
+		}
+
+		resp, err = r.client.Do(req)
+
+		r.release(req.URL.Host, err == nil && resp.StatusCode < http.StatusInternalServerError)
+
+		if !shouldRetry(req, resp, err) || attempt == attempts-1 {
+			break
+		}
+
+		wait := r.backoff(attempt)
+
+		if retryAfter, ok := parseRetryAfter(resp, r.clock.Now()); ok {
+			if retryAfter > r.config.MaxRetryAfter {
+				break
+			}
+
+			wait = retryAfter
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		if err = r.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, err
+}
+
+// State : state of the circuit of every host contacted so far
+func (r *Resilient) State() map[string]CircuitState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	states := make(map[string]CircuitState, len(r.circuits))
+	for host, c := range r.circuits {
+		states[host] = r.stateOf(c)
+	}
+
+	return states
+}
+
+// stateOf : an open circuit is reported as half-open once a probe request can be sent
+func (r *Resilient) stateOf(c *circuit) CircuitState {
+	if c.state == CircuitOpen && r.clock.Now().Sub(c.openedAt) >= r.config.OpenTimeout {
+		return CircuitHalfOpen
+	}
+
+	return c.state
+}
+
+// acquire : whether a request can be sent to the host
+func (r *Resilient) acquire(host string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.circuits[host]
+	if !ok {
+		c = &circuit{}
+		r.circuits[host] = c
+	}
+
+	c.state = r.stateOf(c)
+
+	switch c.state {
+	case CircuitOpen:
+		return errors.Wrap(ErrCircuitOpen, host)
+	case CircuitHalfOpen:
+		if c.probing {
+			return errors.Wrap(ErrCircuitOpen, host)
+		}
+
+		c.probing = true
+	}
+
+	return nil
+}
+
+// release : records the outcome of a request sent to the host
+func (r *Resilient) release(host string, success bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := r.circuits[host]
+	c.probing = false
+
+	if success {
+		c.state = CircuitClosed
+		c.failures = 0
+
+		return
+	}
+
+	c.failures++
+
+	if c.state == CircuitHalfOpen || c.failures >= r.config.FailureThreshold {
+		c.state = CircuitOpen
+		c.openedAt = r.clock.Now()
+	}
+}
+
+// backoff : random delay up to BaseBackoff * 2^attempt, capped at MaxBackoff ("full jitter")
+func (r *Resilient) backoff(attempt int) time.Duration {
+	maxBackoff := r.config.BaseBackoff << attempt
+	if maxBackoff <= 0 || maxBackoff > r.config.MaxBackoff {
+		maxBackoff = r.config.MaxBackoff
+	}
+
+	//nolint:gosec // jitter does not need a secure source
+	return time.Duration(rand.Int63n(int64(maxBackoff) + 1))
+}
+
+// isRetryable : only idempotent requests whose body can be sent again are retried
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+	default:
+		return false
+	}
+
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// the caller gave up, or the circuit is open
+		return req.Context().Err() == nil
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot rewind request body")
+	}
+
+	retry := req.Clone(req.Context())
+	retry.Body = body
+
+	return retry, nil
+}
+
+// parseRetryAfter : Retry-After of 429 and 503 responses, either in seconds or as an HTTP date
+func parseRetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil ||
+		(resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
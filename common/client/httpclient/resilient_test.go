@@ -0,0 +1,215 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockhttpclient "github.com/lruggieri/fxnow/common/mock/client/httpclient"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+)
+
+func response(status int, headers ...string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+
+	for i := 0; i+1 < len(headers); i += 2 {
+		resp.Header.Set(headers[i], headers[i+1])
+	}
+
+	return resp
+}
+
+func TestResilient_Do(t *testing.T) {
+	testErr := errors.New("error")
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name           string
+		method         string
+		mock           func(c *mockhttpclient.Client)
+		expectedSleeps int
+		assertion      func(t *testing.T, resp *http.Response, err error, sleeps []time.Duration)
+	}{
+		{
+			name:   "error-not-idempotent",
+			method: http.MethodPost,
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Return(response(http.StatusInternalServerError), nil).Once()
+			},
+			assertion: func(t *testing.T, resp *http.Response, err error, sleeps []time.Duration) {
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+				assert.Empty(t, sleeps)
+			},
+		},
+		{
+			name:   "error-attempts-exhausted",
+			method: http.MethodGet,
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Return(nil, testErr).Times(DefaultMaxAttempts)
+			},
+			assertion: func(t *testing.T, resp *http.Response, err error, sleeps []time.Duration) {
+				assert.Nil(t, resp)
+				assert.ErrorIs(t, err, testErr)
+				assert.Len(t, sleeps, DefaultMaxAttempts-1)
+			},
+		},
+		{
+			name:   "error-retry-after-too-long",
+			method: http.MethodGet,
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Return(response(http.StatusTooManyRequests, "Retry-After", "120"), nil).
+					Once()
+			},
+			assertion: func(t *testing.T, resp *http.Response, err error, sleeps []time.Duration) {
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+				assert.Empty(t, sleeps)
+			},
+		},
+		{
+			name:   "happy-path-server-errors",
+			method: http.MethodGet,
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Return(response(http.StatusBadGateway), nil).Once()
+				c.EXPECT().Do(mock.Anything).Return(response(http.StatusOK), nil).Once()
+			},
+			assertion: func(t *testing.T, resp *http.Response, err error, sleeps []time.Duration) {
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Len(t, sleeps, 1)
+				assert.LessOrEqual(t, sleeps[0], DefaultBaseBackoff)
+			},
+		},
+		{
+			name:   "happy-path-retry-after-seconds",
+			method: http.MethodGet,
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Return(response(http.StatusTooManyRequests, "Retry-After", "2"), nil).Once()
+				c.EXPECT().Do(mock.Anything).Return(response(http.StatusOK), nil).Once()
+			},
+			assertion: func(t *testing.T, resp *http.Response, err error, sleeps []time.Duration) {
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, []time.Duration{2 * time.Second}, sleeps)
+			},
+		},
+		{
+			name:   "happy-path-retry-after-date",
+			method: http.MethodGet,
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Return(response(http.StatusServiceUnavailable,
+					"Retry-After", now.Add(5*time.Second).UTC().Format(http.TimeFormat)), nil).Once()
+				c.EXPECT().Do(mock.Anything).Return(response(http.StatusOK), nil).Once()
+			},
+			assertion: func(t *testing.T, resp *http.Response, err error, sleeps []time.Duration) {
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, []time.Duration{5 * time.Second}, sleeps)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			c := mockhttpclient.NewClient(t)
+			clk := mockclock.NewClock(t)
+			clk.EXPECT().Now().Return(now).Maybe()
+
+			var sleeps []time.Duration
+
+			r := NewResilient(c, ResilientConfig{}, clk)
+			r.sleep = func(ctx context.Context, d time.Duration) error {
+				sleeps = append(sleeps, d)
+
+				return nil
+			}
+
+			tc.mock(c)
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, "https://api.example.com/rates", nil)
+			assert.Nil(t, err)
+
+			resp, err := r.Do(req)
+
+			tc.assertion(t, resp, err, sleeps)
+		})
+	}
+}
+
+func TestResilient_CircuitBreaker(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	c := mockhttpclient.NewClient(t)
+	clk := mockclock.NewClock(t)
+	clk.EXPECT().Now().RunAndReturn(func() time.Time {
+		return now
+	}).Maybe()
+
+	r := NewResilient(c, ResilientConfig{
+		MaxAttempts:      1,
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+	}, clk)
+
+	do := func(host string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://"+host+"/rates", nil)
+		assert.Nil(t, err)
+
+		return r.Do(req)
+	}
+
+	// the circuit opens after consecutive failures
+	c.EXPECT().Do(mock.Anything).Return(response(http.StatusInternalServerError), nil).Twice()
+
+	for i := 0; i < 2; i++ {
+		_, err := do("api.example.com")
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, map[string]CircuitState{"api.example.com": CircuitOpen}, r.State())
+
+	_, err := do("api.example.com")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// other hosts are not affected
+	c.EXPECT().Do(mock.Anything).Return(response(http.StatusOK), nil).Once()
+
+	_, err = do("other.example.com")
+	assert.Nil(t, err)
+
+	// a failed probe opens the circuit again
+	now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, r.State()["api.example.com"])
+
+	c.EXPECT().Do(mock.Anything).Return(nil, errors.New("error")).Once()
+
+	_, err = do("api.example.com")
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, CircuitOpen, r.State()["api.example.com"])
+
+	// a successful probe closes it
+	now = now.Add(time.Minute)
+
+	c.EXPECT().Do(mock.Anything).Return(response(http.StatusOK), nil).Once()
+
+	resp, err := do("api.example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]CircuitState{
+		"api.example.com":   CircuitClosed,
+		"other.example.com": CircuitClosed,
+	}, r.State())
+}
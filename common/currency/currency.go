@@ -0,0 +1,102 @@
+// Package currency is the registry of the cryptocurrencies supported next to ISO 4217 currencies, along with the
+// precision their amounts and rates are expressed with.
+package currency
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	TypeUndefined Type = iota
+	TypeFiat
+	TypeCrypto
+)
+
+const (
+	// RateSignificantDigits : rates span from millions (e.g. BTC_IDR) to billionths (e.g. SHIB_BTC), so they are
+	// rounded to significant digits rather than decimals
+	RateSignificantDigits = 10
+)
+
+type Type uint8
+
+func (t Type) String() string {
+	switch t {
+	case TypeFiat:
+		return "fiat"
+	case TypeCrypto:
+		return "crypto"
+	default:
+		return "undefined"
+	}
+}
+
+type Currency struct {
+	Code string
+	Name string
+	Type Type
+	// Decimals : smallest fraction of an amount in the currency (e.g. 8 for BTC, the satoshi)
+	Decimals int
+}
+
+var cryptoCurrencies = map[string]Currency{
+	"ADA":   {Code: "ADA", Name: "Cardano", Type: TypeCrypto, Decimals: 6},
+	"AVAX":  {Code: "AVAX", Name: "Avalanche", Type: TypeCrypto, Decimals: 18},
+	"BCH":   {Code: "BCH", Name: "Bitcoin Cash", Type: TypeCrypto, Decimals: 8},
+	"BNB":   {Code: "BNB", Name: "BNB", Type: TypeCrypto, Decimals: 18},
+	"BTC":   {Code: "BTC", Name: "Bitcoin", Type: TypeCrypto, Decimals: 8},
+	"DAI":   {Code: "DAI", Name: "Dai", Type: TypeCrypto, Decimals: 18},
+	"DOGE":  {Code: "DOGE", Name: "Dogecoin", Type: TypeCrypto, Decimals: 8},
+	"DOT":   {Code: "DOT", Name: "Polkadot", Type: TypeCrypto, Decimals: 10},
+	"ETH":   {Code: "ETH", Name: "Ethereum", Type: TypeCrypto, Decimals: 18},
+	"LINK":  {Code: "LINK", Name: "Chainlink", Type: TypeCrypto, Decimals: 18},
+	"LTC":   {Code: "LTC", Name: "Litecoin", Type: TypeCrypto, Decimals: 8},
+	"MATIC": {Code: "MATIC", Name: "Polygon", Type: TypeCrypto, Decimals: 18},
+	"SHIB":  {Code: "SHIB", Name: "Shiba Inu", Type: TypeCrypto, Decimals: 18},
+	"SOL":   {Code: "SOL", Name: "Solana", Type: TypeCrypto, Decimals: 9},
+	"TRX":   {Code: "TRX", Name: "TRON", Type: TypeCrypto, Decimals: 6},
+	"USDC":  {Code: "USDC", Name: "USD Coin", Type: TypeCrypto, Decimals: 6},
+	"USDT":  {Code: "USDT", Name: "Tether", Type: TypeCrypto, Decimals: 6},
+	"XLM":   {Code: "XLM", Name: "Stellar", Type: TypeCrypto, Decimals: 7},
+	"XRP":   {Code: "XRP", Name: "XRP", Type: TypeCrypto, Decimals: 6},
+}
+
+// Crypto : the cryptocurrency with the code, false if it is not registered
+func Crypto(code string) (Currency, bool) {
+	c, ok := cryptoCurrencies[code]
+
+	return c, ok
+}
+
+func IsCrypto(code string) bool {
+	_, ok := cryptoCurrencies[code]
+
+	return ok
+}
+
+// CryptoCodes : codes of the registered cryptocurrencies, sorted
+func CryptoCodes() []string {
+	codes := make([]string, 0, len(cryptoCurrencies))
+
+	for code := range cryptoCurrencies {
+		codes = append(codes, code)
+	}
+
+	sort.Strings(codes)
+
+	return codes
+}
+
+// RoundRate : the rate rounded to RateSignificantDigits, so that rates computed by inversion do not carry floating
+// point noise
+func RoundRate(rate float64) float64 {
+	if rate == 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return rate
+	}
+
+	magnitude := int(math.Floor(math.Log10(math.Abs(rate)))) + 1
+	scale := math.Pow(10, float64(RateSignificantDigits-magnitude))
+
+	return math.Round(rate*scale) / scale
+}
@@ -0,0 +1,44 @@
+package currency
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrypto(t *testing.T) {
+	btc, ok := Crypto("BTC")
+	assert.True(t, ok)
+	assert.Equal(t, TypeCrypto, btc.Type)
+	assert.Equal(t, 8, btc.Decimals)
+
+	_, ok = Crypto("USD")
+	assert.False(t, ok)
+
+	assert.True(t, IsCrypto("USDT"))
+	assert.False(t, IsCrypto("usdt"))
+	assert.Contains(t, CryptoCodes(), "ETH")
+}
+
+func TestRoundRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     float64
+		expected float64
+	}{
+		{name: "large", rate: 1034567890123.456, expected: 1034567890000},
+		{name: "fiat", rate: 1.0 / 149.83, expected: 0.006674230795},
+		{name: "small", rate: 1.0 / 67123.45, expected: 0.00001489792316},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InEpsilon(t, tc.expected, RoundRate(tc.rate), 1e-12)
+		})
+	}
+
+	assert.Equal(t, 0.0, RoundRate(0))
+	assert.True(t, math.IsInf(RoundRate(math.Inf(1)), 1))
+}
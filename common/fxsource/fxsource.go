@@ -1,10 +1,31 @@
 package fxsource
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type FXSource interface {
 	FetchRate(context.Context, FetchRateRequest) (*FetchRateResponse, error)
 	FetchAllRates(context.Context, FetchAllRatesRequest) (*FetchAllRatesResponse, error)
+	// Calls : number of calls answered by the provider since the source was created, which are the ones metered by
+	// paid providers
+	Calls() int64
+}
+
+// MultiSource : optional capability of sources fetching several quote currencies in a single call
+type MultiSource interface {
+	FetchMultiRates(context.Context, FetchMultiRatesRequest) (*FetchMultiRatesResponse, error)
+}
+
+// HistoricalSource : optional capability of sources providing the rates of past days
+type HistoricalSource interface {
+	FetchHistoricalRates(context.Context, FetchHistoricalRatesRequest) (*FetchHistoricalRatesResponse, error)
+}
+
+// TimeSeriesSource : optional capability of sources providing the daily rates of a range of days in a single call
+type TimeSeriesSource interface {
+	FetchTimeSeries(context.Context, FetchTimeSeriesRequest) (*FetchTimeSeriesResponse, error)
 }
 
 type FetchRateRequest struct {
@@ -17,9 +38,13 @@ type FetchRateResponse struct {
 }
 
 type Rate struct {
-	From      string
-	To        string
-	Rate      float64
+	From string
+	To   string
+	// Rate : mid rate
+	Rate float64
+	// Bid, Ask : optional, 0 if the source only provides the mid rate
+	Bid       float64
+	Ask       float64
 	Timestamp int64 // unix (s)
 }
 
@@ -29,5 +54,59 @@ type FetchAllRatesRequest struct {
 }
 
 type FetchAllRatesResponse struct {
+	// Rates : sorted by base and quote currency
+	Rates []Rate
+	// Errors : base currencies that could not be fetched, sorted by currency. Rates of the other ones are still
+	// returned.
+	Errors []CurrencyError
+}
+
+type CurrencyError struct {
+	Currency string
+	Err      error
+}
+
+func (ce CurrencyError) Error() string {
+	return ce.Currency + ": " + ce.Err.Error()
+}
+
+func (ce CurrencyError) Unwrap() error {
+	return ce.Err
+}
+
+type FetchMultiRatesRequest struct {
+	From string
+	To   []string
+}
+
+type FetchMultiRatesResponse struct {
+	// Rates : sorted by quote currency
+	Rates []Rate
+}
+
+type FetchHistoricalRatesRequest struct {
+	From string
+	// To : Optional. Quote currencies, all the available ones if empty.
+	To []string
+	// Date : only the day is considered
+	Date time.Time
+}
+
+type FetchHistoricalRatesResponse struct {
+	// Rates : sorted by quote currency. Their timestamp is the start of the day (UTC).
+	Rates []Rate
+}
+
+type FetchTimeSeriesRequest struct {
+	From string
+	To   []string
+	// Start, End : first and last day of the range, included
+	Start time.Time
+	End   time.Time
+}
+
+type FetchTimeSeriesResponse struct {
+	// Rates : one per quote currency and day, sorted by quote currency and day. Their timestamp is the start of the
+	// day (UTC).
 	Rates []Rate
 }
@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/client/httpclient"
+	"github.com/lruggieri/fxnow/common/clock"
+)
+
+// HTTP : up if a GET to the URL returns a 2xx status code, e.g. for OIDC discovery documents
+type HTTP struct {
+	Client httpclient.Client
+	URL    string
+}
+
+func (h *HTTP) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.URL, nil)
+	if err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+
+	res, err := h.Client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "cannot reach %s", h.URL)
+	}
+
+	defer res.Body.Close()
+
+	// lets the connection be reused
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Errorf("status code %d from %s", res.StatusCode, h.URL)
+	}
+
+	return nil
+}
+
+// Freshness : down once the last occurrence of a periodic task is older than MaxAge, or if it never occurred
+type Freshness struct {
+	Clock clock.Clock
+	// Last : when the task last succeeded, zero if it never did
+	Last   func() time.Time
+	MaxAge time.Duration
+}
+
+func (f *Freshness) Check(context.Context) error {
+	last := f.Last()
+	if last.IsZero() {
+		return errors.New("never happened")
+	}
+
+	if age := f.Clock.Now().Sub(last); age > f.MaxAge {
+		return errors.Errorf("last happened %s ago, more than %s", age.Round(time.Second), f.MaxAge)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockhttpclient "github.com/lruggieri/fxnow/common/mock/client/httpclient"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+)
+
+func TestHTTP_Check(t *testing.T) {
+	testErr := errors.New("error")
+	url := "https://gitlab.com/.well-known/openid-configuration"
+
+	tests := []struct {
+		name      string
+		mock      func(c *mockhttpclient.Client)
+		assertion func(t *testing.T, err error)
+	}{
+		{
+			name: "happy-path",
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+					assert.Equal(t, url, req.URL.String())
+				}).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}, nil).Once()
+			},
+			assertion: func(t *testing.T, err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "error-status-code",
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusBadGateway,
+					Body:       io.NopCloser(strings.NewReader(``)),
+				}, nil).Once()
+			},
+			assertion: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "status code 502")
+			},
+		},
+		{
+			name: "error-unreachable",
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			c := mockhttpclient.NewClient(t)
+			tc.mock(c)
+
+			h := &HTTP{Client: c, URL: url}
+
+			tc.assertion(t, h.Check(context.Background()))
+		})
+	}
+}
+
+func TestFreshness_Check(t *testing.T) {
+	now := time.Date(2023, time.October, 20, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		last      time.Time
+		assertion func(t *testing.T, err error)
+	}{
+		{
+			name: "happy-path",
+			last: now.Add(-time.Minute),
+			assertion: func(t *testing.T, err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "error-stale",
+			last: now.Add(-10 * time.Minute),
+			assertion: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "last happened 10m0s ago, more than 5m0s")
+			},
+		},
+		{
+			name: "error-never",
+			assertion: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "never happened")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			clk := mockclock.NewClock(t)
+			clk.EXPECT().Now().Return(now).Maybe()
+
+			f := &Freshness{
+				Clock:  clk,
+				Last:   func() time.Time { return tc.last },
+				MaxAge: 5 * time.Minute,
+			}
+
+			tc.assertion(t, f.Check(context.Background()))
+		})
+	}
+}
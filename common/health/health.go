@@ -0,0 +1,204 @@
+// Package health reports whether a service is alive and ready to serve requests. Liveness only depends on the
+// process, so that orchestrators do not restart services because of their dependencies, while readiness depends on
+// the registered checks (e.g. databases, caches, upstream services).
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lruggieri/fxnow/common/clock"
+	cHttp "github.com/lruggieri/fxnow/common/http"
+	"github.com/lruggieri/fxnow/common/logger"
+)
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+const (
+	// DefaultTimeout : checks taking longer are considered failed
+	DefaultTimeout = 2 * time.Second
+	// DefaultCacheTTL : how long the result of a check is reused, so that probes do not hammer the dependencies
+	DefaultCacheTTL = 5 * time.Second
+
+	// publicError : reported by HandleReady in place of the errors of the checks, which are only logged as they may
+	// contain internal details (e.g. hosts, URLs with credentials)
+	publicError = "unavailable"
+)
+
+type Status string
+
+type Checker interface {
+	// Check : returns an error if the dependency is not usable. Must return once the context is done.
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc : adapter to use functions as checkers, e.g. CheckerFunc(store.Ping)
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+type Check struct {
+	// Name : reported in the readiness details, e.g. "mysql"
+	Name    string
+	Checker Checker
+	// Timeout : optional, defaults to DefaultTimeout
+	Timeout time.Duration
+	// CacheTTL : optional, defaults to DefaultCacheTTL
+	CacheTTL time.Duration
+}
+
+type Result struct {
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// CheckedAt : when the check was run, older than the report if cached
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+type Registry struct {
+	Clock clock.Clock
+
+	mu     sync.Mutex
+	checks []*check
+}
+
+// check : a registered check along with its last result
+type check struct {
+	Check
+
+	// mu : held while running, so that concurrent probes share the same run
+	mu        sync.Mutex
+	result    Result
+	checkedAt time.Time
+}
+
+func (r *Registry) Register(c Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks = append(r.checks, &check{Check: c})
+}
+
+// Ready : runs the checks concurrently, reusing the results not older than their cache TTL. The report is down if
+// any check is down.
+func (r *Registry) Ready(ctx context.Context) Report {
+	r.mu.Lock()
+	checks := r.checks
+	r.mu.Unlock()
+
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+
+	for idx, c := range checks {
+		wg.Add(1)
+
+		go func(idx int, c *check) {
+			defer wg.Done()
+
+			results[idx] = r.run(ctx, c)
+		}(idx, c)
+	}
+
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]Result, len(checks)),
+	}
+
+	for idx, c := range checks {
+		report.Checks[c.Name] = results[idx]
+
+		if results[idx].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+// HandleLive : always up while the process can serve requests
+func (r *Registry) HandleLive(c *gin.Context) {
+	cHttp.HTTPResponse(c, Report{Status: StatusUp}, nil, http.StatusOK)
+}
+
+// HandleReady : 503 while any check is down, so that the replica does not receive traffic. The errors of the checks
+// are not exposed.
+func (r *Registry) HandleReady(c *gin.Context) {
+	report := r.Ready(c.Request.Context())
+
+	for name, result := range report.Checks {
+		if result.Error != "" {
+			result.Error = publicError
+			report.Checks[name] = result
+		}
+	}
+
+	statusCode := http.StatusOK
+	if report.Status != StatusUp {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	cHttp.HTTPResponse(c, report, nil, statusCode)
+}
+
+func (r *Registry) run(ctx context.Context, c *check) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := r.Clock.Now()
+
+	if !c.checkedAt.IsZero() && now.Sub(c.checkedAt) < c.cacheTTL() {
+		return c.result
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, c.timeout())
+	defer cancel()
+
+	result := Result{Status: StatusUp, CheckedAt: now}
+
+	if err := c.Checker.Check(checkCtx); err != nil {
+		logger.WithError(err).Error("health check %s failed", c.Name)
+
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	// failures caused by the caller going away say nothing about the dependency
+	if ctx.Err() == nil {
+		c.result = result
+		c.checkedAt = now
+	}
+
+	return result
+}
+
+func (c *check) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+
+	return DefaultTimeout
+}
+
+func (c *check) cacheTTL() time.Duration {
+	if c.CacheTTL > 0 {
+		return c.CacheTTL
+	}
+
+	return DefaultCacheTTL
+}
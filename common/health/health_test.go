@@ -0,0 +1,171 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/logger/zap"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+	mockhealth "github.com/lruggieri/fxnow/common/mock/health"
+)
+
+func TestRegistry_Ready(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	testErr := errors.New("error")
+	now := time.Date(2023, time.October, 20, 10, 0, 0, 0, time.UTC)
+
+	type deps struct {
+		mysql *mockhealth.Checker
+		redis *mockhealth.Checker
+	}
+
+	tests := []struct {
+		name      string
+		mock      func(d deps)
+		assertion func(t *testing.T, r *Registry, clk *time.Time)
+	}{
+		{
+			name: "up",
+			mock: func(d deps) {
+				d.mysql.EXPECT().Check(mock.Anything).Return(nil).Once()
+				d.redis.EXPECT().Check(mock.Anything).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, r *Registry, clk *time.Time) {
+				assert.Equal(t, Report{
+					Status: StatusUp,
+					Checks: map[string]Result{
+						"mysql": {Status: StatusUp, CheckedAt: now},
+						"redis": {Status: StatusUp, CheckedAt: now},
+					},
+				}, r.Ready(context.Background()))
+			},
+		},
+		{
+			name: "down",
+			mock: func(d deps) {
+				d.mysql.EXPECT().Check(mock.Anything).Return(nil).Once()
+				d.redis.EXPECT().Check(mock.Anything).Return(testErr).Once()
+			},
+			assertion: func(t *testing.T, r *Registry, clk *time.Time) {
+				assert.Equal(t, Report{
+					Status: StatusDown,
+					Checks: map[string]Result{
+						"mysql": {Status: StatusUp, CheckedAt: now},
+						"redis": {Status: StatusDown, Error: "error", CheckedAt: now},
+					},
+				}, r.Ready(context.Background()))
+			},
+		},
+		{
+			name: "cached",
+			mock: func(d deps) {
+				d.mysql.EXPECT().Check(mock.Anything).Return(testErr).Once()
+				d.redis.EXPECT().Check(mock.Anything).Return(nil).Twice()
+				// run again once the cache TTL of the check expires
+				d.mysql.EXPECT().Check(mock.Anything).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, r *Registry, clk *time.Time) {
+				assert.Equal(t, StatusDown, r.Ready(context.Background()).Status)
+
+				*clk = now.Add(4 * time.Second)
+				report := r.Ready(context.Background())
+				assert.Equal(t, StatusDown, report.Status)
+				assert.Equal(t, now, report.Checks["mysql"].CheckedAt)
+
+				*clk = now.Add(time.Minute)
+				report = r.Ready(context.Background())
+				assert.Equal(t, StatusUp, report.Status)
+				assert.Equal(t, now.Add(time.Minute), report.Checks["mysql"].CheckedAt)
+			},
+		},
+		{
+			name: "timeout",
+			mock: func(d deps) {
+				d.mysql.EXPECT().Check(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}).Once()
+				d.redis.EXPECT().Check(mock.Anything).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, r *Registry, clk *time.Time) {
+				report := r.Ready(context.Background())
+				assert.Equal(t, StatusDown, report.Status)
+				assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["mysql"].Error)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				mysql: mockhealth.NewChecker(t),
+				redis: mockhealth.NewChecker(t),
+			}
+			tc.mock(d)
+
+			current := now
+			clk := mockclock.NewClock(t)
+			clk.EXPECT().Now().RunAndReturn(func() time.Time {
+				return current
+			})
+
+			r := &Registry{Clock: clk}
+			r.Register(Check{Name: "mysql", Checker: d.mysql, Timeout: 10 * time.Millisecond})
+			r.Register(Check{Name: "redis", Checker: d.redis, CacheTTL: 30 * time.Second})
+
+			tc.assertion(t, r, &current)
+		})
+	}
+}
+
+func TestRegistry_Handle(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+	gin.SetMode(gin.TestMode)
+
+	clk := mockclock.NewClock(t)
+	clk.EXPECT().Now().Return(time.Date(2023, time.October, 20, 10, 0, 0, 0, time.UTC))
+
+	mysql := mockhealth.NewChecker(t)
+	mysql.EXPECT().Check(mock.Anything).Return(errors.New("dial tcp 10.0.0.12:3306: connection refused")).Once()
+
+	r := &Registry{Clock: clk}
+	r.Register(Check{Name: "mysql", Checker: mysql})
+
+	router := gin.New()
+	router.GET("/livez", r.HandleLive)
+	router.GET("/readyz", r.HandleReady)
+
+	var body struct {
+		Response Report `json:"response"`
+	}
+
+	// liveness does not depend on the checks
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, StatusUp, body.Response.Status)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, StatusDown, body.Response.Status)
+	// details are only logged
+	assert.Equal(t, "unavailable", body.Response.Checks["mysql"].Error)
+	assert.NotContains(t, rec.Body.String(), "10.0.0.12")
+}
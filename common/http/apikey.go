@@ -0,0 +1,126 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/util"
+)
+
+const (
+	ContextKeyAPIKey util.ContextKey = "api-key"
+
+	APIKeyHeader     = "X-API-Key"
+	APIKeyQueryParam = "api-key"
+
+	bearerPrefix = "Bearer "
+)
+
+// APIKeyValidator : resolves API keys, or any other bearer credential such as OAuth2 access tokens, returning
+// ErrNotAuthenticated for unknown ones
+type APIKeyValidator interface {
+	ValidateAPIKey(ctx context.Context, apiKeyID string) (*model.APIKey, error)
+}
+
+// APIKeyAuth : middleware authenticating requests through an API key, taken from the Authorization header
+// ("Bearer <key>"), the X-API-Key header or the api-key query parameter. Headers should be preferred, query
+// parameters end up in proxy and access logs.
+// The resolved key, including its owner, is available to handlers through GetAPIKeyFromContext(c.Request.Context()).
+func APIKeyAuth(validator APIKeyValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKeyID, err := getAPIKeyID(c)
+		if err != nil {
+			HTTPResponse(c, nil, err, GetHttpStatusFromError(err))
+			c.Abort()
+
+			return
+		}
+
+		apiKey, err := validator.ValidateAPIKey(c.Request.Context(), apiKeyID)
+		if err != nil {
+			HTTPResponse(c, nil, err, GetHttpStatusFromError(err))
+			c.Abort()
+
+			return
+		}
+
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ContextKeyAPIKey, apiKey))
+		c.Next()
+	}
+}
+
+// RequireScope : middleware rejecting credentials not granted the scope, to be used after APIKeyAuth
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := GetAPIKeyFromContext(c.Request.Context())
+		if apiKey == nil || !apiKey.HasScope(scope) {
+			err := errors.Wrap(cError.ErrNotAuthorized, fmt.Sprintf("missing scope '%s'", scope))
+			HTTPResponse(c, nil, err, GetHttpStatusFromError(err))
+			c.Abort()
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// getAPIKeyID : returns the API key of the request. Requests carrying different keys are rejected.
+func getAPIKeyID(c *gin.Context) (string, error) {
+	var apiKeyID string
+
+	if authorization := c.GetHeader("Authorization"); authorization != "" {
+		if !strings.HasPrefix(authorization, bearerPrefix) {
+			return "", errors.Wrap(cError.ErrNotAuthenticated, "unsupported authorization scheme")
+		}
+
+		apiKeyID = strings.TrimSpace(strings.TrimPrefix(authorization, bearerPrefix))
+	}
+
+	for _, candidate := range []string{c.GetHeader(APIKeyHeader), c.Query(APIKeyQueryParam)} {
+		candidate = strings.TrimSpace(candidate)
+
+		switch {
+		case candidate == "":
+		case apiKeyID == "":
+			apiKeyID = candidate
+		case apiKeyID != candidate:
+			return "", errors.Wrap(cError.ErrInvalidParameter, "conflicting API keys")
+		}
+	}
+
+	if apiKeyID == "" {
+		return "", errors.Wrap(cError.ErrNotAuthenticated, "missing API key")
+	}
+
+	return apiKeyID, nil
+}
+
+func GetAPIKeyFromContext(ctx context.Context) *model.APIKey {
+	if ctx == nil {
+		return nil
+	}
+
+	apiKey, ok := ctx.Value(ContextKeyAPIKey).(*model.APIKey)
+	if !ok {
+		return nil
+	}
+
+	return apiKey
+}
+
+// GetAPIKeyOwnerFromContext : returns the ID of the user owning the API key of the request
+func GetAPIKeyOwnerFromContext(ctx context.Context) string {
+	apiKey := GetAPIKeyFromContext(ctx)
+	if apiKey == nil {
+		return ""
+	}
+
+	return apiKey.UserID
+}
@@ -0,0 +1,199 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	mockhttp "github.com/lruggieri/fxnow/common/mock/http"
+	"github.com/lruggieri/fxnow/common/model"
+)
+
+func TestAPIKeyAuth(t *testing.T) {
+	testErr := errors.New("error")
+	apiKey := &model.APIKey{APIKeyID: "api_key", UserID: "user_id", PlanID: model.PlanFree}
+
+	tests := []struct {
+		name           string
+		target         string
+		headers        map[string]string
+		mock           func(v *mockhttp.APIKeyValidator)
+		expectedStatus int
+	}{
+		{
+			name:           "error-missing-key",
+			target:         "/rate",
+			mock:           func(v *mockhttp.APIKeyValidator) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "error-unsupported-scheme",
+			target:         "/rate",
+			headers:        map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+			mock:           func(v *mockhttp.APIKeyValidator) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "error-conflicting-keys",
+			target:         "/rate?api-key=other_key",
+			headers:        map[string]string{APIKeyHeader: "api_key"},
+			mock:           func(v *mockhttp.APIKeyValidator) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "error-invalid-key",
+			target:  "/rate",
+			headers: map[string]string{APIKeyHeader: "api_key"},
+			mock: func(v *mockhttp.APIKeyValidator) {
+				v.EXPECT().ValidateAPIKey(mock.Anything, "api_key").Return(nil, cError.ErrNotAuthenticated).Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:    "error-validator",
+			target:  "/rate",
+			headers: map[string]string{APIKeyHeader: "api_key"},
+			mock: func(v *mockhttp.APIKeyValidator) {
+				v.EXPECT().ValidateAPIKey(mock.Anything, "api_key").Return(nil, testErr).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:    "happy-path-bearer",
+			target:  "/rate",
+			headers: map[string]string{"Authorization": "Bearer api_key"},
+			mock: func(v *mockhttp.APIKeyValidator) {
+				v.EXPECT().ValidateAPIKey(mock.Anything, "api_key").Return(apiKey, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "happy-path-header",
+			target:  "/rate",
+			headers: map[string]string{APIKeyHeader: "api_key"},
+			mock: func(v *mockhttp.APIKeyValidator) {
+				v.EXPECT().ValidateAPIKey(mock.Anything, "api_key").Return(apiKey, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "happy-path-query",
+			target: "/rate?api-key=api_key",
+			mock: func(v *mockhttp.APIKeyValidator) {
+				v.EXPECT().ValidateAPIKey(mock.Anything, "api_key").Return(apiKey, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "happy-path-same-key-everywhere",
+			target:  "/rate?api-key=api_key",
+			headers: map[string]string{"Authorization": "Bearer api_key", APIKeyHeader: "api_key"},
+			mock: func(v *mockhttp.APIKeyValidator) {
+				v.EXPECT().ValidateAPIKey(mock.Anything, "api_key").Return(apiKey, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			v := mockhttp.NewAPIKeyValidator(t)
+			tc.mock(v)
+
+			var handlerCalled bool
+
+			r := gin.New()
+			r.Use(APIKeyAuth(v))
+			r.GET("/rate", func(c *gin.Context) {
+				handlerCalled = true
+
+				assert.Equal(t, apiKey, GetAPIKeyFromContext(c.Request.Context()))
+				assert.Equal(t, "user_id", GetAPIKeyOwnerFromContext(c.Request.Context()))
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, http.NoBody)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Equal(t, tc.expectedStatus == http.StatusOK, handlerCalled)
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name           string
+		apiKey         *model.APIKey
+		expectedStatus int
+	}{
+		{
+			name:           "error-no-api-key",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "error-missing-scope",
+			apiKey:         &model.APIKey{APIKeyID: "client_id", Scopes: []string{"other:read"}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "happy-path-scope-granted",
+			apiKey:         &model.APIKey{APIKeyID: "client_id", Scopes: []string{"rates:read"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "happy-path-unrestricted-api-key",
+			apiKey:         &model.APIKey{APIKeyID: "api_key"},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tc.apiKey != nil {
+					c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ContextKeyAPIKey, tc.apiKey))
+				}
+			}, RequireScope("rates:read"))
+			r.GET("/rate", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/rate", http.NoBody))
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestGetAPIKeyFromContext(t *testing.T) {
+	apiKey := &model.APIKey{APIKeyID: "api_key", UserID: "user_id"}
+	ctx := context.WithValue(context.Background(), ContextKeyAPIKey, apiKey)
+
+	//nolint:staticcheck
+	assert.Nil(t, GetAPIKeyFromContext(nil))
+	assert.Nil(t, GetAPIKeyFromContext(context.Background()))
+	assert.Equal(t, apiKey, GetAPIKeyFromContext(ctx))
+	assert.Equal(t, "", GetAPIKeyOwnerFromContext(context.Background()))
+	assert.Equal(t, "user_id", GetAPIKeyOwnerFromContext(ctx))
+}
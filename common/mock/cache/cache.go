@@ -76,6 +76,61 @@ func (_c *Cache_Get_Call) RunAndReturn(run func(context.Context, string, interfa
 	return _c
 }
 
+// Increment provides a mock function with given fields: ctx, key, delta, expiration
+func (_m *Cache) Increment(ctx context.Context, key string, delta int64, expiration time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, delta, expiration)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, delta, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = rf(ctx, key, delta, expiration)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Cache_Increment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Increment'
+type Cache_Increment_Call struct {
+	*mock.Call
+}
+
+// Increment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+//   - expiration time.Duration
+func (_e *Cache_Expecter) Increment(ctx interface{}, key interface{}, delta interface{}, expiration interface{}) *Cache_Increment_Call {
+	return &Cache_Increment_Call{Call: _e.mock.On("Increment", ctx, key, delta, expiration)}
+}
+
+func (_c *Cache_Increment_Call) Run(run func(ctx context.Context, key string, delta int64, expiration time.Duration)) *Cache_Increment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Cache_Increment_Call) Return(value int64, err error) *Cache_Increment_Call {
+	_c.Call.Return(value, err)
+	return _c
+}
+
+func (_c *Cache_Increment_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (int64, error)) *Cache_Increment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Remove provides a mock function with given fields: ctx, key
 func (_m *Cache) Remove(ctx context.Context, key string) error {
 	ret := _m.Called(ctx, key)
@@ -164,6 +219,62 @@ func (_c *Cache_Set_Call) RunAndReturn(run func(context.Context, string, interfa
 	return _c
 }
 
+// SetFenced provides a mock function with given fields: ctx, key, value, token, expiration
+func (_m *Cache) SetFenced(ctx context.Context, key string, value interface{}, token int64, expiration time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, token, expiration)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, int64, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, token, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, int64, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, token, expiration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, token, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Cache_SetFenced_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFenced'
+type Cache_SetFenced_Call struct {
+	*mock.Call
+}
+
+// SetFenced is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - token int64
+//   - expiration time.Duration
+func (_e *Cache_Expecter) SetFenced(ctx interface{}, key interface{}, value interface{}, token interface{}, expiration interface{}) *Cache_SetFenced_Call {
+	return &Cache_SetFenced_Call{Call: _e.mock.On("SetFenced", ctx, key, value, token, expiration)}
+}
+
+func (_c *Cache_SetFenced_Call) Run(run func(ctx context.Context, key string, value interface{}, token int64, expiration time.Duration)) *Cache_SetFenced_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(int64), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Cache_SetFenced_Call) Return(set bool, err error) *Cache_SetFenced_Call {
+	_c.Call.Return(set, err)
+	return _c
+}
+
+func (_c *Cache_SetFenced_Call) RunAndReturn(run func(context.Context, string, interface{}, int64, time.Duration) (bool, error)) *Cache_SetFenced_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 type mockConstructorTestingTNewCache interface {
 	mock.TestingT
 	Cleanup(func())
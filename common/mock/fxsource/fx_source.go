@@ -22,6 +22,47 @@ func (_m *FXSource) EXPECT() *FXSource_Expecter {
 	return &FXSource_Expecter{mock: &_m.Mock}
 }
 
+// Calls provides a mock function with given fields:
+func (_m *FXSource) Calls() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// FXSource_Calls_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Calls'
+type FXSource_Calls_Call struct {
+	*mock.Call
+}
+
+// Calls is a helper method to define mock.On call
+func (_e *FXSource_Expecter) Calls() *FXSource_Calls_Call {
+	return &FXSource_Calls_Call{Call: _e.mock.On("Calls")}
+}
+
+func (_c *FXSource_Calls_Call) Run(run func()) *FXSource_Calls_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *FXSource_Calls_Call) Return(_a0 int64) *FXSource_Calls_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FXSource_Calls_Call) RunAndReturn(run func() int64) *FXSource_Calls_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FetchAllRates provides a mock function with given fields: _a0, _a1
 func (_m *FXSource) FetchAllRates(_a0 context.Context, _a1 fxsource.FetchAllRatesRequest) (*fxsource.FetchAllRatesResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockfxsource
+
+import (
+	context "context"
+
+	fxsource "github.com/lruggieri/fxnow/common/fxsource"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HistoricalSource is an autogenerated mock type for the HistoricalSource type
+type HistoricalSource struct {
+	mock.Mock
+}
+
+type HistoricalSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HistoricalSource) EXPECT() *HistoricalSource_Expecter {
+	return &HistoricalSource_Expecter{mock: &_m.Mock}
+}
+
+// FetchHistoricalRates provides a mock function with given fields: _a0, _a1
+func (_m *HistoricalSource) FetchHistoricalRates(_a0 context.Context, _a1 fxsource.FetchHistoricalRatesRequest) (*fxsource.FetchHistoricalRatesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *fxsource.FetchHistoricalRatesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, fxsource.FetchHistoricalRatesRequest) (*fxsource.FetchHistoricalRatesResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, fxsource.FetchHistoricalRatesRequest) *fxsource.FetchHistoricalRatesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fxsource.FetchHistoricalRatesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, fxsource.FetchHistoricalRatesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HistoricalSource_FetchHistoricalRates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchHistoricalRates'
+type HistoricalSource_FetchHistoricalRates_Call struct {
+	*mock.Call
+}
+
+// FetchHistoricalRates is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 fxsource.FetchHistoricalRatesRequest
+func (_e *HistoricalSource_Expecter) FetchHistoricalRates(_a0 interface{}, _a1 interface{}) *HistoricalSource_FetchHistoricalRates_Call {
+	return &HistoricalSource_FetchHistoricalRates_Call{Call: _e.mock.On("FetchHistoricalRates", _a0, _a1)}
+}
+
+func (_c *HistoricalSource_FetchHistoricalRates_Call) Run(run func(_a0 context.Context, _a1 fxsource.FetchHistoricalRatesRequest)) *HistoricalSource_FetchHistoricalRates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(fxsource.FetchHistoricalRatesRequest))
+	})
+	return _c
+}
+
+func (_c *HistoricalSource_FetchHistoricalRates_Call) Return(_a0 *fxsource.FetchHistoricalRatesResponse, _a1 error) *HistoricalSource_FetchHistoricalRates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HistoricalSource_FetchHistoricalRates_Call) RunAndReturn(run func(context.Context, fxsource.FetchHistoricalRatesRequest) (*fxsource.FetchHistoricalRatesResponse, error)) *HistoricalSource_FetchHistoricalRates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewHistoricalSource interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHistoricalSource creates a new instance of HistoricalSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHistoricalSource(t mockConstructorTestingTNewHistoricalSource) *HistoricalSource {
+	mock := &HistoricalSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
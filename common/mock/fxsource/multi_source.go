@@ -0,0 +1,93 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockfxsource
+
+import (
+	context "context"
+
+	fxsource "github.com/lruggieri/fxnow/common/fxsource"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MultiSource is an autogenerated mock type for the MultiSource type
+type MultiSource struct {
+	mock.Mock
+}
+
+type MultiSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MultiSource) EXPECT() *MultiSource_Expecter {
+	return &MultiSource_Expecter{mock: &_m.Mock}
+}
+
+// FetchMultiRates provides a mock function with given fields: _a0, _a1
+func (_m *MultiSource) FetchMultiRates(_a0 context.Context, _a1 fxsource.FetchMultiRatesRequest) (*fxsource.FetchMultiRatesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *fxsource.FetchMultiRatesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, fxsource.FetchMultiRatesRequest) (*fxsource.FetchMultiRatesResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, fxsource.FetchMultiRatesRequest) *fxsource.FetchMultiRatesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fxsource.FetchMultiRatesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, fxsource.FetchMultiRatesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MultiSource_FetchMultiRates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchMultiRates'
+type MultiSource_FetchMultiRates_Call struct {
+	*mock.Call
+}
+
+// FetchMultiRates is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 fxsource.FetchMultiRatesRequest
+func (_e *MultiSource_Expecter) FetchMultiRates(_a0 interface{}, _a1 interface{}) *MultiSource_FetchMultiRates_Call {
+	return &MultiSource_FetchMultiRates_Call{Call: _e.mock.On("FetchMultiRates", _a0, _a1)}
+}
+
+func (_c *MultiSource_FetchMultiRates_Call) Run(run func(_a0 context.Context, _a1 fxsource.FetchMultiRatesRequest)) *MultiSource_FetchMultiRates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(fxsource.FetchMultiRatesRequest))
+	})
+	return _c
+}
+
+func (_c *MultiSource_FetchMultiRates_Call) Return(_a0 *fxsource.FetchMultiRatesResponse, _a1 error) *MultiSource_FetchMultiRates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MultiSource_FetchMultiRates_Call) RunAndReturn(run func(context.Context, fxsource.FetchMultiRatesRequest) (*fxsource.FetchMultiRatesResponse, error)) *MultiSource_FetchMultiRates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMultiSource interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMultiSource creates a new instance of MultiSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMultiSource(t mockConstructorTestingTNewMultiSource) *MultiSource {
+	mock := &MultiSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockfxsource
+
+import (
+	context "context"
+
+	fxsource "github.com/lruggieri/fxnow/common/fxsource"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TimeSeriesSource is an autogenerated mock type for the TimeSeriesSource type
+type TimeSeriesSource struct {
+	mock.Mock
+}
+
+type TimeSeriesSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TimeSeriesSource) EXPECT() *TimeSeriesSource_Expecter {
+	return &TimeSeriesSource_Expecter{mock: &_m.Mock}
+}
+
+// FetchTimeSeries provides a mock function with given fields: _a0, _a1
+func (_m *TimeSeriesSource) FetchTimeSeries(_a0 context.Context, _a1 fxsource.FetchTimeSeriesRequest) (*fxsource.FetchTimeSeriesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *fxsource.FetchTimeSeriesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, fxsource.FetchTimeSeriesRequest) (*fxsource.FetchTimeSeriesResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, fxsource.FetchTimeSeriesRequest) *fxsource.FetchTimeSeriesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fxsource.FetchTimeSeriesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, fxsource.FetchTimeSeriesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TimeSeriesSource_FetchTimeSeries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchTimeSeries'
+type TimeSeriesSource_FetchTimeSeries_Call struct {
+	*mock.Call
+}
+
+// FetchTimeSeries is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 fxsource.FetchTimeSeriesRequest
+func (_e *TimeSeriesSource_Expecter) FetchTimeSeries(_a0 interface{}, _a1 interface{}) *TimeSeriesSource_FetchTimeSeries_Call {
+	return &TimeSeriesSource_FetchTimeSeries_Call{Call: _e.mock.On("FetchTimeSeries", _a0, _a1)}
+}
+
+func (_c *TimeSeriesSource_FetchTimeSeries_Call) Run(run func(_a0 context.Context, _a1 fxsource.FetchTimeSeriesRequest)) *TimeSeriesSource_FetchTimeSeries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(fxsource.FetchTimeSeriesRequest))
+	})
+	return _c
+}
+
+func (_c *TimeSeriesSource_FetchTimeSeries_Call) Return(_a0 *fxsource.FetchTimeSeriesResponse, _a1 error) *TimeSeriesSource_FetchTimeSeries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TimeSeriesSource_FetchTimeSeries_Call) RunAndReturn(run func(context.Context, fxsource.FetchTimeSeriesRequest) (*fxsource.FetchTimeSeriesResponse, error)) *TimeSeriesSource_FetchTimeSeries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewTimeSeriesSource interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTimeSeriesSource creates a new instance of TimeSeriesSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTimeSeriesSource(t mockConstructorTestingTNewTimeSeriesSource) *TimeSeriesSource {
+	mock := &TimeSeriesSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
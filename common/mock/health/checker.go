@@ -0,0 +1,79 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockhealth
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Checker is an autogenerated mock type for the Checker type
+type Checker struct {
+	mock.Mock
+}
+
+type Checker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Checker) EXPECT() *Checker_Expecter {
+	return &Checker_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: ctx
+func (_m *Checker) Check(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Checker_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type Checker_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Checker_Expecter) Check(ctx interface{}) *Checker_Check_Call {
+	return &Checker_Check_Call{Call: _e.mock.On("Check", ctx)}
+}
+
+func (_c *Checker_Check_Call) Run(run func(ctx context.Context)) *Checker_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Checker_Check_Call) Return(_a0 error) *Checker_Check_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Checker_Check_Call) RunAndReturn(run func(context.Context) error) *Checker_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewChecker interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewChecker creates a new instance of Checker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewChecker(t mockConstructorTestingTNewChecker) *Checker {
+	mock := &Checker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockhealth
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CheckerFunc is an autogenerated mock type for the CheckerFunc type
+type CheckerFunc struct {
+	mock.Mock
+}
+
+type CheckerFunc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CheckerFunc) EXPECT() *CheckerFunc_Expecter {
+	return &CheckerFunc_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx
+func (_m *CheckerFunc) Execute(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckerFunc_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type CheckerFunc_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *CheckerFunc_Expecter) Execute(ctx interface{}) *CheckerFunc_Execute_Call {
+	return &CheckerFunc_Execute_Call{Call: _e.mock.On("Execute", ctx)}
+}
+
+func (_c *CheckerFunc_Execute_Call) Run(run func(ctx context.Context)) *CheckerFunc_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *CheckerFunc_Execute_Call) Return(_a0 error) *CheckerFunc_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CheckerFunc_Execute_Call) RunAndReturn(run func(context.Context) error) *CheckerFunc_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewCheckerFunc interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewCheckerFunc creates a new instance of CheckerFunc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewCheckerFunc(t mockConstructorTestingTNewCheckerFunc) *CheckerFunc {
+	mock := &CheckerFunc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockhttp
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/lruggieri/fxnow/common/model"
+)
+
+// APIKeyValidator is an autogenerated mock type for the APIKeyValidator type
+type APIKeyValidator struct {
+	mock.Mock
+}
+
+type APIKeyValidator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APIKeyValidator) EXPECT() *APIKeyValidator_Expecter {
+	return &APIKeyValidator_Expecter{mock: &_m.Mock}
+}
+
+// ValidateAPIKey provides a mock function with given fields: ctx, apiKeyID
+func (_m *APIKeyValidator) ValidateAPIKey(ctx context.Context, apiKeyID string) (*model.APIKey, error) {
+	ret := _m.Called(ctx, apiKeyID)
+
+	var r0 *model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.APIKey, error)); ok {
+		return rf(ctx, apiKeyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.APIKey); ok {
+		r0 = rf(ctx, apiKeyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, apiKeyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyValidator_ValidateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateAPIKey'
+type APIKeyValidator_ValidateAPIKey_Call struct {
+	*mock.Call
+}
+
+// ValidateAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKeyID string
+func (_e *APIKeyValidator_Expecter) ValidateAPIKey(ctx interface{}, apiKeyID interface{}) *APIKeyValidator_ValidateAPIKey_Call {
+	return &APIKeyValidator_ValidateAPIKey_Call{Call: _e.mock.On("ValidateAPIKey", ctx, apiKeyID)}
+}
+
+func (_c *APIKeyValidator_ValidateAPIKey_Call) Run(run func(ctx context.Context, apiKeyID string)) *APIKeyValidator_ValidateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyValidator_ValidateAPIKey_Call) Return(_a0 *model.APIKey, _a1 error) *APIKeyValidator_ValidateAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyValidator_ValidateAPIKey_Call) RunAndReturn(run func(context.Context, string) (*model.APIKey, error)) *APIKeyValidator_ValidateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewAPIKeyValidator interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAPIKeyValidator creates a new instance of APIKeyValidator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAPIKeyValidator(t mockConstructorTestingTNewAPIKeyValidator) *APIKeyValidator {
+	mock := &APIKeyValidator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -22,6 +22,47 @@ func (_m *Store) EXPECT() *Store_Expecter {
 	return &Store_Expecter{mock: &_m.Mock}
 }
 
+// Close provides a mock function with given fields:
+func (_m *Store) Close() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type Store_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *Store_Expecter) Close() *Store_Close_Call {
+	return &Store_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *Store_Close_Call) Run(run func()) *Store_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Store_Close_Call) Return(_a0 error) *Store_Close_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_Close_Call) RunAndReturn(run func() error) *Store_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAPIKey provides a mock function with given fields: ctx, req
 func (_m *Store) CreateAPIKey(ctx context.Context, req store.CreateAPIKeyRequest) (*store.CreateAPIKeyResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -77,24 +118,24 @@ func (_c *Store_CreateAPIKey_Call) RunAndReturn(run func(context.Context, store.
 	return _c
 }
 
-// CreateUser provides a mock function with given fields: ctx, req
-func (_m *Store) CreateUser(ctx context.Context, req store.CreateUserRequest) (*store.CreateUserResponse, error) {
+// CreateAuditLog provides a mock function with given fields: ctx, req
+func (_m *Store) CreateAuditLog(ctx context.Context, req store.CreateAuditLogRequest) (*store.CreateAuditLogResponse, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *store.CreateUserResponse
+	var r0 *store.CreateAuditLogResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, store.CreateUserRequest) (*store.CreateUserResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateAuditLogRequest) (*store.CreateAuditLogResponse, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, store.CreateUserRequest) *store.CreateUserResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateAuditLogRequest) *store.CreateAuditLogResponse); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*store.CreateUserResponse)
+			r0 = ret.Get(0).(*store.CreateAuditLogResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, store.CreateUserRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, store.CreateAuditLogRequest) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -103,53 +144,53 @@ func (_m *Store) CreateUser(ctx context.Context, req store.CreateUserRequest) (*
 	return r0, r1
 }
 
-// Store_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
-type Store_CreateUser_Call struct {
+// Store_CreateAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuditLog'
+type Store_CreateAuditLog_Call struct {
 	*mock.Call
 }
 
-// CreateUser is a helper method to define mock.On call
+// CreateAuditLog is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req store.CreateUserRequest
-func (_e *Store_Expecter) CreateUser(ctx interface{}, req interface{}) *Store_CreateUser_Call {
-	return &Store_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, req)}
+//   - req store.CreateAuditLogRequest
+func (_e *Store_Expecter) CreateAuditLog(ctx interface{}, req interface{}) *Store_CreateAuditLog_Call {
+	return &Store_CreateAuditLog_Call{Call: _e.mock.On("CreateAuditLog", ctx, req)}
 }
 
-func (_c *Store_CreateUser_Call) Run(run func(ctx context.Context, req store.CreateUserRequest)) *Store_CreateUser_Call {
+func (_c *Store_CreateAuditLog_Call) Run(run func(ctx context.Context, req store.CreateAuditLogRequest)) *Store_CreateAuditLog_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(store.CreateUserRequest))
+		run(args[0].(context.Context), args[1].(store.CreateAuditLogRequest))
 	})
 	return _c
 }
 
-func (_c *Store_CreateUser_Call) Return(_a0 *store.CreateUserResponse, _a1 error) *Store_CreateUser_Call {
+func (_c *Store_CreateAuditLog_Call) Return(_a0 *store.CreateAuditLogResponse, _a1 error) *Store_CreateAuditLog_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Store_CreateUser_Call) RunAndReturn(run func(context.Context, store.CreateUserRequest) (*store.CreateUserResponse, error)) *Store_CreateUser_Call {
+func (_c *Store_CreateAuditLog_Call) RunAndReturn(run func(context.Context, store.CreateAuditLogRequest) (*store.CreateAuditLogResponse, error)) *Store_CreateAuditLog_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAPIKey provides a mock function with given fields: ctx, req
-func (_m *Store) DeleteAPIKey(ctx context.Context, req store.DeleteAPIKeyRequest) (*store.DeleteAPIKeyResponse, error) {
+// CreateOAuthClient provides a mock function with given fields: ctx, req
+func (_m *Store) CreateOAuthClient(ctx context.Context, req store.CreateOAuthClientRequest) (*store.CreateOAuthClientResponse, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *store.DeleteAPIKeyResponse
+	var r0 *store.CreateOAuthClientResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteAPIKeyRequest) (*store.DeleteAPIKeyResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateOAuthClientRequest) (*store.CreateOAuthClientResponse, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteAPIKeyRequest) *store.DeleteAPIKeyResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateOAuthClientRequest) *store.CreateOAuthClientResponse); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*store.DeleteAPIKeyResponse)
+			r0 = ret.Get(0).(*store.CreateOAuthClientResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, store.DeleteAPIKeyRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, store.CreateOAuthClientRequest) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -158,53 +199,53 @@ func (_m *Store) DeleteAPIKey(ctx context.Context, req store.DeleteAPIKeyRequest
 	return r0, r1
 }
 
-// Store_DeleteAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAPIKey'
-type Store_DeleteAPIKey_Call struct {
+// Store_CreateOAuthClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOAuthClient'
+type Store_CreateOAuthClient_Call struct {
 	*mock.Call
 }
 
-// DeleteAPIKey is a helper method to define mock.On call
+// CreateOAuthClient is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req store.DeleteAPIKeyRequest
-func (_e *Store_Expecter) DeleteAPIKey(ctx interface{}, req interface{}) *Store_DeleteAPIKey_Call {
-	return &Store_DeleteAPIKey_Call{Call: _e.mock.On("DeleteAPIKey", ctx, req)}
+//   - req store.CreateOAuthClientRequest
+func (_e *Store_Expecter) CreateOAuthClient(ctx interface{}, req interface{}) *Store_CreateOAuthClient_Call {
+	return &Store_CreateOAuthClient_Call{Call: _e.mock.On("CreateOAuthClient", ctx, req)}
 }
 
-func (_c *Store_DeleteAPIKey_Call) Run(run func(ctx context.Context, req store.DeleteAPIKeyRequest)) *Store_DeleteAPIKey_Call {
+func (_c *Store_CreateOAuthClient_Call) Run(run func(ctx context.Context, req store.CreateOAuthClientRequest)) *Store_CreateOAuthClient_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(store.DeleteAPIKeyRequest))
+		run(args[0].(context.Context), args[1].(store.CreateOAuthClientRequest))
 	})
 	return _c
 }
 
-func (_c *Store_DeleteAPIKey_Call) Return(_a0 *store.DeleteAPIKeyResponse, _a1 error) *Store_DeleteAPIKey_Call {
+func (_c *Store_CreateOAuthClient_Call) Return(_a0 *store.CreateOAuthClientResponse, _a1 error) *Store_CreateOAuthClient_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Store_DeleteAPIKey_Call) RunAndReturn(run func(context.Context, store.DeleteAPIKeyRequest) (*store.DeleteAPIKeyResponse, error)) *Store_DeleteAPIKey_Call {
+func (_c *Store_CreateOAuthClient_Call) RunAndReturn(run func(context.Context, store.CreateOAuthClientRequest) (*store.CreateOAuthClientResponse, error)) *Store_CreateOAuthClient_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAPIKey provides a mock function with given fields: ctx, req
-func (_m *Store) GetAPIKey(ctx context.Context, req store.GetAPIKeyRequest) (*store.GetAPIKeyResponse, error) {
+// CreateOrganization provides a mock function with given fields: ctx, req
+func (_m *Store) CreateOrganization(ctx context.Context, req store.CreateOrganizationRequest) (*store.CreateOrganizationResponse, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *store.GetAPIKeyResponse
+	var r0 *store.CreateOrganizationResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, store.GetAPIKeyRequest) (*store.GetAPIKeyResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateOrganizationRequest) (*store.CreateOrganizationResponse, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, store.GetAPIKeyRequest) *store.GetAPIKeyResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateOrganizationRequest) *store.CreateOrganizationResponse); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*store.GetAPIKeyResponse)
+			r0 = ret.Get(0).(*store.CreateOrganizationResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, store.GetAPIKeyRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, store.CreateOrganizationRequest) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -213,53 +254,53 @@ func (_m *Store) GetAPIKey(ctx context.Context, req store.GetAPIKeyRequest) (*st
 	return r0, r1
 }
 
-// Store_GetAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPIKey'
-type Store_GetAPIKey_Call struct {
+// Store_CreateOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrganization'
+type Store_CreateOrganization_Call struct {
 	*mock.Call
 }
 
-// GetAPIKey is a helper method to define mock.On call
+// CreateOrganization is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req store.GetAPIKeyRequest
-func (_e *Store_Expecter) GetAPIKey(ctx interface{}, req interface{}) *Store_GetAPIKey_Call {
-	return &Store_GetAPIKey_Call{Call: _e.mock.On("GetAPIKey", ctx, req)}
+//   - req store.CreateOrganizationRequest
+func (_e *Store_Expecter) CreateOrganization(ctx interface{}, req interface{}) *Store_CreateOrganization_Call {
+	return &Store_CreateOrganization_Call{Call: _e.mock.On("CreateOrganization", ctx, req)}
 }
 
-func (_c *Store_GetAPIKey_Call) Run(run func(ctx context.Context, req store.GetAPIKeyRequest)) *Store_GetAPIKey_Call {
+func (_c *Store_CreateOrganization_Call) Run(run func(ctx context.Context, req store.CreateOrganizationRequest)) *Store_CreateOrganization_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(store.GetAPIKeyRequest))
+		run(args[0].(context.Context), args[1].(store.CreateOrganizationRequest))
 	})
 	return _c
 }
 
-func (_c *Store_GetAPIKey_Call) Return(_a0 *store.GetAPIKeyResponse, _a1 error) *Store_GetAPIKey_Call {
+func (_c *Store_CreateOrganization_Call) Return(_a0 *store.CreateOrganizationResponse, _a1 error) *Store_CreateOrganization_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Store_GetAPIKey_Call) RunAndReturn(run func(context.Context, store.GetAPIKeyRequest) (*store.GetAPIKeyResponse, error)) *Store_GetAPIKey_Call {
+func (_c *Store_CreateOrganization_Call) RunAndReturn(run func(context.Context, store.CreateOrganizationRequest) (*store.CreateOrganizationResponse, error)) *Store_CreateOrganization_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUser provides a mock function with given fields: ctx, req
-func (_m *Store) GetUser(ctx context.Context, req store.GetUserRequest) (*store.GetUserResponse, error) {
+// CreateOrganizationMember provides a mock function with given fields: ctx, req
+func (_m *Store) CreateOrganizationMember(ctx context.Context, req store.CreateOrganizationMemberRequest) (*store.CreateOrganizationMemberResponse, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *store.GetUserResponse
+	var r0 *store.CreateOrganizationMemberResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, store.GetUserRequest) (*store.GetUserResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateOrganizationMemberRequest) (*store.CreateOrganizationMemberResponse, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, store.GetUserRequest) *store.GetUserResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateOrganizationMemberRequest) *store.CreateOrganizationMemberResponse); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*store.GetUserResponse)
+			r0 = ret.Get(0).(*store.CreateOrganizationMemberResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, store.GetUserRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, store.CreateOrganizationMemberRequest) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -268,53 +309,53 @@ func (_m *Store) GetUser(ctx context.Context, req store.GetUserRequest) (*store.
 	return r0, r1
 }
 
-// Store_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
-type Store_GetUser_Call struct {
+// Store_CreateOrganizationMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrganizationMember'
+type Store_CreateOrganizationMember_Call struct {
 	*mock.Call
 }
 
-// GetUser is a helper method to define mock.On call
+// CreateOrganizationMember is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req store.GetUserRequest
-func (_e *Store_Expecter) GetUser(ctx interface{}, req interface{}) *Store_GetUser_Call {
-	return &Store_GetUser_Call{Call: _e.mock.On("GetUser", ctx, req)}
+//   - req store.CreateOrganizationMemberRequest
+func (_e *Store_Expecter) CreateOrganizationMember(ctx interface{}, req interface{}) *Store_CreateOrganizationMember_Call {
+	return &Store_CreateOrganizationMember_Call{Call: _e.mock.On("CreateOrganizationMember", ctx, req)}
 }
 
-func (_c *Store_GetUser_Call) Run(run func(ctx context.Context, req store.GetUserRequest)) *Store_GetUser_Call {
+func (_c *Store_CreateOrganizationMember_Call) Run(run func(ctx context.Context, req store.CreateOrganizationMemberRequest)) *Store_CreateOrganizationMember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(store.GetUserRequest))
+		run(args[0].(context.Context), args[1].(store.CreateOrganizationMemberRequest))
 	})
 	return _c
 }
 
-func (_c *Store_GetUser_Call) Return(_a0 *store.GetUserResponse, _a1 error) *Store_GetUser_Call {
+func (_c *Store_CreateOrganizationMember_Call) Return(_a0 *store.CreateOrganizationMemberResponse, _a1 error) *Store_CreateOrganizationMember_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Store_GetUser_Call) RunAndReturn(run func(context.Context, store.GetUserRequest) (*store.GetUserResponse, error)) *Store_GetUser_Call {
+func (_c *Store_CreateOrganizationMember_Call) RunAndReturn(run func(context.Context, store.CreateOrganizationMemberRequest) (*store.CreateOrganizationMemberResponse, error)) *Store_CreateOrganizationMember_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAPIKeys provides a mock function with given fields: ctx, req
-func (_m *Store) ListAPIKeys(ctx context.Context, req store.ListAPIKeysRequest) (*store.ListAPIKeysResponse, error) {
+// CreateSession provides a mock function with given fields: ctx, req
+func (_m *Store) CreateSession(ctx context.Context, req store.CreateSessionRequest) (*store.CreateSessionResponse, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *store.ListAPIKeysResponse
+	var r0 *store.CreateSessionResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, store.ListAPIKeysRequest) (*store.ListAPIKeysResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateSessionRequest) (*store.CreateSessionResponse, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, store.ListAPIKeysRequest) *store.ListAPIKeysResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateSessionRequest) *store.CreateSessionResponse); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*store.ListAPIKeysResponse)
+			r0 = ret.Get(0).(*store.CreateSessionResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, store.ListAPIKeysRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, store.CreateSessionRequest) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -323,31 +364,1558 @@ func (_m *Store) ListAPIKeys(ctx context.Context, req store.ListAPIKeysRequest)
 	return r0, r1
 }
 
-// Store_ListAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPIKeys'
-type Store_ListAPIKeys_Call struct {
+// Store_CreateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSession'
+type Store_CreateSession_Call struct {
 	*mock.Call
 }
 
-// ListAPIKeys is a helper method to define mock.On call
+// CreateSession is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req store.ListAPIKeysRequest
-func (_e *Store_Expecter) ListAPIKeys(ctx interface{}, req interface{}) *Store_ListAPIKeys_Call {
-	return &Store_ListAPIKeys_Call{Call: _e.mock.On("ListAPIKeys", ctx, req)}
+//   - req store.CreateSessionRequest
+func (_e *Store_Expecter) CreateSession(ctx interface{}, req interface{}) *Store_CreateSession_Call {
+	return &Store_CreateSession_Call{Call: _e.mock.On("CreateSession", ctx, req)}
 }
 
-func (_c *Store_ListAPIKeys_Call) Run(run func(ctx context.Context, req store.ListAPIKeysRequest)) *Store_ListAPIKeys_Call {
+func (_c *Store_CreateSession_Call) Run(run func(ctx context.Context, req store.CreateSessionRequest)) *Store_CreateSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(store.ListAPIKeysRequest))
+		run(args[0].(context.Context), args[1].(store.CreateSessionRequest))
 	})
 	return _c
 }
 
-func (_c *Store_ListAPIKeys_Call) Return(_a0 *store.ListAPIKeysResponse, _a1 error) *Store_ListAPIKeys_Call {
+func (_c *Store_CreateSession_Call) Return(_a0 *store.CreateSessionResponse, _a1 error) *Store_CreateSession_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Store_ListAPIKeys_Call) RunAndReturn(run func(context.Context, store.ListAPIKeysRequest) (*store.ListAPIKeysResponse, error)) *Store_ListAPIKeys_Call {
+func (_c *Store_CreateSession_Call) RunAndReturn(run func(context.Context, store.CreateSessionRequest) (*store.CreateSessionResponse, error)) *Store_CreateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function with given fields: ctx, req
+func (_m *Store) CreateUser(ctx context.Context, req store.CreateUserRequest) (*store.CreateUserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.CreateUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateUserRequest) (*store.CreateUserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateUserRequest) *store.CreateUserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.CreateUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.CreateUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type Store_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.CreateUserRequest
+func (_e *Store_Expecter) CreateUser(ctx interface{}, req interface{}) *Store_CreateUser_Call {
+	return &Store_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, req)}
+}
+
+func (_c *Store_CreateUser_Call) Run(run func(ctx context.Context, req store.CreateUserRequest)) *Store_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.CreateUserRequest))
+	})
+	return _c
+}
+
+func (_c *Store_CreateUser_Call) Return(_a0 *store.CreateUserResponse, _a1 error) *Store_CreateUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_CreateUser_Call) RunAndReturn(run func(context.Context, store.CreateUserRequest) (*store.CreateUserResponse, error)) *Store_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, req
+func (_m *Store) CreateWebhook(ctx context.Context, req store.CreateWebhookRequest) (*store.CreateWebhookResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.CreateWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateWebhookRequest) (*store.CreateWebhookResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateWebhookRequest) *store.CreateWebhookResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.CreateWebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.CreateWebhookRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type Store_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.CreateWebhookRequest
+func (_e *Store_Expecter) CreateWebhook(ctx interface{}, req interface{}) *Store_CreateWebhook_Call {
+	return &Store_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, req)}
+}
+
+func (_c *Store_CreateWebhook_Call) Run(run func(ctx context.Context, req store.CreateWebhookRequest)) *Store_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.CreateWebhookRequest))
+	})
+	return _c
+}
+
+func (_c *Store_CreateWebhook_Call) Return(_a0 *store.CreateWebhookResponse, _a1 error) *Store_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_CreateWebhook_Call) RunAndReturn(run func(context.Context, store.CreateWebhookRequest) (*store.CreateWebhookResponse, error)) *Store_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhookDeadLetter provides a mock function with given fields: ctx, req
+func (_m *Store) CreateWebhookDeadLetter(ctx context.Context, req store.CreateWebhookDeadLetterRequest) (*store.CreateWebhookDeadLetterResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.CreateWebhookDeadLetterResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateWebhookDeadLetterRequest) (*store.CreateWebhookDeadLetterResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.CreateWebhookDeadLetterRequest) *store.CreateWebhookDeadLetterResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.CreateWebhookDeadLetterResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.CreateWebhookDeadLetterRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_CreateWebhookDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhookDeadLetter'
+type Store_CreateWebhookDeadLetter_Call struct {
+	*mock.Call
+}
+
+// CreateWebhookDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.CreateWebhookDeadLetterRequest
+func (_e *Store_Expecter) CreateWebhookDeadLetter(ctx interface{}, req interface{}) *Store_CreateWebhookDeadLetter_Call {
+	return &Store_CreateWebhookDeadLetter_Call{Call: _e.mock.On("CreateWebhookDeadLetter", ctx, req)}
+}
+
+func (_c *Store_CreateWebhookDeadLetter_Call) Run(run func(ctx context.Context, req store.CreateWebhookDeadLetterRequest)) *Store_CreateWebhookDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.CreateWebhookDeadLetterRequest))
+	})
+	return _c
+}
+
+func (_c *Store_CreateWebhookDeadLetter_Call) Return(_a0 *store.CreateWebhookDeadLetterResponse, _a1 error) *Store_CreateWebhookDeadLetter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_CreateWebhookDeadLetter_Call) RunAndReturn(run func(context.Context, store.CreateWebhookDeadLetterRequest) (*store.CreateWebhookDeadLetterResponse, error)) *Store_CreateWebhookDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAPIKey provides a mock function with given fields: ctx, req
+func (_m *Store) DeleteAPIKey(ctx context.Context, req store.DeleteAPIKeyRequest) (*store.DeleteAPIKeyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.DeleteAPIKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteAPIKeyRequest) (*store.DeleteAPIKeyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteAPIKeyRequest) *store.DeleteAPIKeyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.DeleteAPIKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.DeleteAPIKeyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_DeleteAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAPIKey'
+type Store_DeleteAPIKey_Call struct {
+	*mock.Call
+}
+
+// DeleteAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.DeleteAPIKeyRequest
+func (_e *Store_Expecter) DeleteAPIKey(ctx interface{}, req interface{}) *Store_DeleteAPIKey_Call {
+	return &Store_DeleteAPIKey_Call{Call: _e.mock.On("DeleteAPIKey", ctx, req)}
+}
+
+func (_c *Store_DeleteAPIKey_Call) Run(run func(ctx context.Context, req store.DeleteAPIKeyRequest)) *Store_DeleteAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.DeleteAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *Store_DeleteAPIKey_Call) Return(_a0 *store.DeleteAPIKeyResponse, _a1 error) *Store_DeleteAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_DeleteAPIKey_Call) RunAndReturn(run func(context.Context, store.DeleteAPIKeyRequest) (*store.DeleteAPIKeyResponse, error)) *Store_DeleteAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOAuthClient provides a mock function with given fields: ctx, req
+func (_m *Store) DeleteOAuthClient(ctx context.Context, req store.DeleteOAuthClientRequest) (*store.DeleteOAuthClientResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.DeleteOAuthClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteOAuthClientRequest) (*store.DeleteOAuthClientResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteOAuthClientRequest) *store.DeleteOAuthClientResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.DeleteOAuthClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.DeleteOAuthClientRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_DeleteOAuthClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOAuthClient'
+type Store_DeleteOAuthClient_Call struct {
+	*mock.Call
+}
+
+// DeleteOAuthClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.DeleteOAuthClientRequest
+func (_e *Store_Expecter) DeleteOAuthClient(ctx interface{}, req interface{}) *Store_DeleteOAuthClient_Call {
+	return &Store_DeleteOAuthClient_Call{Call: _e.mock.On("DeleteOAuthClient", ctx, req)}
+}
+
+func (_c *Store_DeleteOAuthClient_Call) Run(run func(ctx context.Context, req store.DeleteOAuthClientRequest)) *Store_DeleteOAuthClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.DeleteOAuthClientRequest))
+	})
+	return _c
+}
+
+func (_c *Store_DeleteOAuthClient_Call) Return(_a0 *store.DeleteOAuthClientResponse, _a1 error) *Store_DeleteOAuthClient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_DeleteOAuthClient_Call) RunAndReturn(run func(context.Context, store.DeleteOAuthClientRequest) (*store.DeleteOAuthClientResponse, error)) *Store_DeleteOAuthClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOrganizationMember provides a mock function with given fields: ctx, req
+func (_m *Store) DeleteOrganizationMember(ctx context.Context, req store.DeleteOrganizationMemberRequest) (*store.DeleteOrganizationMemberResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.DeleteOrganizationMemberResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteOrganizationMemberRequest) (*store.DeleteOrganizationMemberResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteOrganizationMemberRequest) *store.DeleteOrganizationMemberResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.DeleteOrganizationMemberResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.DeleteOrganizationMemberRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_DeleteOrganizationMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOrganizationMember'
+type Store_DeleteOrganizationMember_Call struct {
+	*mock.Call
+}
+
+// DeleteOrganizationMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.DeleteOrganizationMemberRequest
+func (_e *Store_Expecter) DeleteOrganizationMember(ctx interface{}, req interface{}) *Store_DeleteOrganizationMember_Call {
+	return &Store_DeleteOrganizationMember_Call{Call: _e.mock.On("DeleteOrganizationMember", ctx, req)}
+}
+
+func (_c *Store_DeleteOrganizationMember_Call) Run(run func(ctx context.Context, req store.DeleteOrganizationMemberRequest)) *Store_DeleteOrganizationMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.DeleteOrganizationMemberRequest))
+	})
+	return _c
+}
+
+func (_c *Store_DeleteOrganizationMember_Call) Return(_a0 *store.DeleteOrganizationMemberResponse, _a1 error) *Store_DeleteOrganizationMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_DeleteOrganizationMember_Call) RunAndReturn(run func(context.Context, store.DeleteOrganizationMemberRequest) (*store.DeleteOrganizationMemberResponse, error)) *Store_DeleteOrganizationMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSession provides a mock function with given fields: ctx, req
+func (_m *Store) DeleteSession(ctx context.Context, req store.DeleteSessionRequest) (*store.DeleteSessionResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.DeleteSessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteSessionRequest) (*store.DeleteSessionResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteSessionRequest) *store.DeleteSessionResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.DeleteSessionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.DeleteSessionRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_DeleteSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSession'
+type Store_DeleteSession_Call struct {
+	*mock.Call
+}
+
+// DeleteSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.DeleteSessionRequest
+func (_e *Store_Expecter) DeleteSession(ctx interface{}, req interface{}) *Store_DeleteSession_Call {
+	return &Store_DeleteSession_Call{Call: _e.mock.On("DeleteSession", ctx, req)}
+}
+
+func (_c *Store_DeleteSession_Call) Run(run func(ctx context.Context, req store.DeleteSessionRequest)) *Store_DeleteSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.DeleteSessionRequest))
+	})
+	return _c
+}
+
+func (_c *Store_DeleteSession_Call) Return(_a0 *store.DeleteSessionResponse, _a1 error) *Store_DeleteSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_DeleteSession_Call) RunAndReturn(run func(context.Context, store.DeleteSessionRequest) (*store.DeleteSessionResponse, error)) *Store_DeleteSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, req
+func (_m *Store) DeleteWebhook(ctx context.Context, req store.DeleteWebhookRequest) (*store.DeleteWebhookResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.DeleteWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteWebhookRequest) (*store.DeleteWebhookResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeleteWebhookRequest) *store.DeleteWebhookResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.DeleteWebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.DeleteWebhookRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type Store_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.DeleteWebhookRequest
+func (_e *Store_Expecter) DeleteWebhook(ctx interface{}, req interface{}) *Store_DeleteWebhook_Call {
+	return &Store_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, req)}
+}
+
+func (_c *Store_DeleteWebhook_Call) Run(run func(ctx context.Context, req store.DeleteWebhookRequest)) *Store_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.DeleteWebhookRequest))
+	})
+	return _c
+}
+
+func (_c *Store_DeleteWebhook_Call) Return(_a0 *store.DeleteWebhookResponse, _a1 error) *Store_DeleteWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_DeleteWebhook_Call) RunAndReturn(run func(context.Context, store.DeleteWebhookRequest) (*store.DeleteWebhookResponse, error)) *Store_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAPIKey provides a mock function with given fields: ctx, req
+func (_m *Store) GetAPIKey(ctx context.Context, req store.GetAPIKeyRequest) (*store.GetAPIKeyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.GetAPIKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetAPIKeyRequest) (*store.GetAPIKeyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetAPIKeyRequest) *store.GetAPIKeyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.GetAPIKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.GetAPIKeyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPIKey'
+type Store_GetAPIKey_Call struct {
+	*mock.Call
+}
+
+// GetAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.GetAPIKeyRequest
+func (_e *Store_Expecter) GetAPIKey(ctx interface{}, req interface{}) *Store_GetAPIKey_Call {
+	return &Store_GetAPIKey_Call{Call: _e.mock.On("GetAPIKey", ctx, req)}
+}
+
+func (_c *Store_GetAPIKey_Call) Run(run func(ctx context.Context, req store.GetAPIKeyRequest)) *Store_GetAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.GetAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *Store_GetAPIKey_Call) Return(_a0 *store.GetAPIKeyResponse, _a1 error) *Store_GetAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetAPIKey_Call) RunAndReturn(run func(context.Context, store.GetAPIKeyRequest) (*store.GetAPIKeyResponse, error)) *Store_GetAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOAuthClient provides a mock function with given fields: ctx, req
+func (_m *Store) GetOAuthClient(ctx context.Context, req store.GetOAuthClientRequest) (*store.GetOAuthClientResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.GetOAuthClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetOAuthClientRequest) (*store.GetOAuthClientResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetOAuthClientRequest) *store.GetOAuthClientResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.GetOAuthClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.GetOAuthClientRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetOAuthClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOAuthClient'
+type Store_GetOAuthClient_Call struct {
+	*mock.Call
+}
+
+// GetOAuthClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.GetOAuthClientRequest
+func (_e *Store_Expecter) GetOAuthClient(ctx interface{}, req interface{}) *Store_GetOAuthClient_Call {
+	return &Store_GetOAuthClient_Call{Call: _e.mock.On("GetOAuthClient", ctx, req)}
+}
+
+func (_c *Store_GetOAuthClient_Call) Run(run func(ctx context.Context, req store.GetOAuthClientRequest)) *Store_GetOAuthClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.GetOAuthClientRequest))
+	})
+	return _c
+}
+
+func (_c *Store_GetOAuthClient_Call) Return(_a0 *store.GetOAuthClientResponse, _a1 error) *Store_GetOAuthClient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetOAuthClient_Call) RunAndReturn(run func(context.Context, store.GetOAuthClientRequest) (*store.GetOAuthClientResponse, error)) *Store_GetOAuthClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrganization provides a mock function with given fields: ctx, req
+func (_m *Store) GetOrganization(ctx context.Context, req store.GetOrganizationRequest) (*store.GetOrganizationResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.GetOrganizationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetOrganizationRequest) (*store.GetOrganizationResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetOrganizationRequest) *store.GetOrganizationResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.GetOrganizationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.GetOrganizationRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrganization'
+type Store_GetOrganization_Call struct {
+	*mock.Call
+}
+
+// GetOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.GetOrganizationRequest
+func (_e *Store_Expecter) GetOrganization(ctx interface{}, req interface{}) *Store_GetOrganization_Call {
+	return &Store_GetOrganization_Call{Call: _e.mock.On("GetOrganization", ctx, req)}
+}
+
+func (_c *Store_GetOrganization_Call) Run(run func(ctx context.Context, req store.GetOrganizationRequest)) *Store_GetOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.GetOrganizationRequest))
+	})
+	return _c
+}
+
+func (_c *Store_GetOrganization_Call) Return(_a0 *store.GetOrganizationResponse, _a1 error) *Store_GetOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetOrganization_Call) RunAndReturn(run func(context.Context, store.GetOrganizationRequest) (*store.GetOrganizationResponse, error)) *Store_GetOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrganizationMember provides a mock function with given fields: ctx, req
+func (_m *Store) GetOrganizationMember(ctx context.Context, req store.GetOrganizationMemberRequest) (*store.GetOrganizationMemberResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.GetOrganizationMemberResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetOrganizationMemberRequest) (*store.GetOrganizationMemberResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetOrganizationMemberRequest) *store.GetOrganizationMemberResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.GetOrganizationMemberResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.GetOrganizationMemberRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetOrganizationMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrganizationMember'
+type Store_GetOrganizationMember_Call struct {
+	*mock.Call
+}
+
+// GetOrganizationMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.GetOrganizationMemberRequest
+func (_e *Store_Expecter) GetOrganizationMember(ctx interface{}, req interface{}) *Store_GetOrganizationMember_Call {
+	return &Store_GetOrganizationMember_Call{Call: _e.mock.On("GetOrganizationMember", ctx, req)}
+}
+
+func (_c *Store_GetOrganizationMember_Call) Run(run func(ctx context.Context, req store.GetOrganizationMemberRequest)) *Store_GetOrganizationMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.GetOrganizationMemberRequest))
+	})
+	return _c
+}
+
+func (_c *Store_GetOrganizationMember_Call) Return(_a0 *store.GetOrganizationMemberResponse, _a1 error) *Store_GetOrganizationMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetOrganizationMember_Call) RunAndReturn(run func(context.Context, store.GetOrganizationMemberRequest) (*store.GetOrganizationMemberResponse, error)) *Store_GetOrganizationMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPlan provides a mock function with given fields: ctx, req
+func (_m *Store) GetPlan(ctx context.Context, req store.GetPlanRequest) (*store.GetPlanResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.GetPlanResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetPlanRequest) (*store.GetPlanResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetPlanRequest) *store.GetPlanResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.GetPlanResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.GetPlanRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetPlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlan'
+type Store_GetPlan_Call struct {
+	*mock.Call
+}
+
+// GetPlan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.GetPlanRequest
+func (_e *Store_Expecter) GetPlan(ctx interface{}, req interface{}) *Store_GetPlan_Call {
+	return &Store_GetPlan_Call{Call: _e.mock.On("GetPlan", ctx, req)}
+}
+
+func (_c *Store_GetPlan_Call) Run(run func(ctx context.Context, req store.GetPlanRequest)) *Store_GetPlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.GetPlanRequest))
+	})
+	return _c
+}
+
+func (_c *Store_GetPlan_Call) Return(_a0 *store.GetPlanResponse, _a1 error) *Store_GetPlan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetPlan_Call) RunAndReturn(run func(context.Context, store.GetPlanRequest) (*store.GetPlanResponse, error)) *Store_GetPlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSession provides a mock function with given fields: ctx, req
+func (_m *Store) GetSession(ctx context.Context, req store.GetSessionRequest) (*store.GetSessionResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.GetSessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetSessionRequest) (*store.GetSessionResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetSessionRequest) *store.GetSessionResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.GetSessionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.GetSessionRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSession'
+type Store_GetSession_Call struct {
+	*mock.Call
+}
+
+// GetSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.GetSessionRequest
+func (_e *Store_Expecter) GetSession(ctx interface{}, req interface{}) *Store_GetSession_Call {
+	return &Store_GetSession_Call{Call: _e.mock.On("GetSession", ctx, req)}
+}
+
+func (_c *Store_GetSession_Call) Run(run func(ctx context.Context, req store.GetSessionRequest)) *Store_GetSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.GetSessionRequest))
+	})
+	return _c
+}
+
+func (_c *Store_GetSession_Call) Return(_a0 *store.GetSessionResponse, _a1 error) *Store_GetSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetSession_Call) RunAndReturn(run func(context.Context, store.GetSessionRequest) (*store.GetSessionResponse, error)) *Store_GetSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, req
+func (_m *Store) GetUser(ctx context.Context, req store.GetUserRequest) (*store.GetUserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.GetUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetUserRequest) (*store.GetUserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetUserRequest) *store.GetUserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.GetUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.GetUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type Store_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.GetUserRequest
+func (_e *Store_Expecter) GetUser(ctx interface{}, req interface{}) *Store_GetUser_Call {
+	return &Store_GetUser_Call{Call: _e.mock.On("GetUser", ctx, req)}
+}
+
+func (_c *Store_GetUser_Call) Run(run func(ctx context.Context, req store.GetUserRequest)) *Store_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.GetUserRequest))
+	})
+	return _c
+}
+
+func (_c *Store_GetUser_Call) Return(_a0 *store.GetUserResponse, _a1 error) *Store_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetUser_Call) RunAndReturn(run func(context.Context, store.GetUserRequest) (*store.GetUserResponse, error)) *Store_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhook provides a mock function with given fields: ctx, req
+func (_m *Store) GetWebhook(ctx context.Context, req store.GetWebhookRequest) (*store.GetWebhookResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.GetWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetWebhookRequest) (*store.GetWebhookResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.GetWebhookRequest) *store.GetWebhookResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.GetWebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.GetWebhookRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type Store_GetWebhook_Call struct {
+	*mock.Call
+}
+
+// GetWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.GetWebhookRequest
+func (_e *Store_Expecter) GetWebhook(ctx interface{}, req interface{}) *Store_GetWebhook_Call {
+	return &Store_GetWebhook_Call{Call: _e.mock.On("GetWebhook", ctx, req)}
+}
+
+func (_c *Store_GetWebhook_Call) Run(run func(ctx context.Context, req store.GetWebhookRequest)) *Store_GetWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.GetWebhookRequest))
+	})
+	return _c
+}
+
+func (_c *Store_GetWebhook_Call) Return(_a0 *store.GetWebhookResponse, _a1 error) *Store_GetWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetWebhook_Call) RunAndReturn(run func(context.Context, store.GetWebhookRequest) (*store.GetWebhookResponse, error)) *Store_GetWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAPIKeys provides a mock function with given fields: ctx, req
+func (_m *Store) ListAPIKeys(ctx context.Context, req store.ListAPIKeysRequest) (*store.ListAPIKeysResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.ListAPIKeysResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListAPIKeysRequest) (*store.ListAPIKeysResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListAPIKeysRequest) *store.ListAPIKeysResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.ListAPIKeysResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListAPIKeysRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPIKeys'
+type Store_ListAPIKeys_Call struct {
+	*mock.Call
+}
+
+// ListAPIKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.ListAPIKeysRequest
+func (_e *Store_Expecter) ListAPIKeys(ctx interface{}, req interface{}) *Store_ListAPIKeys_Call {
+	return &Store_ListAPIKeys_Call{Call: _e.mock.On("ListAPIKeys", ctx, req)}
+}
+
+func (_c *Store_ListAPIKeys_Call) Run(run func(ctx context.Context, req store.ListAPIKeysRequest)) *Store_ListAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.ListAPIKeysRequest))
+	})
+	return _c
+}
+
+func (_c *Store_ListAPIKeys_Call) Return(_a0 *store.ListAPIKeysResponse, _a1 error) *Store_ListAPIKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListAPIKeys_Call) RunAndReturn(run func(context.Context, store.ListAPIKeysRequest) (*store.ListAPIKeysResponse, error)) *Store_ListAPIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuditLogs provides a mock function with given fields: ctx, req
+func (_m *Store) ListAuditLogs(ctx context.Context, req store.ListAuditLogsRequest) (*store.ListAuditLogsResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.ListAuditLogsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListAuditLogsRequest) (*store.ListAuditLogsResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListAuditLogsRequest) *store.ListAuditLogsResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.ListAuditLogsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListAuditLogsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditLogs'
+type Store_ListAuditLogs_Call struct {
+	*mock.Call
+}
+
+// ListAuditLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.ListAuditLogsRequest
+func (_e *Store_Expecter) ListAuditLogs(ctx interface{}, req interface{}) *Store_ListAuditLogs_Call {
+	return &Store_ListAuditLogs_Call{Call: _e.mock.On("ListAuditLogs", ctx, req)}
+}
+
+func (_c *Store_ListAuditLogs_Call) Run(run func(ctx context.Context, req store.ListAuditLogsRequest)) *Store_ListAuditLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.ListAuditLogsRequest))
+	})
+	return _c
+}
+
+func (_c *Store_ListAuditLogs_Call) Return(_a0 *store.ListAuditLogsResponse, _a1 error) *Store_ListAuditLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListAuditLogs_Call) RunAndReturn(run func(context.Context, store.ListAuditLogsRequest) (*store.ListAuditLogsResponse, error)) *Store_ListAuditLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOAuthClients provides a mock function with given fields: ctx, req
+func (_m *Store) ListOAuthClients(ctx context.Context, req store.ListOAuthClientsRequest) (*store.ListOAuthClientsResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.ListOAuthClientsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListOAuthClientsRequest) (*store.ListOAuthClientsResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListOAuthClientsRequest) *store.ListOAuthClientsResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.ListOAuthClientsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListOAuthClientsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListOAuthClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOAuthClients'
+type Store_ListOAuthClients_Call struct {
+	*mock.Call
+}
+
+// ListOAuthClients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.ListOAuthClientsRequest
+func (_e *Store_Expecter) ListOAuthClients(ctx interface{}, req interface{}) *Store_ListOAuthClients_Call {
+	return &Store_ListOAuthClients_Call{Call: _e.mock.On("ListOAuthClients", ctx, req)}
+}
+
+func (_c *Store_ListOAuthClients_Call) Run(run func(ctx context.Context, req store.ListOAuthClientsRequest)) *Store_ListOAuthClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.ListOAuthClientsRequest))
+	})
+	return _c
+}
+
+func (_c *Store_ListOAuthClients_Call) Return(_a0 *store.ListOAuthClientsResponse, _a1 error) *Store_ListOAuthClients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListOAuthClients_Call) RunAndReturn(run func(context.Context, store.ListOAuthClientsRequest) (*store.ListOAuthClientsResponse, error)) *Store_ListOAuthClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrganizationMembers provides a mock function with given fields: ctx, req
+func (_m *Store) ListOrganizationMembers(ctx context.Context, req store.ListOrganizationMembersRequest) (*store.ListOrganizationMembersResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.ListOrganizationMembersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListOrganizationMembersRequest) (*store.ListOrganizationMembersResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListOrganizationMembersRequest) *store.ListOrganizationMembersResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.ListOrganizationMembersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListOrganizationMembersRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListOrganizationMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrganizationMembers'
+type Store_ListOrganizationMembers_Call struct {
+	*mock.Call
+}
+
+// ListOrganizationMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.ListOrganizationMembersRequest
+func (_e *Store_Expecter) ListOrganizationMembers(ctx interface{}, req interface{}) *Store_ListOrganizationMembers_Call {
+	return &Store_ListOrganizationMembers_Call{Call: _e.mock.On("ListOrganizationMembers", ctx, req)}
+}
+
+func (_c *Store_ListOrganizationMembers_Call) Run(run func(ctx context.Context, req store.ListOrganizationMembersRequest)) *Store_ListOrganizationMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.ListOrganizationMembersRequest))
+	})
+	return _c
+}
+
+func (_c *Store_ListOrganizationMembers_Call) Return(_a0 *store.ListOrganizationMembersResponse, _a1 error) *Store_ListOrganizationMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListOrganizationMembers_Call) RunAndReturn(run func(context.Context, store.ListOrganizationMembersRequest) (*store.ListOrganizationMembersResponse, error)) *Store_ListOrganizationMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrganizations provides a mock function with given fields: ctx, req
+func (_m *Store) ListOrganizations(ctx context.Context, req store.ListOrganizationsRequest) (*store.ListOrganizationsResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.ListOrganizationsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListOrganizationsRequest) (*store.ListOrganizationsResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListOrganizationsRequest) *store.ListOrganizationsResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.ListOrganizationsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListOrganizationsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListOrganizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrganizations'
+type Store_ListOrganizations_Call struct {
+	*mock.Call
+}
+
+// ListOrganizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.ListOrganizationsRequest
+func (_e *Store_Expecter) ListOrganizations(ctx interface{}, req interface{}) *Store_ListOrganizations_Call {
+	return &Store_ListOrganizations_Call{Call: _e.mock.On("ListOrganizations", ctx, req)}
+}
+
+func (_c *Store_ListOrganizations_Call) Run(run func(ctx context.Context, req store.ListOrganizationsRequest)) *Store_ListOrganizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.ListOrganizationsRequest))
+	})
+	return _c
+}
+
+func (_c *Store_ListOrganizations_Call) Return(_a0 *store.ListOrganizationsResponse, _a1 error) *Store_ListOrganizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListOrganizations_Call) RunAndReturn(run func(context.Context, store.ListOrganizationsRequest) (*store.ListOrganizationsResponse, error)) *Store_ListOrganizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPlans provides a mock function with given fields: ctx, req
+func (_m *Store) ListPlans(ctx context.Context, req store.ListPlansRequest) (*store.ListPlansResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.ListPlansResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListPlansRequest) (*store.ListPlansResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListPlansRequest) *store.ListPlansResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.ListPlansResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListPlansRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListPlans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPlans'
+type Store_ListPlans_Call struct {
+	*mock.Call
+}
+
+// ListPlans is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.ListPlansRequest
+func (_e *Store_Expecter) ListPlans(ctx interface{}, req interface{}) *Store_ListPlans_Call {
+	return &Store_ListPlans_Call{Call: _e.mock.On("ListPlans", ctx, req)}
+}
+
+func (_c *Store_ListPlans_Call) Run(run func(ctx context.Context, req store.ListPlansRequest)) *Store_ListPlans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.ListPlansRequest))
+	})
+	return _c
+}
+
+func (_c *Store_ListPlans_Call) Return(_a0 *store.ListPlansResponse, _a1 error) *Store_ListPlans_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListPlans_Call) RunAndReturn(run func(context.Context, store.ListPlansRequest) (*store.ListPlansResponse, error)) *Store_ListPlans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSessions provides a mock function with given fields: ctx, req
+func (_m *Store) ListSessions(ctx context.Context, req store.ListSessionsRequest) (*store.ListSessionsResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.ListSessionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListSessionsRequest) (*store.ListSessionsResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListSessionsRequest) *store.ListSessionsResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.ListSessionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListSessionsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type Store_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.ListSessionsRequest
+func (_e *Store_Expecter) ListSessions(ctx interface{}, req interface{}) *Store_ListSessions_Call {
+	return &Store_ListSessions_Call{Call: _e.mock.On("ListSessions", ctx, req)}
+}
+
+func (_c *Store_ListSessions_Call) Run(run func(ctx context.Context, req store.ListSessionsRequest)) *Store_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.ListSessionsRequest))
+	})
+	return _c
+}
+
+func (_c *Store_ListSessions_Call) Return(_a0 *store.ListSessionsResponse, _a1 error) *Store_ListSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListSessions_Call) RunAndReturn(run func(context.Context, store.ListSessionsRequest) (*store.ListSessionsResponse, error)) *Store_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeadLetters provides a mock function with given fields: ctx, req
+func (_m *Store) ListWebhookDeadLetters(ctx context.Context, req store.ListWebhookDeadLettersRequest) (*store.ListWebhookDeadLettersResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.ListWebhookDeadLettersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListWebhookDeadLettersRequest) (*store.ListWebhookDeadLettersResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListWebhookDeadLettersRequest) *store.ListWebhookDeadLettersResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.ListWebhookDeadLettersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListWebhookDeadLettersRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListWebhookDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeadLetters'
+type Store_ListWebhookDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.ListWebhookDeadLettersRequest
+func (_e *Store_Expecter) ListWebhookDeadLetters(ctx interface{}, req interface{}) *Store_ListWebhookDeadLetters_Call {
+	return &Store_ListWebhookDeadLetters_Call{Call: _e.mock.On("ListWebhookDeadLetters", ctx, req)}
+}
+
+func (_c *Store_ListWebhookDeadLetters_Call) Run(run func(ctx context.Context, req store.ListWebhookDeadLettersRequest)) *Store_ListWebhookDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.ListWebhookDeadLettersRequest))
+	})
+	return _c
+}
+
+func (_c *Store_ListWebhookDeadLetters_Call) Return(_a0 *store.ListWebhookDeadLettersResponse, _a1 error) *Store_ListWebhookDeadLetters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListWebhookDeadLetters_Call) RunAndReturn(run func(context.Context, store.ListWebhookDeadLettersRequest) (*store.ListWebhookDeadLettersResponse, error)) *Store_ListWebhookDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhooks provides a mock function with given fields: ctx, req
+func (_m *Store) ListWebhooks(ctx context.Context, req store.ListWebhooksRequest) (*store.ListWebhooksResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.ListWebhooksResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListWebhooksRequest) (*store.ListWebhooksResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListWebhooksRequest) *store.ListWebhooksResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.ListWebhooksResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListWebhooksRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
+type Store_ListWebhooks_Call struct {
+	*mock.Call
+}
+
+// ListWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.ListWebhooksRequest
+func (_e *Store_Expecter) ListWebhooks(ctx interface{}, req interface{}) *Store_ListWebhooks_Call {
+	return &Store_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", ctx, req)}
+}
+
+func (_c *Store_ListWebhooks_Call) Run(run func(ctx context.Context, req store.ListWebhooksRequest)) *Store_ListWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.ListWebhooksRequest))
+	})
+	return _c
+}
+
+func (_c *Store_ListWebhooks_Call) Return(_a0 *store.ListWebhooksResponse, _a1 error) *Store_ListWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListWebhooks_Call) RunAndReturn(run func(context.Context, store.ListWebhooksRequest) (*store.ListWebhooksResponse, error)) *Store_ListWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *Store) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type Store_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Store_Expecter) Ping(ctx interface{}) *Store_Ping_Call {
+	return &Store_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *Store_Ping_Call) Run(run func(ctx context.Context)) *Store_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Store_Ping_Call) Return(_a0 error) *Store_Ping_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_Ping_Call) RunAndReturn(run func(context.Context) error) *Store_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAPIKey provides a mock function with given fields: ctx, req
+func (_m *Store) UpdateAPIKey(ctx context.Context, req store.UpdateAPIKeyRequest) (*store.UpdateAPIKeyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.UpdateAPIKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.UpdateAPIKeyRequest) (*store.UpdateAPIKeyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.UpdateAPIKeyRequest) *store.UpdateAPIKeyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.UpdateAPIKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.UpdateAPIKeyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_UpdateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAPIKey'
+type Store_UpdateAPIKey_Call struct {
+	*mock.Call
+}
+
+// UpdateAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.UpdateAPIKeyRequest
+func (_e *Store_Expecter) UpdateAPIKey(ctx interface{}, req interface{}) *Store_UpdateAPIKey_Call {
+	return &Store_UpdateAPIKey_Call{Call: _e.mock.On("UpdateAPIKey", ctx, req)}
+}
+
+func (_c *Store_UpdateAPIKey_Call) Run(run func(ctx context.Context, req store.UpdateAPIKeyRequest)) *Store_UpdateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.UpdateAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *Store_UpdateAPIKey_Call) Return(_a0 *store.UpdateAPIKeyResponse, _a1 error) *Store_UpdateAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_UpdateAPIKey_Call) RunAndReturn(run func(context.Context, store.UpdateAPIKeyRequest) (*store.UpdateAPIKeyResponse, error)) *Store_UpdateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSession provides a mock function with given fields: ctx, req
+func (_m *Store) UpdateSession(ctx context.Context, req store.UpdateSessionRequest) (*store.UpdateSessionResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *store.UpdateSessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.UpdateSessionRequest) (*store.UpdateSessionResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.UpdateSessionRequest) *store.UpdateSessionResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.UpdateSessionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.UpdateSessionRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_UpdateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSession'
+type Store_UpdateSession_Call struct {
+	*mock.Call
+}
+
+// UpdateSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req store.UpdateSessionRequest
+func (_e *Store_Expecter) UpdateSession(ctx interface{}, req interface{}) *Store_UpdateSession_Call {
+	return &Store_UpdateSession_Call{Call: _e.mock.On("UpdateSession", ctx, req)}
+}
+
+func (_c *Store_UpdateSession_Call) Run(run func(ctx context.Context, req store.UpdateSessionRequest)) *Store_UpdateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.UpdateSessionRequest))
+	})
+	return _c
+}
+
+func (_c *Store_UpdateSession_Call) Return(_a0 *store.UpdateSessionResponse, _a1 error) *Store_UpdateSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_UpdateSession_Call) RunAndReturn(run func(context.Context, store.UpdateSessionRequest) (*store.UpdateSessionResponse, error)) *Store_UpdateSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,34 +1,30 @@
 package model
 
-const (
-	APIKeyTypeUndefined APIKeyType = iota
-	APIKeyTypeUnlimited
-	APIKeyTypeLimited
-)
-
-type APIKeyType uint8
+type APIKey struct {
+	ID       uint64 `json:"id"`
+	APIKeyID string `json:"api_key"`
+	// UserID : owner of the key, empty for keys owned by an organization
+	UserID         string `json:"user_id"`
+	OrganizationID string `json:"organization_id,omitempty"`
+	// PlanID : limits of the key, changing it does not require reissuing the key
+	PlanID     string `json:"plan"`
+	Expiration int64  `json:"expiration"`
+	// Scopes : only set for OAuth2 clients, whose APIKeyID is the client ID. API keys are not restricted.
+	Scopes []string `json:"scopes,omitempty"`
 
-func (akt APIKeyType) Uint8() uint8 {
-	return uint8(akt)
+	User *User
 }
 
-func (akt APIKeyType) String() string {
-	switch akt {
-	case APIKeyTypeUnlimited:
-		return "unlimited"
-	case APIKeyTypeLimited:
-		return "limited"
-	default:
-		return "undefined"
+func (ak *APIKey) HasScope(scope string) bool {
+	if ak.Scopes == nil {
+		return true
 	}
-}
 
-type APIKey struct {
-	ID         uint64     `json:"id"`
-	APIKeyID   string     `json:"api_key"`
-	UserID     string     `json:"user_id"`
-	Type       APIKeyType `json:"type"`
-	Expiration int64      `json:"expiration"`
+	for _, s := range ak.Scopes {
+		if s == scope {
+			return true
+		}
+	}
 
-	User *User
+	return false
 }
@@ -0,0 +1,91 @@
+package model
+
+import "strings"
+
+const (
+	AuditActionUndefined AuditAction = iota
+	AuditActionAPIKeyRevoked
+	AuditActionUserCreated
+	AuditActionAPIKeyCreated
+	AuditActionAPIKeyRotated
+	AuditActionAPIKeyPlanChanged
+	AuditActionLogin
+	AuditActionLogout
+	AuditActionSessionRevoked
+	AuditActionOAuthClientCreated
+	AuditActionOAuthClientDeleted
+	AuditActionOrganizationCreated
+	AuditActionOrganizationMemberAdded
+	AuditActionOrganizationMemberRemoved
+	AuditActionWebhookCreated
+	AuditActionWebhookDeleted
+)
+
+type AuditAction uint8
+
+func (aa AuditAction) Uint8() uint8 {
+	return uint8(aa)
+}
+
+func (aa AuditAction) String() string {
+	switch aa {
+	case AuditActionAPIKeyRevoked:
+		return "api_key_revoked"
+	case AuditActionUserCreated:
+		return "user_created"
+	case AuditActionAPIKeyCreated:
+		return "api_key_created"
+	case AuditActionAPIKeyRotated:
+		return "api_key_rotated"
+	case AuditActionAPIKeyPlanChanged:
+		return "api_key_plan_changed"
+	case AuditActionLogin:
+		return "login"
+	case AuditActionLogout:
+		return "logout"
+	case AuditActionSessionRevoked:
+		return "session_revoked"
+	case AuditActionOAuthClientCreated:
+		return "oauth_client_created"
+	case AuditActionOAuthClientDeleted:
+		return "oauth_client_deleted"
+	case AuditActionOrganizationCreated:
+		return "organization_created"
+	case AuditActionOrganizationMemberAdded:
+		return "organization_member_added"
+	case AuditActionOrganizationMemberRemoved:
+		return "organization_member_removed"
+	case AuditActionWebhookCreated:
+		return "webhook_created"
+	case AuditActionWebhookDeleted:
+		return "webhook_deleted"
+	default:
+		return "undefined"
+	}
+}
+
+// AuditActionFromString : inverse of AuditAction.String. Returns AuditActionUndefined for unknown actions.
+func AuditActionFromString(action string) AuditAction {
+	action = strings.ToLower(strings.TrimSpace(action))
+
+	for aa := AuditActionUndefined + 1; aa.String() != AuditActionUndefined.String(); aa++ {
+		if aa.String() == action {
+			return aa
+		}
+	}
+
+	return AuditActionUndefined
+}
+
+// AuditLog : append-only record of an action performed by a user on a resource
+type AuditLog struct {
+	ID                   uint64      `json:"id"`
+	ActorUserID          string      `json:"actor_user_id"`
+	Action               AuditAction `json:"action"`
+	TargetUserID         string      `json:"target_user_id"`
+	TargetID             string      `json:"target_id"`
+	TargetOrganizationID string      `json:"target_organization_id"`
+	IP                   string      `json:"ip"`
+	UserAgent            string      `json:"user_agent"`
+	Timestamp            int64       `json:"timestamp"` // unix (s)
+}
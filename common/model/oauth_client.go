@@ -0,0 +1,12 @@
+package model
+
+// OAuthClient : client authenticating through the OAuth2 client credentials grant, for machine-to-machine access
+type OAuthClient struct {
+	ID        uint64   `json:"id"`
+	ClientID  string   `json:"client_id"`
+	UserID    string   `json:"user_id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	PlanID    string   `json:"plan"`
+	CreatedAt int64    `json:"created_at"` // unix (s)
+}
@@ -0,0 +1,67 @@
+package model
+
+import "strings"
+
+const (
+	OrganizationRoleUndefined OrganizationRole = iota
+	OrganizationRoleMember
+	OrganizationRoleAdmin
+	OrganizationRoleOwner
+)
+
+// OrganizationRole : roles are ordered, each one granting the permissions of the previous ones
+type OrganizationRole uint8
+
+func (or OrganizationRole) Uint8() uint8 {
+	return uint8(or)
+}
+
+func (or OrganizationRole) String() string {
+	switch or {
+	case OrganizationRoleMember:
+		return "member"
+	case OrganizationRoleAdmin:
+		return "admin"
+	case OrganizationRoleOwner:
+		return "owner"
+	default:
+		return "undefined"
+	}
+}
+
+// OrganizationRoleFromString : inverse of OrganizationRole.String. Returns OrganizationRoleUndefined for unknown roles.
+func OrganizationRoleFromString(role string) OrganizationRole {
+	role = strings.ToLower(strings.TrimSpace(role))
+
+	for r := OrganizationRoleUndefined + 1; r.String() != OrganizationRoleUndefined.String(); r++ {
+		if r.String() == role {
+			return r
+		}
+	}
+
+	return OrganizationRoleUndefined
+}
+
+// Organization : group of users sharing API keys and their quota. Keys owned by an organization are not bound to any
+// of its members, and are kept when members leave.
+type Organization struct {
+	ID             uint64 `json:"id"`
+	OrganizationID string `json:"organization_id"`
+	Name           string `json:"name"`
+	CreatedAt      int64  `json:"created_at"` // unix (s)
+}
+
+type OrganizationMember struct {
+	ID             uint64           `json:"id"`
+	OrganizationID string           `json:"organization_id"`
+	UserID         string           `json:"user_id"`
+	Role           OrganizationRole `json:"role"`
+	CreatedAt      int64            `json:"created_at"` // unix (s)
+
+	User *User `json:"user,omitempty"`
+}
+
+// CanManage : whether the member can manage the organization keys and members
+func (om *OrganizationMember) CanManage() bool {
+	return om != nil && om.Role >= OrganizationRoleAdmin
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lruggieri/fxnow/common/currency"
+)
+
+const (
+	// PlanFree : plan of keys and clients created without choosing one
+	PlanFree      = "free"
+	PlanUnlimited = "unlimited"
+
+	PlanEndpointRate = "rate"
+)
+
+// Plan : limits applied to the keys referencing it. Zero values mean no limit. Requests are counted in cost units,
+// which grow with the pairs and periods requested.
+type Plan struct {
+	ID                 uint64   `json:"id"`
+	PlanID             string   `json:"plan_id"`
+	Name               string   `json:"name"`
+	RequestsPerMinute  int      `json:"requests_per_minute"`
+	RequestsPerDay     int      `json:"requests_per_day"`
+	RequestsPerMonth   int      `json:"requests_per_month"`
+	MaxPairsPerRequest int      `json:"max_pairs_per_request"`
+	Endpoints          []string `json:"endpoints"`
+	// Freshness : how often rates served to the plan are refreshed, 0 for real-time rates
+	Freshness int64 `json:"freshness"` // seconds
+	// SpreadBps : spread in basis points quoted around the mid rate, when the source does not provide bid and ask
+	SpreadBps float64 `json:"spread_bps"`
+}
+
+func (p *Plan) AllowsEndpoint(endpoint string) bool {
+	for _, e := range p.Endpoints {
+		if e == endpoint {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Plan) FreshnessDuration() time.Duration {
+	return time.Duration(p.Freshness) * time.Second
+}
+
+// SyntheticBidAsk : bid and ask half of the spread away from the mid rate, 0 if the plan has no spread
+func (p *Plan) SyntheticBidAsk(mid float64) (bid, ask float64) {
+	if p.SpreadBps <= 0 {
+		return 0, 0
+	}
+
+	halfSpread := mid * p.SpreadBps / 2 / 10000
+
+	return currency.RoundRate(mid - halfSpread), currency.RoundRate(mid + halfSpread)
+}
@@ -0,0 +1,14 @@
+package model
+
+// Session : first-party session of a user, issued by identity after a successful login
+type Session struct {
+	ID                uint64 `json:"id"`
+	SessionID         string `json:"session_id"`
+	UserID            string `json:"user_id"`
+	Provider          string `json:"provider"`
+	IP                string `json:"ip"`
+	UserAgent         string `json:"user_agent"`
+	CreatedAt         int64  `json:"created_at"`         // unix (s)
+	Expiration        int64  `json:"expiration"`         // unix (s)
+	RefreshExpiration int64  `json:"refresh_expiration"` // unix (s)
+}
@@ -1,9 +1,37 @@
 package model
 
+const (
+	UserRoleUndefined UserRole = iota
+	UserRoleStandard
+	UserRoleAdmin
+)
+
+type UserRole uint8
+
+func (ur UserRole) Uint8() uint8 {
+	return uint8(ur)
+}
+
+func (ur UserRole) String() string {
+	switch ur {
+	case UserRoleStandard:
+		return "standard"
+	case UserRoleAdmin:
+		return "admin"
+	default:
+		return "undefined"
+	}
+}
+
 type User struct {
-	ID        uint64 `json:"id"`
-	UserID    string `json:"user_id"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
+	ID        uint64   `json:"id"`
+	UserID    string   `json:"user_id"`
+	FirstName string   `json:"first_name"`
+	LastName  string   `json:"last_name"`
+	Email     string   `json:"email"`
+	Role      UserRole `json:"role"`
+}
+
+func (u *User) IsAdmin() bool {
+	return u != nil && u.Role == UserRoleAdmin
 }
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// MaxWebhookWindow : longest window change conditions can be evaluated on
+	MaxWebhookWindow = 24 * time.Hour
+)
+
+const (
+	WebhookConditionUndefined WebhookCondition = iota
+	// WebhookConditionCrosses : the rate crosses the threshold, in either direction
+	WebhookConditionCrosses
+	// WebhookConditionChange : the rate moves by at least threshold percent within the window
+	WebhookConditionChange
+)
+
+type WebhookCondition uint8
+
+func (wc WebhookCondition) Uint8() uint8 {
+	return uint8(wc)
+}
+
+func (wc WebhookCondition) String() string {
+	switch wc {
+	case WebhookConditionCrosses:
+		return "crosses"
+	case WebhookConditionChange:
+		return "change"
+	default:
+		return "undefined"
+	}
+}
+
+// WebhookConditionFromString : inverse of WebhookCondition.String. Returns WebhookConditionUndefined for unknown
+// conditions.
+func WebhookConditionFromString(condition string) WebhookCondition {
+	condition = strings.ToLower(strings.TrimSpace(condition))
+
+	for wc := WebhookConditionUndefined + 1; wc.String() != WebhookConditionUndefined.String(); wc++ {
+		if wc.String() == condition {
+			return wc
+		}
+	}
+
+	return WebhookConditionUndefined
+}
+
+// Webhook : URL notified when the rate of a pair meets the condition
+type Webhook struct {
+	ID        uint64 `json:"id"`
+	WebhookID string `json:"webhook_id"`
+	UserID    string `json:"user_id"`
+	URL       string `json:"url"`
+	// Secret : key of the HMAC signature of the payloads. It must be kept in clear to sign them.
+	Secret    string           `json:"-"`
+	Pair      string           `json:"pair"`
+	Condition WebhookCondition `json:"condition"`
+	Threshold float64          `json:"threshold"`
+	Window    int64            `json:"window"` // seconds, change conditions only
+	CreatedAt int64            `json:"created_at"`
+}
+
+func (w *Webhook) WindowDuration() time.Duration {
+	return time.Duration(w.Window) * time.Second
+}
+
+// WebhookDeadLetter : payload that could not be delivered after all the attempts
+type WebhookDeadLetter struct {
+	ID        uint64 `json:"id"`
+	WebhookID string `json:"webhook_id"`
+	Payload   string `json:"payload"`
+	Error     string `json:"error"`
+	Attempts  int    `json:"attempts"`
+	CreatedAt int64  `json:"created_at"` // unix (s)
+}
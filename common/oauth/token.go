@@ -0,0 +1,133 @@
+package oauth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/clock"
+	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/util"
+)
+
+const (
+	// AccessTokenLifetime : client credentials tokens are short-lived, as they cannot be revoked before they expire
+	AccessTokenLifetime = 15 * time.Minute
+
+	ScopeRatesRead = "rates:read"
+
+	issuer = "fxnow-identity"
+	// minKeyLength : HS256 keys shorter than the hash size weaken the signature
+	minKeyLength = 32
+)
+
+// AllScopes : scopes that can be granted to clients
+var AllScopes = []string{ScopeRatesRead}
+
+// Claims : what an access token grants to the client holding it
+type Claims struct {
+	ClientID string
+	// UserID : owner of the client
+	UserID string
+	Scopes []string
+	PlanID string
+
+	Expiration int64 // Unix time (seconds)
+}
+
+type tokenClaims struct {
+	jwt.Claims
+
+	UserID string `json:"uid"`
+	Scope  string `json:"scope"`
+	PlanID string `json:"plan"`
+}
+
+// Signer : issues and verifies client credentials access tokens. Tokens are HS256 JWTs, so services sharing the key
+// can verify them without any lookup.
+type Signer struct {
+	signer jose.Signer
+	key    []byte
+	clock  clock.Clock
+}
+
+func (s *Signer) Issue(claims Claims) (string, int64, error) {
+	now := s.clock.Now()
+	expiration := now.Add(AccessTokenLifetime)
+
+	token, err := jwt.Signed(s.signer).Claims(tokenClaims{
+		Claims: jwt.Claims{
+			Issuer:   issuer,
+			Subject:  claims.ClientID,
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(expiration),
+		},
+		UserID: claims.UserID,
+		Scope:  strings.Join(claims.Scopes, " "),
+		PlanID: claims.PlanID,
+	}).CompactSerialize()
+	if err != nil {
+		return "", 0, errors.Wrap(err, "cannot sign access token")
+	}
+
+	return token, expiration.Unix(), nil
+}
+
+func (s *Signer) Verify(token string) (*Claims, error) {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, errors.Wrap(cError.ErrNotAuthenticated, "malformed access token")
+	}
+
+	var tc tokenClaims
+
+	if err = parsed.Claims(s.key, &tc); err != nil {
+		return nil, errors.Wrap(cError.ErrNotAuthenticated, "invalid access token signature")
+	}
+
+	if err = tc.ValidateWithLeeway(jwt.Expected{Issuer: issuer, Time: s.clock.Now()}, 0); err != nil {
+		return nil, errors.Wrap(cError.ErrNotAuthenticated, "invalid access token")
+	}
+
+	if tc.Subject == "" || tc.Expiry == nil {
+		return nil, errors.Wrap(cError.ErrNotAuthenticated, "incomplete access token")
+	}
+
+	return &Claims{
+		ClientID:   tc.Subject,
+		UserID:     tc.UserID,
+		Scopes:     strings.Fields(tc.Scope),
+		PlanID:     tc.PlanID,
+		Expiration: tc.Expiry.Time().Unix(),
+	}, nil
+}
+
+// IsAccessToken : tells access tokens apart from API keys
+func IsAccessToken(credential string) bool {
+	return strings.Count(credential, ".") == 2
+}
+
+// ValidScopes : whether all scopes can be granted to clients
+func ValidScopes(scopes []string) bool {
+	return util.SliceContains(AllScopes, scopes)
+}
+
+func NewSigner(key []byte, clk clock.Clock) (*Signer, error) {
+	if len(key) < minKeyLength {
+		return nil, errors.Errorf("access token key must be at least %d bytes long", minKeyLength)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: key}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create access token signer")
+	}
+
+	return &Signer{
+		signer: signer,
+		key:    key,
+		clock:  clk,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package oauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+	"github.com/lruggieri/fxnow/common/model"
+)
+
+func TestSigner(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	now := time.Unix(1700000000, 0)
+
+	newSigner := func(t *testing.T, key []byte, at time.Time) *Signer {
+		c := mockclock.NewClock(t)
+		c.EXPECT().Now().Return(at).Maybe()
+
+		s, err := NewSigner(key, c)
+		require.NoError(t, err)
+
+		return s
+	}
+
+	claims := Claims{
+		ClientID: "client_id",
+		UserID:   "user_id",
+		Scopes:   []string{ScopeRatesRead},
+		PlanID:   model.PlanFree,
+	}
+
+	t.Run("error-short-key", func(t *testing.T) {
+		_, err := NewSigner([]byte("short"), mockclock.NewClock(t))
+		assert.Error(t, err)
+	})
+
+	t.Run("happy-path", func(t *testing.T) {
+		s := newSigner(t, key, now)
+
+		token, expiration, err := s.Issue(claims)
+		require.NoError(t, err)
+		assert.True(t, IsAccessToken(token))
+		assert.Equal(t, now.Add(AccessTokenLifetime).Unix(), expiration)
+
+		res, err := s.Verify(token)
+		require.NoError(t, err)
+
+		expected := claims
+		expected.Expiration = expiration
+		assert.Equal(t, &expected, res)
+	})
+
+	t.Run("error-expired", func(t *testing.T) {
+		token, _, err := newSigner(t, key, now).Issue(claims)
+		require.NoError(t, err)
+
+		_, err = newSigner(t, key, now.Add(AccessTokenLifetime+time.Second)).Verify(token)
+		assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+	})
+
+	t.Run("error-other-key", func(t *testing.T) {
+		token, _, err := newSigner(t, key, now).Issue(claims)
+		require.NoError(t, err)
+
+		_, err = newSigner(t, []byte("fedcba9876543210fedcba9876543210"), now).Verify(token)
+		assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+	})
+
+	t.Run("error-malformed", func(t *testing.T) {
+		_, err := newSigner(t, key, now).Verify("not.a.token")
+		assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+	})
+}
+
+func TestIsAccessToken(t *testing.T) {
+	assert.False(t, IsAccessToken("3kP4Fq8JvnhBRr7ZwZkYZe"))
+	assert.True(t, IsAccessToken("header.payload.signature"))
+}
+
+func TestValidScopes(t *testing.T) {
+	assert.True(t, ValidScopes(nil))
+	assert.True(t, ValidScopes([]string{ScopeRatesRead}))
+	assert.False(t, ValidScopes([]string{ScopeRatesRead, "admin"}))
+}
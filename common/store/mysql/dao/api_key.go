@@ -5,11 +5,12 @@ import (
 )
 
 type APIKey struct {
-	ID         uint64       `gorm:"column:id"`
-	APIKeyID   string       `gorm:"column:api_key_id"`
-	UserID     string       `gorm:"column:user_id"`
-	Type       uint8        `gorm:"column:type"`
-	Expiration sql.NullTime `gorm:"column:expiration"`
+	ID             uint64       `gorm:"column:id"`
+	APIKeyID       string       `gorm:"column:api_key_id"`
+	UserID         string       `gorm:"column:user_id"`
+	OrganizationID string       `gorm:"column:organization_id"`
+	PlanID         string       `gorm:"column:plan_id"`
+	Expiration     sql.NullTime `gorm:"column:expiration"`
 
 	User *User `gorm:"foreignKey:UserID;references:UserID"`
 }
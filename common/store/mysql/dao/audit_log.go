@@ -0,0 +1,21 @@
+package dao
+
+import (
+	"time"
+)
+
+type AuditLog struct {
+	ID                   uint64    `gorm:"column:id"`
+	ActorUserID          string    `gorm:"column:actor_user_id"`
+	Action               uint8     `gorm:"column:action"`
+	TargetUserID         string    `gorm:"column:target_user_id"`
+	TargetID             string    `gorm:"column:target_id"`
+	TargetOrganizationID string    `gorm:"column:target_organization_id"`
+	IP                   string    `gorm:"column:ip"`
+	UserAgent            string    `gorm:"column:user_agent"`
+	Timestamp            time.Time `gorm:"column:timestamp"`
+}
+
+func (*AuditLog) TableName() string {
+	return "audit_log"
+}
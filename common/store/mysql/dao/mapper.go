@@ -1,6 +1,8 @@
 package dao
 
 import (
+	"strings"
+
 	"github.com/lruggieri/fxnow/common/model"
 	"github.com/lruggieri/fxnow/common/store/mysql/util"
 )
@@ -11,11 +13,12 @@ func APIKeyToModel(in *APIKey) *model.APIKey {
 	}
 
 	return &model.APIKey{
-		ID:         in.ID,
-		APIKeyID:   in.APIKeyID,
-		UserID:     in.UserID,
-		Type:       model.APIKeyType(in.Type),
-		Expiration: util.SQLTimeToUnix(in.Expiration),
+		ID:             in.ID,
+		APIKeyID:       in.APIKeyID,
+		UserID:         in.UserID,
+		OrganizationID: in.OrganizationID,
+		PlanID:         in.PlanID,
+		Expiration:     util.SQLTimeToUnix(in.Expiration),
 
 		User: UserToModel(in.User),
 	}
@@ -32,5 +35,140 @@ func UserToModel(in *User) *model.User {
 		FirstName: in.FirstName,
 		LastName:  in.LastName,
 		Email:     in.Email,
+		Role:      model.UserRole(in.Role),
+	}
+}
+
+func AuditLogToModel(in *AuditLog) *model.AuditLog {
+	if in == nil {
+		return nil
+	}
+
+	return &model.AuditLog{
+		ID:                   in.ID,
+		ActorUserID:          in.ActorUserID,
+		Action:               model.AuditAction(in.Action),
+		TargetUserID:         in.TargetUserID,
+		TargetID:             in.TargetID,
+		TargetOrganizationID: in.TargetOrganizationID,
+		IP:                   in.IP,
+		UserAgent:            in.UserAgent,
+		Timestamp:            in.Timestamp.Unix(),
+	}
+}
+
+func SessionToModel(in *Session) *model.Session {
+	if in == nil {
+		return nil
+	}
+
+	return &model.Session{
+		ID:                in.ID,
+		SessionID:         in.SessionID,
+		UserID:            in.UserID,
+		Provider:          in.Provider,
+		IP:                in.IP,
+		UserAgent:         in.UserAgent,
+		CreatedAt:         in.CreatedAt.Unix(),
+		Expiration:        in.Expiration.Unix(),
+		RefreshExpiration: in.RefreshExpiration.Unix(),
+	}
+}
+
+func OAuthClientToModel(in *OAuthClient) *model.OAuthClient {
+	if in == nil {
+		return nil
+	}
+
+	return &model.OAuthClient{
+		ID:        in.ID,
+		ClientID:  in.ClientID,
+		UserID:    in.UserID,
+		Name:      in.Name,
+		Scopes:    strings.Fields(in.Scopes),
+		PlanID:    in.PlanID,
+		CreatedAt: in.CreatedAt.Unix(),
+	}
+}
+
+func OrganizationToModel(in *Organization) *model.Organization {
+	if in == nil {
+		return nil
+	}
+
+	return &model.Organization{
+		ID:             in.ID,
+		OrganizationID: in.OrganizationID,
+		Name:           in.Name,
+		CreatedAt:      in.CreatedAt.Unix(),
+	}
+}
+
+func OrganizationMemberToModel(in *OrganizationMember) *model.OrganizationMember {
+	if in == nil {
+		return nil
+	}
+
+	return &model.OrganizationMember{
+		ID:             in.ID,
+		OrganizationID: in.OrganizationID,
+		UserID:         in.UserID,
+		Role:           model.OrganizationRole(in.Role),
+		CreatedAt:      in.CreatedAt.Unix(),
+
+		User: UserToModel(in.User),
+	}
+}
+
+func PlanToModel(in *Plan) *model.Plan {
+	if in == nil {
+		return nil
+	}
+
+	return &model.Plan{
+		ID:                 in.ID,
+		PlanID:             in.PlanID,
+		Name:               in.Name,
+		RequestsPerMinute:  in.RequestsPerMinute,
+		RequestsPerDay:     in.RequestsPerDay,
+		RequestsPerMonth:   in.RequestsPerMonth,
+		MaxPairsPerRequest: in.MaxPairsPerRequest,
+		Endpoints:          strings.Fields(in.Endpoints),
+		Freshness:          in.Freshness,
+		SpreadBps:          in.SpreadBps,
+	}
+}
+
+func WebhookToModel(in *Webhook) *model.Webhook {
+	if in == nil {
+		return nil
+	}
+
+	return &model.Webhook{
+		ID:        in.ID,
+		WebhookID: in.WebhookID,
+		UserID:    in.UserID,
+		URL:       in.URL,
+		Secret:    in.Secret,
+		Pair:      in.Pair,
+		Condition: model.WebhookCondition(in.Condition),
+		Threshold: in.Threshold,
+		Window:    in.Window,
+		CreatedAt: in.CreatedAt.Unix(),
+	}
+}
+
+func WebhookDeadLetterToModel(in *WebhookDeadLetter) *model.WebhookDeadLetter {
+	if in == nil {
+		return nil
+	}
+
+	return &model.WebhookDeadLetter{
+		ID:        in.ID,
+		WebhookID: in.WebhookID,
+		Payload:   in.Payload,
+		Error:     in.Error,
+		Attempts:  in.Attempts,
+		CreatedAt: in.CreatedAt.Unix(),
 	}
 }
@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"time"
+)
+
+type OAuthClient struct {
+	ID         uint64    `gorm:"column:id"`
+	ClientID   string    `gorm:"column:client_id"`
+	UserID     string    `gorm:"column:user_id"`
+	Name       string    `gorm:"column:name"`
+	SecretHash string    `gorm:"column:secret_hash"`
+	Scopes     string    `gorm:"column:scopes"` // space separated
+	PlanID     string    `gorm:"column:plan_id"`
+	CreatedAt  time.Time `gorm:"column:db_create_time;->"`
+}
+
+func (*OAuthClient) TableName() string {
+	return "oauth_client"
+}
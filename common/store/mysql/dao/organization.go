@@ -0,0 +1,30 @@
+package dao
+
+import (
+	"time"
+)
+
+type Organization struct {
+	ID             uint64    `gorm:"column:id"`
+	OrganizationID string    `gorm:"column:organization_id"`
+	Name           string    `gorm:"column:name"`
+	CreatedAt      time.Time `gorm:"column:db_create_time;->"`
+}
+
+func (*Organization) TableName() string {
+	return "organization"
+}
+
+type OrganizationMember struct {
+	ID             uint64    `gorm:"column:id"`
+	OrganizationID string    `gorm:"column:organization_id"`
+	UserID         string    `gorm:"column:user_id"`
+	Role           uint8     `gorm:"column:role"`
+	CreatedAt      time.Time `gorm:"column:db_create_time;->"`
+
+	User *User `gorm:"foreignKey:UserID;references:UserID"`
+}
+
+func (*OrganizationMember) TableName() string {
+	return "organization_member"
+}
@@ -0,0 +1,18 @@
+package dao
+
+type Plan struct {
+	ID                 uint64  `gorm:"column:id"`
+	PlanID             string  `gorm:"column:plan_id"`
+	Name               string  `gorm:"column:name"`
+	RequestsPerMinute  int     `gorm:"column:requests_per_minute"`
+	RequestsPerDay     int     `gorm:"column:requests_per_day"`
+	RequestsPerMonth   int     `gorm:"column:requests_per_month"`
+	MaxPairsPerRequest int     `gorm:"column:max_pairs_per_request"`
+	Endpoints          string  `gorm:"column:endpoints"` // space separated
+	Freshness          int64   `gorm:"column:freshness"` // seconds
+	SpreadBps          float64 `gorm:"column:spread_bps"`
+}
+
+func (*Plan) TableName() string {
+	return "plan"
+}
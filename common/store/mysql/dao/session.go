@@ -0,0 +1,23 @@
+package dao
+
+import (
+	"time"
+)
+
+type Session struct {
+	ID                uint64    `gorm:"column:id"`
+	SessionID         string    `gorm:"column:session_id"`
+	UserID            string    `gorm:"column:user_id"`
+	TokenHash         string    `gorm:"column:token_hash"`
+	RefreshTokenHash  string    `gorm:"column:refresh_token_hash"`
+	Provider          string    `gorm:"column:provider"`
+	IP                string    `gorm:"column:ip"`
+	UserAgent         string    `gorm:"column:user_agent"`
+	Expiration        time.Time `gorm:"column:expiration"`
+	RefreshExpiration time.Time `gorm:"column:refresh_expiration"`
+	CreatedAt         time.Time `gorm:"column:db_create_time;->"`
+}
+
+func (*Session) TableName() string {
+	return "session"
+}
@@ -6,6 +6,7 @@ type User struct {
 	FirstName string `gorm:"column:first_name"`
 	LastName  string `gorm:"column:last_name"`
 	Email     string `gorm:"column:email"`
+	Role      uint8  `gorm:"column:role"`
 }
 
 func (*User) TableName() string {
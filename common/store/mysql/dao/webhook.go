@@ -0,0 +1,35 @@
+package dao
+
+import (
+	"time"
+)
+
+type Webhook struct {
+	ID        uint64    `gorm:"column:id"`
+	WebhookID string    `gorm:"column:webhook_id"`
+	UserID    string    `gorm:"column:user_id"`
+	URL       string    `gorm:"column:url"`
+	Secret    string    `gorm:"column:secret"`
+	Pair      string    `gorm:"column:pair"`
+	Condition uint8     `gorm:"column:condition"`
+	Threshold float64   `gorm:"column:threshold"`
+	Window    int64     `gorm:"column:window"`
+	CreatedAt time.Time `gorm:"column:db_create_time;->"`
+}
+
+func (*Webhook) TableName() string {
+	return "webhook"
+}
+
+type WebhookDeadLetter struct {
+	ID        uint64    `gorm:"column:id"`
+	WebhookID string    `gorm:"column:webhook_id"`
+	Payload   string    `gorm:"column:payload"`
+	Error     string    `gorm:"column:error"`
+	Attempts  int       `gorm:"column:attempts"`
+	CreatedAt time.Time `gorm:"column:db_create_time;->"`
+}
+
+func (*WebhookDeadLetter) TableName() string {
+	return "webhook_dead_letter"
+}
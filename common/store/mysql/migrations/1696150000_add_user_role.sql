@@ -0,0 +1,4 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+ALTER TABLE `user`
+    ADD COLUMN `role` TINYINT NOT NULL DEFAULT 1 COMMENT 'role (1: standard, 2: admin)' AFTER `email`;
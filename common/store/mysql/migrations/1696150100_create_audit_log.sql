@@ -0,0 +1,15 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+CREATE TABLE `audit_log` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'id',
+    `actor_user_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'shortuuid of the user performing the action',
+    `action` TINYINT NOT NULL DEFAULT 0 COMMENT 'action',
+    `target_user_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'shortuuid of the user owning the target resource',
+    `target_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'id of the target resource',
+    `timestamp` DATETIME(3) NOT NULL COMMENT 'action time',
+
+    `db_create_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) COMMENT 'database insertion time, please do not modify',
+    PRIMARY KEY (`id`),
+    KEY `idx_actor_user_id` (`actor_user_id`),
+    KEY `idx_target_user_id` (`target_user_id`)
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = UTF8MB4 COMMENT = 'append-only audit log table';
@@ -0,0 +1,6 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+ALTER TABLE `audit_log`
+    ADD COLUMN `ip` VARCHAR(45) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'client IP address' AFTER `target_id`,
+    ADD COLUMN `user_agent` VARCHAR(512) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'client user agent' AFTER `ip`,
+    ADD KEY `idx_action_timestamp` (`action`, `timestamp`);
@@ -0,0 +1,24 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+CREATE TABLE `session` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'id',
+    `session_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'session shortuuid id',
+    `user_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'user shortuuid id',
+    `token_hash` CHAR(64) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'sha256 of the session token',
+    `refresh_token_hash` CHAR(64) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'sha256 of the refresh token',
+    `provider` VARCHAR(64) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'identity provider used to log in',
+    `ip` VARCHAR(45) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'client IP address at login',
+    `user_agent` VARCHAR(512) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'client user agent at login',
+    `expiration` DATETIME(3) NOT NULL COMMENT 'session token expiration time',
+    `refresh_expiration` DATETIME(3) NOT NULL COMMENT 'refresh token expiration time',
+
+    `db_create_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) COMMENT 'database insertion time, please do not modify',
+    `db_modify_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) ON UPDATE CURRENT_TIMESTAMP (3) COMMENT 'database update time, please do not modify',
+    `disabled_at` TIMESTAMP NULL DEFAULT NULL COMMENT 'disabled time',
+    `disabled` TINYINT DEFAULT '0' COMMENT 'soft delete',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uniq_idx_session_id` (`session_id`),
+    UNIQUE KEY `uniq_idx_token_hash` (`token_hash`),
+    UNIQUE KEY `uniq_idx_refresh_token_hash` (`refresh_token_hash`),
+    KEY `idx_user_id` (`user_id`)
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = UTF8MB4 COMMENT = 'user session table';
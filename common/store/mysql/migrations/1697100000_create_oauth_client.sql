@@ -0,0 +1,19 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+CREATE TABLE `oauth_client` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'id',
+    `client_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'client shortuuid id',
+    `user_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'owner shortuuid id',
+    `name` VARCHAR(128) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'client name',
+    `secret_hash` CHAR(64) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'sha256 of the client secret',
+    `scopes` VARCHAR(512) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'space separated granted scopes',
+    `tier` TINYINT NOT NULL DEFAULT 0 COMMENT 'rate limit tier, same values as api_key.type',
+
+    `db_create_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) COMMENT 'database insertion time, please do not modify',
+    `db_modify_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) ON UPDATE CURRENT_TIMESTAMP (3) COMMENT 'database update time, please do not modify',
+    `disabled_at` TIMESTAMP NULL DEFAULT NULL COMMENT 'disabled time',
+    `disabled` TINYINT DEFAULT '0' COMMENT 'soft delete',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uniq_idx_client_id` (`client_id`),
+    KEY `idx_user_id` (`user_id`)
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = UTF8MB4 COMMENT = 'OAuth2 client table';
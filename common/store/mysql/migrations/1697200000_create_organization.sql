@@ -0,0 +1,29 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+CREATE TABLE `organization` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'id',
+    `organization_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'organization shortuuid id',
+    `name` VARCHAR(128) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'organization name',
+
+    `db_create_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) COMMENT 'database insertion time, please do not modify',
+    `db_modify_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) ON UPDATE CURRENT_TIMESTAMP (3) COMMENT 'database update time, please do not modify',
+    `disabled_at` TIMESTAMP NULL DEFAULT NULL COMMENT 'disabled time',
+    `disabled` TINYINT DEFAULT '0' COMMENT 'soft delete',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uniq_idx_organization_id` (`organization_id`)
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = UTF8MB4 COMMENT = 'organization table';
+
+CREATE TABLE `organization_member` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'id',
+    `organization_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'organization shortuuid id',
+    `user_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'member shortuuid id',
+    `role` TINYINT NOT NULL DEFAULT 0 COMMENT 'role within the organization',
+
+    `db_create_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) COMMENT 'database insertion time, please do not modify',
+    `db_modify_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) ON UPDATE CURRENT_TIMESTAMP (3) COMMENT 'database update time, please do not modify',
+    `disabled_at` TIMESTAMP NULL DEFAULT NULL COMMENT 'disabled time',
+    `disabled` TINYINT DEFAULT '0' COMMENT 'soft delete',
+    PRIMARY KEY (`id`),
+    KEY `idx_organization_id_user_id` (`organization_id`, `user_id`),
+    KEY `idx_user_id` (`user_id`)
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = UTF8MB4 COMMENT = 'organization membership table';
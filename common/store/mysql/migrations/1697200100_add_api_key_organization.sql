@@ -0,0 +1,5 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+ALTER TABLE `api_key`
+    ADD COLUMN `organization_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'owning organization shortuuid id, empty for personal keys' AFTER `user_id`,
+    ADD KEY `idx_organization_id` (`organization_id`);
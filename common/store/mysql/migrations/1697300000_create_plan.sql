@@ -0,0 +1,24 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+CREATE TABLE `plan` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'id',
+    `plan_id` VARCHAR(32) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'plan identifier',
+    `name` VARCHAR(128) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'plan display name',
+    `requests_per_minute` INT NOT NULL DEFAULT 0 COMMENT 'maximum requests per minute, 0 for no limit',
+    `requests_per_day` INT NOT NULL DEFAULT 0 COMMENT 'maximum requests per day, 0 for no limit',
+    `max_pairs_per_request` INT NOT NULL DEFAULT 0 COMMENT 'maximum pairs per request, 0 for no limit',
+    `endpoints` VARCHAR(256) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'space separated allowed endpoints',
+    `freshness` INT NOT NULL DEFAULT 0 COMMENT 'seconds between refreshes of the served rates, 0 for real-time',
+
+    `db_create_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) COMMENT 'database insertion time, please do not modify',
+    `db_modify_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) ON UPDATE CURRENT_TIMESTAMP (3) COMMENT 'database update time, please do not modify',
+    `disabled_at` TIMESTAMP NULL DEFAULT NULL COMMENT 'disabled time',
+    `disabled` TINYINT DEFAULT '0' COMMENT 'soft delete',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uniq_idx_plan_id` (`plan_id`)
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = UTF8MB4 COMMENT = 'plan catalog table';
+
+INSERT INTO `plan` (`plan_id`, `name`, `requests_per_minute`, `requests_per_day`, `max_pairs_per_request`, `endpoints`, `freshness`)
+VALUES
+    ('free', 'Free', 2, 1000, 10, 'rate', 60),
+    ('unlimited', 'Unlimited', 0, 0, 0, 'rate history stream', 0);
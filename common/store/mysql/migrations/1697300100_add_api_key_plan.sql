@@ -0,0 +1,16 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+-- plans replace the key types (1: unlimited, 2: limited)
+ALTER TABLE `api_key`
+    ADD COLUMN `plan_id` VARCHAR(32) CHARACTER SET UTF8MB4 NOT NULL DEFAULT 'free' COMMENT 'plan identifier' AFTER `organization_id`;
+
+UPDATE `api_key` SET `plan_id` = IF(`type` = 1, 'unlimited', 'free');
+
+ALTER TABLE `api_key` DROP COLUMN `type`;
+
+ALTER TABLE `oauth_client`
+    ADD COLUMN `plan_id` VARCHAR(32) CHARACTER SET UTF8MB4 NOT NULL DEFAULT 'free' COMMENT 'plan identifier' AFTER `scopes`;
+
+UPDATE `oauth_client` SET `plan_id` = IF(`tier` = 1, 'unlimited', 'free');
+
+ALTER TABLE `oauth_client` DROP COLUMN `tier`;
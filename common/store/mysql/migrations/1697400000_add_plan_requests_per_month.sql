@@ -0,0 +1,6 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+ALTER TABLE `plan`
+    ADD COLUMN `requests_per_month` INT NOT NULL DEFAULT 0 COMMENT 'maximum requests per calendar month, 0 for no limit' AFTER `requests_per_day`;
+
+UPDATE `plan` SET `requests_per_month` = 25000 WHERE `plan_id` = 'free';
@@ -0,0 +1,12 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+-- limits are now counted in cost units, one per pair for rates: the free plan keeps allowing two full requests per
+-- minute
+UPDATE `plan`
+SET `requests_per_minute` = 20, `requests_per_day` = 10000, `requests_per_month` = 250000
+WHERE `plan_id` = 'free';
+
+ALTER TABLE `plan`
+    MODIFY COLUMN `requests_per_minute` INT NOT NULL DEFAULT 0 COMMENT 'maximum cost units per minute, 0 for no limit',
+    MODIFY COLUMN `requests_per_day` INT NOT NULL DEFAULT 0 COMMENT 'maximum cost units per day, 0 for no limit',
+    MODIFY COLUMN `requests_per_month` INT NOT NULL DEFAULT 0 COMMENT 'maximum cost units per calendar month, 0 for no limit';
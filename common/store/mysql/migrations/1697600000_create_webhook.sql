@@ -0,0 +1,36 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+CREATE TABLE `webhook` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'id',
+    `webhook_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'webhook shortuuid id',
+    `user_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'owner shortuuid id',
+    `url` VARCHAR(2048) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'URL payloads are posted to',
+    `secret` VARCHAR(64) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'HMAC key of the payload signatures',
+    `pair` VARCHAR(32) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'currency pair, e.g. EUR_USD',
+    `condition` TINYINT NOT NULL DEFAULT 0 COMMENT '1: crosses threshold, 2: changes by threshold percent within window',
+    `threshold` DOUBLE NOT NULL DEFAULT 0 COMMENT 'rate, or percent change',
+    `window` INT NOT NULL DEFAULT 0 COMMENT 'seconds, change conditions only',
+
+    `db_create_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) COMMENT 'database insertion time, please do not modify',
+    `db_modify_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) ON UPDATE CURRENT_TIMESTAMP (3) COMMENT 'database update time, please do not modify',
+    `disabled_at` TIMESTAMP NULL DEFAULT NULL COMMENT 'disabled time',
+    `disabled` TINYINT DEFAULT '0' COMMENT 'soft delete',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uniq_idx_webhook_id` (`webhook_id`),
+    KEY `idx_user_id` (`user_id`)
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = UTF8MB4 COMMENT = 'rate notification webhook table';
+
+CREATE TABLE `webhook_dead_letter` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'id',
+    `webhook_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'webhook shortuuid id',
+    `payload` TEXT CHARACTER SET UTF8MB4 NOT NULL COMMENT 'undelivered payload',
+    `error` VARCHAR(1024) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'error of the last attempt',
+    `attempts` INT NOT NULL DEFAULT 0 COMMENT 'delivery attempts made',
+
+    `db_create_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) COMMENT 'database insertion time, please do not modify',
+    `db_modify_time` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) ON UPDATE CURRENT_TIMESTAMP (3) COMMENT 'database update time, please do not modify',
+    `disabled_at` TIMESTAMP NULL DEFAULT NULL COMMENT 'disabled time',
+    `disabled` TINYINT DEFAULT '0' COMMENT 'soft delete',
+    PRIMARY KEY (`id`),
+    KEY `idx_webhook_id` (`webhook_id`)
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = UTF8MB4 COMMENT = 'undelivered webhook payloads table';
@@ -0,0 +1,4 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+ALTER TABLE `plan`
+    ADD COLUMN `spread_bps` DECIMAL(8, 2) NOT NULL DEFAULT 0 COMMENT 'spread in basis points quoted around mid rates, when the source does not provide bid and ask' AFTER `freshness`;
@@ -0,0 +1,5 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+ALTER TABLE `audit_log`
+    ADD COLUMN `target_organization_id` CHAR(22) CHARACTER SET UTF8MB4 NOT NULL DEFAULT '' COMMENT 'shortuuid of the organization owning the target resource' AFTER `target_id`,
+    ADD KEY `idx_target_organization_id` (`target_organization_id`);
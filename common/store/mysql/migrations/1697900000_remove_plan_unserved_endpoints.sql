@@ -0,0 +1,4 @@
+-- noinspection SqlNoDataSourceInspectionForFile
+
+-- no route serves the history and stream endpoints
+UPDATE `plan` SET `endpoints` = 'rate' WHERE `endpoints` = 'rate history stream';
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -51,6 +52,7 @@ func (m *MySQL) CreateUser(ctx context.Context, req store.CreateUserRequest) (*s
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		Email:     req.Email,
+		Role:      model.UserRoleStandard.Uint8(),
 	}
 	if tx := m.db.WithContext(ctx).Create(d); tx.Error != nil {
 		return nil, tx.Error
@@ -98,6 +100,10 @@ func (m *MySQL) ListAPIKeys(ctx context.Context, req store.ListAPIKeysRequest) (
 		tx = tx.Where("`user_id` = ?", req.UserID)
 	}
 
+	if req.OrganizationID != "" {
+		tx = tx.Where("`organization_id` = ?", req.OrganizationID)
+	}
+
 	var res []*dao.APIKey
 
 	if tx = tx.WithContext(ctx).Find(&res); tx.Error != nil {
@@ -114,14 +120,15 @@ func (m *MySQL) ListAPIKeys(ctx context.Context, req store.ListAPIKeysRequest) (
 }
 
 func (m *MySQL) CreateAPIKey(ctx context.Context, req store.CreateAPIKeyRequest) (*store.CreateAPIKeyResponse, error) {
-	if req.Type == model.APIKeyTypeUndefined.Uint8() {
-		req.Type = model.APIKeyTypeLimited.Uint8()
+	if req.PlanID == "" {
+		req.PlanID = model.PlanFree
 	}
 
 	d := &dao.APIKey{
-		APIKeyID: util.NewUUID(),
-		UserID:   req.UserID,
-		Type:     req.Type,
+		APIKeyID:       util.NewUUID(),
+		UserID:         req.UserID,
+		OrganizationID: req.OrganizationID,
+		PlanID:         req.PlanID,
 	}
 	if tx := m.db.WithContext(ctx).Create(d); tx.Error != nil {
 		return nil, tx.Error
@@ -132,6 +139,24 @@ func (m *MySQL) CreateAPIKey(ctx context.Context, req store.CreateAPIKeyRequest)
 	}, nil
 }
 
+func (m *MySQL) UpdateAPIKey(ctx context.Context, req store.UpdateAPIKeyRequest) (*store.UpdateAPIKeyResponse, error) {
+	tx := m.db.WithContext(ctx).Model(&dao.APIKey{}).
+		Where("`api_key_id` = ? AND `disabled` = 0", req.APIKeyID).
+		Updates(map[string]interface{}{
+			"plan_id": req.PlanID,
+		})
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return nil, errors.Wrap(cError.ErrNotFound, "API Key not found")
+	}
+
+	return &store.UpdateAPIKeyResponse{}, nil
+}
+
 func (m *MySQL) DeleteAPIKey(ctx context.Context, req store.DeleteAPIKeyRequest) (*store.DeleteAPIKeyResponse, error) {
 	tx := m.db.WithContext(ctx).Model(&dao.APIKey{}).
 		Where("`api_key_id` = ?", req.APIKeyID).
@@ -151,6 +176,553 @@ func (m *MySQL) DeleteAPIKey(ctx context.Context, req store.DeleteAPIKeyRequest)
 	return &store.DeleteAPIKeyResponse{}, nil
 }
 
+func (m *MySQL) GetPlan(ctx context.Context, req store.GetPlanRequest) (*store.GetPlanResponse, error) {
+	var res dao.Plan
+
+	tx := m.db.WithContext(ctx).Model(&dao.Plan{}).
+		Where("`plan_id` = ? AND `disabled` = 0", req.PlanID).
+		First(&res)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrap(cError.ErrNotFound, "plan not found")
+		}
+
+		return nil, tx.Error
+	}
+
+	return &store.GetPlanResponse{
+		Plan: dao.PlanToModel(&res),
+	}, nil
+}
+
+func (m *MySQL) ListPlans(ctx context.Context, _ store.ListPlansRequest) (*store.ListPlansResponse, error) {
+	var res []*dao.Plan
+
+	tx := m.db.WithContext(ctx).Model(&dao.Plan{}).
+		Where("`disabled` = 0").
+		Order("`id` ASC").
+		Find(&res)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.ListPlansResponse{
+		Plans: util.MapMultipleItems(dao.PlanToModel, res),
+	}, nil
+}
+
+func (m *MySQL) CreateAuditLog(
+	ctx context.Context, req store.CreateAuditLogRequest,
+) (*store.CreateAuditLogResponse, error) {
+	d := &dao.AuditLog{
+		ActorUserID:          req.ActorUserID,
+		Action:               req.Action,
+		TargetUserID:         req.TargetUserID,
+		TargetID:             req.TargetID,
+		TargetOrganizationID: req.TargetOrganizationID,
+		IP:                   req.IP,
+		UserAgent:            req.UserAgent,
+		Timestamp:            time.Now(),
+	}
+	if tx := m.db.WithContext(ctx).Create(d); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.CreateAuditLogResponse{}, nil
+}
+
+func (m *MySQL) ListAuditLogs(
+	ctx context.Context, req store.ListAuditLogsRequest,
+) (*store.ListAuditLogsResponse, error) {
+	tx := m.db.Model(&dao.AuditLog{})
+
+	if req.UserID != "" {
+		tx = tx.Where("(`actor_user_id` = ? OR `target_user_id` = ?)", req.UserID, req.UserID)
+	}
+
+	if len(req.Actions) > 0 {
+		tx = tx.Where("`action` IN ?", req.Actions)
+	}
+
+	if req.From > 0 {
+		tx = tx.Where("`timestamp` >= ?", time.Unix(req.From, 0))
+	}
+
+	if req.To > 0 {
+		tx = tx.Where("`timestamp` < ?", time.Unix(req.To, 0))
+	}
+
+	if req.Limit > 0 {
+		tx = tx.Limit(req.Limit)
+	}
+
+	var res []*dao.AuditLog
+
+	if tx = tx.WithContext(ctx).Order("`id` DESC").Offset(req.Offset).Find(&res); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.ListAuditLogsResponse{
+		AuditLogs: util.MapMultipleItems(dao.AuditLogToModel, res),
+	}, nil
+}
+
+func (m *MySQL) GetSession(ctx context.Context, req store.GetSessionRequest) (*store.GetSessionResponse, error) {
+	tx := m.db.Model(&dao.Session{}).Where("`disabled` = 0")
+
+	if req.SessionID != "" {
+		tx = tx.Where("`session_id` = ?", req.SessionID)
+	}
+
+	if req.TokenHash != "" {
+		tx = tx.Where("`token_hash` = ?", req.TokenHash)
+	}
+
+	if req.RefreshTokenHash != "" {
+		tx = tx.Where("`refresh_token_hash` = ?", req.RefreshTokenHash)
+	}
+
+	var res dao.Session
+
+	if tx = tx.WithContext(ctx).First(&res); tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrap(cError.ErrNotFound, "session not found")
+		}
+
+		return nil, tx.Error
+	}
+
+	return &store.GetSessionResponse{
+		Session: dao.SessionToModel(&res),
+	}, nil
+}
+
+func (m *MySQL) ListSessions(ctx context.Context, req store.ListSessionsRequest) (*store.ListSessionsResponse, error) {
+	tx := m.db.Model(&dao.Session{}).Where("`disabled` = 0")
+
+	if req.UserID != "" {
+		tx = tx.Where("`user_id` = ?", req.UserID)
+	}
+
+	var res []*dao.Session
+
+	if tx = tx.WithContext(ctx).Order("`id` DESC").Find(&res); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.ListSessionsResponse{
+		Sessions: util.MapMultipleItems(dao.SessionToModel, res),
+	}, nil
+}
+
+func (m *MySQL) CreateSession(ctx context.Context, req store.CreateSessionRequest) (*store.CreateSessionResponse, error) {
+	d := &dao.Session{
+		SessionID:         util.NewUUID(),
+		UserID:            req.UserID,
+		TokenHash:         req.TokenHash,
+		RefreshTokenHash:  req.RefreshTokenHash,
+		Provider:          req.Provider,
+		IP:                req.IP,
+		UserAgent:         req.UserAgent,
+		Expiration:        time.Unix(req.Expiration, 0),
+		RefreshExpiration: time.Unix(req.RefreshExpiration, 0),
+	}
+	if tx := m.db.WithContext(ctx).Create(d); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.CreateSessionResponse{
+		SessionID: d.SessionID,
+	}, nil
+}
+
+func (m *MySQL) UpdateSession(ctx context.Context, req store.UpdateSessionRequest) (*store.UpdateSessionResponse, error) {
+	tx := m.db.WithContext(ctx).Model(&dao.Session{}).
+		Where("`session_id` = ? AND `disabled` = 0", req.SessionID).
+		Updates(map[string]interface{}{
+			"token_hash":         req.TokenHash,
+			"refresh_token_hash": req.RefreshTokenHash,
+			"expiration":         time.Unix(req.Expiration, 0),
+			"refresh_expiration": time.Unix(req.RefreshExpiration, 0),
+		})
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return nil, errors.Wrap(cError.ErrNotFound, "session not found")
+	}
+
+	return &store.UpdateSessionResponse{}, nil
+}
+
+func (m *MySQL) DeleteSession(ctx context.Context, req store.DeleteSessionRequest) (*store.DeleteSessionResponse, error) {
+	tx := m.db.WithContext(ctx).Model(&dao.Session{}).
+		Where("`session_id` = ?", req.SessionID).
+		Updates(map[string]interface{}{
+			"disabled":    true,
+			"disabled_at": sql.NullTime{Time: time.Now(), Valid: true},
+		})
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.DeleteSessionResponse{}, nil
+}
+
+func (m *MySQL) GetOAuthClient(
+	ctx context.Context, req store.GetOAuthClientRequest,
+) (*store.GetOAuthClientResponse, error) {
+	tx := m.db.Model(&dao.OAuthClient{}).Where("`disabled` = 0")
+
+	if req.ClientID != "" {
+		tx = tx.Where("`client_id` = ?", req.ClientID)
+	}
+
+	if req.SecretHash != "" {
+		tx = tx.Where("`secret_hash` = ?", req.SecretHash)
+	}
+
+	var res dao.OAuthClient
+
+	if tx = tx.WithContext(ctx).First(&res); tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrap(cError.ErrNotFound, "OAuth client not found")
+		}
+
+		return nil, tx.Error
+	}
+
+	return &store.GetOAuthClientResponse{
+		OAuthClient: dao.OAuthClientToModel(&res),
+	}, nil
+}
+
+func (m *MySQL) ListOAuthClients(
+	ctx context.Context, req store.ListOAuthClientsRequest,
+) (*store.ListOAuthClientsResponse, error) {
+	tx := m.db.Model(&dao.OAuthClient{}).Where("`disabled` = 0")
+
+	if req.UserID != "" {
+		tx = tx.Where("`user_id` = ?", req.UserID)
+	}
+
+	var res []*dao.OAuthClient
+
+	if tx = tx.WithContext(ctx).Order("`id` DESC").Find(&res); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.ListOAuthClientsResponse{
+		OAuthClients: util.MapMultipleItems(dao.OAuthClientToModel, res),
+	}, nil
+}
+
+func (m *MySQL) CreateOAuthClient(
+	ctx context.Context, req store.CreateOAuthClientRequest,
+) (*store.CreateOAuthClientResponse, error) {
+	if req.PlanID == "" {
+		req.PlanID = model.PlanFree
+	}
+
+	d := &dao.OAuthClient{
+		ClientID:   util.NewUUID(),
+		UserID:     req.UserID,
+		Name:       req.Name,
+		SecretHash: req.SecretHash,
+		Scopes:     strings.Join(req.Scopes, " "),
+		PlanID:     req.PlanID,
+	}
+	if tx := m.db.WithContext(ctx).Create(d); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.CreateOAuthClientResponse{
+		ClientID: d.ClientID,
+	}, nil
+}
+
+func (m *MySQL) DeleteOAuthClient(
+	ctx context.Context, req store.DeleteOAuthClientRequest,
+) (*store.DeleteOAuthClientResponse, error) {
+	tx := m.db.WithContext(ctx).Model(&dao.OAuthClient{}).
+		Where("`client_id` = ?", req.ClientID).
+		Updates(map[string]interface{}{
+			"disabled":    true,
+			"disabled_at": sql.NullTime{Time: time.Now(), Valid: true},
+		})
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.DeleteOAuthClientResponse{}, nil
+}
+
+func (m *MySQL) GetOrganization(
+	ctx context.Context, req store.GetOrganizationRequest,
+) (*store.GetOrganizationResponse, error) {
+	var res dao.Organization
+
+	tx := m.db.WithContext(ctx).Model(&dao.Organization{}).
+		Where("`organization_id` = ? AND `disabled` = 0", req.OrganizationID).
+		First(&res)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrap(cError.ErrNotFound, "organization not found")
+		}
+
+		return nil, tx.Error
+	}
+
+	return &store.GetOrganizationResponse{
+		Organization: dao.OrganizationToModel(&res),
+	}, nil
+}
+
+func (m *MySQL) ListOrganizations(
+	ctx context.Context, req store.ListOrganizationsRequest,
+) (*store.ListOrganizationsResponse, error) {
+	tx := m.db.Model(&dao.Organization{}).Where("`organization`.`disabled` = 0")
+
+	if req.UserID != "" {
+		tx = tx.
+			Joins("JOIN `organization_member` ON "+
+				"`organization_member`.`organization_id` = `organization`.`organization_id` AND "+
+				"`organization_member`.`disabled` = 0").
+			Where("`organization_member`.`user_id` = ?", req.UserID)
+	}
+
+	var res []*dao.Organization
+
+	if tx = tx.WithContext(ctx).Order("`organization`.`id` DESC").Find(&res); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.ListOrganizationsResponse{
+		Organizations: util.MapMultipleItems(dao.OrganizationToModel, res),
+	}, nil
+}
+
+func (m *MySQL) CreateOrganization(
+	ctx context.Context, req store.CreateOrganizationRequest,
+) (*store.CreateOrganizationResponse, error) {
+	d := &dao.Organization{
+		OrganizationID: util.NewUUID(),
+		Name:           req.Name,
+	}
+
+	// an organization never exists without its owner
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(d).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&dao.OrganizationMember{
+			OrganizationID: d.OrganizationID,
+			UserID:         req.OwnerUserID,
+			Role:           model.OrganizationRoleOwner.Uint8(),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &store.CreateOrganizationResponse{
+		OrganizationID: d.OrganizationID,
+	}, nil
+}
+
+func (m *MySQL) GetOrganizationMember(
+	ctx context.Context, req store.GetOrganizationMemberRequest,
+) (*store.GetOrganizationMemberResponse, error) {
+	var res dao.OrganizationMember
+
+	tx := m.db.WithContext(ctx).Model(&dao.OrganizationMember{}).
+		Where("`organization_id` = ? AND `user_id` = ? AND `disabled` = 0", req.OrganizationID, req.UserID).
+		Preload("User").
+		First(&res)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrap(cError.ErrNotFound, "organization member not found")
+		}
+
+		return nil, tx.Error
+	}
+
+	return &store.GetOrganizationMemberResponse{
+		Member: dao.OrganizationMemberToModel(&res),
+	}, nil
+}
+
+func (m *MySQL) ListOrganizationMembers(
+	ctx context.Context, req store.ListOrganizationMembersRequest,
+) (*store.ListOrganizationMembersResponse, error) {
+	var res []*dao.OrganizationMember
+
+	tx := m.db.WithContext(ctx).Model(&dao.OrganizationMember{}).
+		Where("`organization_id` = ? AND `disabled` = 0", req.OrganizationID).
+		Preload("User").
+		Order("`id` ASC").
+		Find(&res)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.ListOrganizationMembersResponse{
+		Members: util.MapMultipleItems(dao.OrganizationMemberToModel, res),
+	}, nil
+}
+
+func (m *MySQL) CreateOrganizationMember(
+	ctx context.Context, req store.CreateOrganizationMemberRequest,
+) (*store.CreateOrganizationMemberResponse, error) {
+	d := &dao.OrganizationMember{
+		OrganizationID: req.OrganizationID,
+		UserID:         req.UserID,
+		Role:           req.Role,
+	}
+	if tx := m.db.WithContext(ctx).Create(d); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.CreateOrganizationMemberResponse{}, nil
+}
+
+// DeleteOrganizationMember : only the membership is removed, keys owned by the organization are not affected
+func (m *MySQL) DeleteOrganizationMember(
+	ctx context.Context, req store.DeleteOrganizationMemberRequest,
+) (*store.DeleteOrganizationMemberResponse, error) {
+	tx := m.db.WithContext(ctx).Model(&dao.OrganizationMember{}).
+		Where("`organization_id` = ? AND `user_id` = ? AND `disabled` = 0", req.OrganizationID, req.UserID).
+		Updates(map[string]interface{}{
+			"disabled":    true,
+			"disabled_at": sql.NullTime{Time: time.Now(), Valid: true},
+		})
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return nil, errors.Wrap(cError.ErrNotFound, "organization member not found")
+	}
+
+	return &store.DeleteOrganizationMemberResponse{}, nil
+}
+
+func (m *MySQL) GetWebhook(ctx context.Context, req store.GetWebhookRequest) (*store.GetWebhookResponse, error) {
+	var res dao.Webhook
+
+	tx := m.db.WithContext(ctx).Model(&dao.Webhook{}).
+		Where("`webhook_id` = ? AND `disabled` = 0", req.WebhookID).
+		First(&res)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrap(cError.ErrNotFound, "webhook not found")
+		}
+
+		return nil, tx.Error
+	}
+
+	return &store.GetWebhookResponse{
+		Webhook: dao.WebhookToModel(&res),
+	}, nil
+}
+
+func (m *MySQL) ListWebhooks(ctx context.Context, req store.ListWebhooksRequest) (*store.ListWebhooksResponse, error) {
+	tx := m.db.Model(&dao.Webhook{}).Where("`disabled` = 0")
+
+	if req.UserID != "" {
+		tx = tx.Where("`user_id` = ?", req.UserID)
+	}
+
+	var res []*dao.Webhook
+
+	if tx = tx.WithContext(ctx).Order("`id` DESC").Find(&res); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.ListWebhooksResponse{
+		Webhooks: util.MapMultipleItems(dao.WebhookToModel, res),
+	}, nil
+}
+
+func (m *MySQL) CreateWebhook(
+	ctx context.Context, req store.CreateWebhookRequest,
+) (*store.CreateWebhookResponse, error) {
+	d := &dao.Webhook{
+		WebhookID: util.NewUUID(),
+		UserID:    req.UserID,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Pair:      req.Pair,
+		Condition: req.Condition,
+		Threshold: req.Threshold,
+		Window:    req.Window,
+	}
+	if tx := m.db.WithContext(ctx).Create(d); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.CreateWebhookResponse{
+		WebhookID: d.WebhookID,
+	}, nil
+}
+
+func (m *MySQL) DeleteWebhook(
+	ctx context.Context, req store.DeleteWebhookRequest,
+) (*store.DeleteWebhookResponse, error) {
+	tx := m.db.WithContext(ctx).Model(&dao.Webhook{}).
+		Where("`webhook_id` = ?", req.WebhookID).
+		Updates(map[string]interface{}{
+			"disabled":    true,
+			"disabled_at": sql.NullTime{Time: time.Now(), Valid: true},
+		})
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.DeleteWebhookResponse{}, nil
+}
+
+func (m *MySQL) ListWebhookDeadLetters(
+	ctx context.Context, req store.ListWebhookDeadLettersRequest,
+) (*store.ListWebhookDeadLettersResponse, error) {
+	var res []*dao.WebhookDeadLetter
+
+	tx := m.db.WithContext(ctx).Model(&dao.WebhookDeadLetter{}).
+		Where("`webhook_id` = ? AND `disabled` = 0", req.WebhookID).
+		Order("`id` DESC").
+		Find(&res)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.ListWebhookDeadLettersResponse{
+		DeadLetters: util.MapMultipleItems(dao.WebhookDeadLetterToModel, res),
+	}, nil
+}
+
+func (m *MySQL) CreateWebhookDeadLetter(
+	ctx context.Context, req store.CreateWebhookDeadLetterRequest,
+) (*store.CreateWebhookDeadLetterResponse, error) {
+	d := &dao.WebhookDeadLetter{
+		WebhookID: req.WebhookID,
+		Payload:   req.Payload,
+		Error:     req.Error,
+		Attempts:  req.Attempts,
+	}
+	if tx := m.db.WithContext(ctx).Create(d); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &store.CreateWebhookDeadLetterResponse{}, nil
+}
+
 type Config struct {
 	Username string
 	Password string
@@ -159,6 +731,24 @@ type Config struct {
 	DBName   string
 }
 
+func (m *MySQL) Close() error {
+	db, err := m.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return db.Close()
+}
+
+func (m *MySQL) Ping(ctx context.Context) error {
+	db, err := m.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return db.PingContext(ctx)
+}
+
 func New(c Config) (store.Store, error) {
 	dsn := fmt.Sprintf(
 		"%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True",
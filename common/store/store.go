@@ -3,6 +3,11 @@ package store
 import "context"
 
 type Store interface {
+	// Close : closes the connections of the store
+	Close() error
+	// Ping : checks that the store can be reached
+	Ping(ctx context.Context) error
+
 	// User
 	GetUser(ctx context.Context, req GetUserRequest) (*GetUserResponse, error)
 	CreateUser(ctx context.Context, req CreateUserRequest) (*CreateUserResponse, error)
@@ -11,5 +16,56 @@ type Store interface {
 	GetAPIKey(ctx context.Context, req GetAPIKeyRequest) (*GetAPIKeyResponse, error)
 	ListAPIKeys(ctx context.Context, req ListAPIKeysRequest) (*ListAPIKeysResponse, error)
 	CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	UpdateAPIKey(ctx context.Context, req UpdateAPIKeyRequest) (*UpdateAPIKeyResponse, error)
 	DeleteAPIKey(ctx context.Context, req DeleteAPIKeyRequest) (*DeleteAPIKeyResponse, error)
+
+	// Plan
+	GetPlan(ctx context.Context, req GetPlanRequest) (*GetPlanResponse, error)
+	ListPlans(ctx context.Context, req ListPlansRequest) (*ListPlansResponse, error)
+
+	// Audit Log
+	CreateAuditLog(ctx context.Context, req CreateAuditLogRequest) (*CreateAuditLogResponse, error)
+	ListAuditLogs(ctx context.Context, req ListAuditLogsRequest) (*ListAuditLogsResponse, error)
+
+	// Session
+	GetSession(ctx context.Context, req GetSessionRequest) (*GetSessionResponse, error)
+	ListSessions(ctx context.Context, req ListSessionsRequest) (*ListSessionsResponse, error)
+	CreateSession(ctx context.Context, req CreateSessionRequest) (*CreateSessionResponse, error)
+	UpdateSession(ctx context.Context, req UpdateSessionRequest) (*UpdateSessionResponse, error)
+	DeleteSession(ctx context.Context, req DeleteSessionRequest) (*DeleteSessionResponse, error)
+
+	// OAuth Client
+	GetOAuthClient(ctx context.Context, req GetOAuthClientRequest) (*GetOAuthClientResponse, error)
+	ListOAuthClients(ctx context.Context, req ListOAuthClientsRequest) (*ListOAuthClientsResponse, error)
+	CreateOAuthClient(ctx context.Context, req CreateOAuthClientRequest) (*CreateOAuthClientResponse, error)
+	DeleteOAuthClient(ctx context.Context, req DeleteOAuthClientRequest) (*DeleteOAuthClientResponse, error)
+
+	// Organization
+	GetOrganization(ctx context.Context, req GetOrganizationRequest) (*GetOrganizationResponse, error)
+	ListOrganizations(ctx context.Context, req ListOrganizationsRequest) (*ListOrganizationsResponse, error)
+	CreateOrganization(ctx context.Context, req CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	GetOrganizationMember(
+		ctx context.Context, req GetOrganizationMemberRequest,
+	) (*GetOrganizationMemberResponse, error)
+	ListOrganizationMembers(
+		ctx context.Context, req ListOrganizationMembersRequest,
+	) (*ListOrganizationMembersResponse, error)
+	CreateOrganizationMember(
+		ctx context.Context, req CreateOrganizationMemberRequest,
+	) (*CreateOrganizationMemberResponse, error)
+	DeleteOrganizationMember(
+		ctx context.Context, req DeleteOrganizationMemberRequest,
+	) (*DeleteOrganizationMemberResponse, error)
+
+	// Webhook
+	GetWebhook(ctx context.Context, req GetWebhookRequest) (*GetWebhookResponse, error)
+	ListWebhooks(ctx context.Context, req ListWebhooksRequest) (*ListWebhooksResponse, error)
+	CreateWebhook(ctx context.Context, req CreateWebhookRequest) (*CreateWebhookResponse, error)
+	DeleteWebhook(ctx context.Context, req DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListWebhookDeadLetters(
+		ctx context.Context, req ListWebhookDeadLettersRequest,
+	) (*ListWebhookDeadLettersResponse, error)
+	CreateWebhookDeadLetter(
+		ctx context.Context, req CreateWebhookDeadLetterRequest,
+	) (*CreateWebhookDeadLetterResponse, error)
 }
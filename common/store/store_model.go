@@ -35,16 +35,20 @@ type GetAPIKeyResponse struct {
 }
 
 type ListAPIKeysRequest struct {
-	UserID string
+	UserID         string
+	OrganizationID string
 }
 
 type ListAPIKeysResponse struct {
 	UserKeys []*model.APIKey
 }
 
+// CreateAPIKeyRequest : keys are owned either by a user or by an organization
 type CreateAPIKeyRequest struct {
-	UserID     string
-	Type       uint8
+	UserID         string
+	OrganizationID string
+	// PlanID : Optional. Defaults to the free plan.
+	PlanID     string
 	Expiration int64 // Unix time (seconds)
 }
 
@@ -52,8 +56,257 @@ type CreateAPIKeyResponse struct {
 	APIKeyID string
 }
 
+// UpdateAPIKeyRequest : moves the key to another plan, the key itself does not change
+type UpdateAPIKeyRequest struct {
+	APIKeyID string
+	PlanID   string
+}
+
+type UpdateAPIKeyResponse struct{}
+
 type DeleteAPIKeyRequest struct {
 	APIKeyID string
 }
 
 type DeleteAPIKeyResponse struct{}
+
+type GetPlanRequest struct {
+	PlanID string
+}
+
+type GetPlanResponse struct {
+	Plan *model.Plan
+}
+
+type ListPlansRequest struct{}
+
+type ListPlansResponse struct {
+	Plans []*model.Plan
+}
+
+type CreateAuditLogRequest struct {
+	ActorUserID  string
+	Action       uint8
+	TargetUserID string
+	TargetID     string
+	// TargetOrganizationID : Optional. Organization owning the target resource.
+	TargetOrganizationID string
+	IP                   string
+	UserAgent            string
+}
+
+type CreateAuditLogResponse struct{}
+
+type ListAuditLogsRequest struct {
+	// UserID : Optional. Only return entries where the user is either the actor or the target.
+	UserID  string
+	Actions []uint8
+	From    int64 // Unix time (seconds), inclusive
+	To      int64 // Unix time (seconds), exclusive
+
+	Offset int
+	Limit  int
+}
+
+type ListAuditLogsResponse struct {
+	AuditLogs []*model.AuditLog
+}
+
+type GetSessionRequest struct {
+	SessionID        string
+	TokenHash        string
+	RefreshTokenHash string
+}
+
+type GetSessionResponse struct {
+	Session *model.Session
+}
+
+type ListSessionsRequest struct {
+	UserID string
+}
+
+type ListSessionsResponse struct {
+	Sessions []*model.Session
+}
+
+type CreateSessionRequest struct {
+	UserID            string
+	TokenHash         string
+	RefreshTokenHash  string
+	Provider          string
+	IP                string
+	UserAgent         string
+	Expiration        int64 // Unix time (seconds)
+	RefreshExpiration int64 // Unix time (seconds)
+}
+
+type CreateSessionResponse struct {
+	SessionID string
+}
+
+// UpdateSessionRequest : rotates the tokens of a session
+type UpdateSessionRequest struct {
+	SessionID         string
+	TokenHash         string
+	RefreshTokenHash  string
+	Expiration        int64 // Unix time (seconds)
+	RefreshExpiration int64 // Unix time (seconds)
+}
+
+type UpdateSessionResponse struct{}
+
+type DeleteSessionRequest struct {
+	SessionID string
+}
+
+type DeleteSessionResponse struct{}
+
+type GetOAuthClientRequest struct {
+	ClientID   string
+	SecretHash string
+}
+
+type GetOAuthClientResponse struct {
+	OAuthClient *model.OAuthClient
+}
+
+type ListOAuthClientsRequest struct {
+	UserID string
+}
+
+type ListOAuthClientsResponse struct {
+	OAuthClients []*model.OAuthClient
+}
+
+type CreateOAuthClientRequest struct {
+	UserID     string
+	Name       string
+	SecretHash string
+	Scopes     []string
+	// PlanID : Optional. Defaults to the free plan.
+	PlanID string
+}
+
+type CreateOAuthClientResponse struct {
+	ClientID string
+}
+
+type DeleteOAuthClientRequest struct {
+	ClientID string
+}
+
+type DeleteOAuthClientResponse struct{}
+
+type GetOrganizationRequest struct {
+	OrganizationID string
+}
+
+type GetOrganizationResponse struct {
+	Organization *model.Organization
+}
+
+type ListOrganizationsRequest struct {
+	// UserID : Optional. Only return the organizations the user is a member of.
+	UserID string
+}
+
+type ListOrganizationsResponse struct {
+	Organizations []*model.Organization
+}
+
+// CreateOrganizationRequest : the organization is created together with its owner membership
+type CreateOrganizationRequest struct {
+	Name        string
+	OwnerUserID string
+}
+
+type CreateOrganizationResponse struct {
+	OrganizationID string
+}
+
+type GetOrganizationMemberRequest struct {
+	OrganizationID string
+	UserID         string
+}
+
+type GetOrganizationMemberResponse struct {
+	Member *model.OrganizationMember
+}
+
+type ListOrganizationMembersRequest struct {
+	OrganizationID string
+}
+
+type ListOrganizationMembersResponse struct {
+	Members []*model.OrganizationMember
+}
+
+type CreateOrganizationMemberRequest struct {
+	OrganizationID string
+	UserID         string
+	Role           uint8
+}
+
+type CreateOrganizationMemberResponse struct{}
+
+type DeleteOrganizationMemberRequest struct {
+	OrganizationID string
+	UserID         string
+}
+
+type DeleteOrganizationMemberResponse struct{}
+
+type GetWebhookRequest struct {
+	WebhookID string
+}
+
+type GetWebhookResponse struct {
+	Webhook *model.Webhook
+}
+
+type ListWebhooksRequest struct {
+	// UserID : Optional. Only return the webhooks of the user.
+	UserID string
+}
+
+type ListWebhooksResponse struct {
+	Webhooks []*model.Webhook
+}
+
+type CreateWebhookRequest struct {
+	UserID    string
+	URL       string
+	Secret    string
+	Pair      string
+	Condition uint8
+	Threshold float64
+	Window    int64 // seconds
+}
+
+type CreateWebhookResponse struct {
+	WebhookID string
+}
+
+type DeleteWebhookRequest struct {
+	WebhookID string
+}
+
+type DeleteWebhookResponse struct{}
+
+type ListWebhookDeadLettersRequest struct {
+	WebhookID string
+}
+
+type ListWebhookDeadLettersResponse struct {
+	DeadLetters []*model.WebhookDeadLetter
+}
+
+type CreateWebhookDeadLetterRequest struct {
+	WebhookID string
+	Payload   string
+	Error     string
+	Attempts  int
+}
+
+type CreateWebhookDeadLetterResponse struct{}
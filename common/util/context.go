@@ -1,7 +1,39 @@
 package util
 
+import (
+	"context"
+	"time"
+)
+
 type ContextKey string
 
 func (ck ContextKey) String() string {
 	return string(ck)
 }
+
+// detachedContext : see WithoutCancel
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key any) any {
+	return c.parent.Value(key)
+}
+
+// WithoutCancel : keeps the values of the parent but not its cancellation, for work that must complete after the
+// parent is done (e.g. recording an outcome during a shutdown). Equivalent to context.WithoutCancel from Go 1.21.
+func WithoutCancel(parent context.Context) context.Context {
+	return detachedContext{parent: parent}
+}
@@ -0,0 +1,21 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithoutCancel(t *testing.T) {
+	key := ContextKey("key")
+
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), key, "value"))
+	cancel()
+
+	ctx := WithoutCancel(parent)
+
+	assert.Nil(t, ctx.Err())
+	assert.Nil(t, ctx.Done())
+	assert.Equal(t, "value", ctx.Value(key))
+}
@@ -3,6 +3,8 @@ package util
 import (
 	"fmt"
 	"strings"
+
+	"github.com/lruggieri/fxnow/common/currency"
 )
 
 func PairFromCurrencies(fromCurrency, toCurrency string) string {
@@ -17,3 +19,29 @@ func CurrenciesFromPair(currencyPair string) (fromCurrency, toCurrency string) {
 
 	return parts[0], parts[1]
 }
+
+// IsValidPair : whether the pair is made of two distinct valid currencies (e.g. "EUR_USD", "BTC_USD")
+func IsValidPair(currencyPair string) bool {
+	from, to := CurrenciesFromPair(currencyPair)
+
+	return IsValidCurrency(from) && IsValidCurrency(to) && from != to
+}
+
+// IsValidCurrency : whether the currency is an ISO 4217 code (e.g. "EUR") or a registered cryptocurrency (e.g. "USDT")
+func IsValidCurrency(code string) bool {
+	if currency.IsCrypto(code) {
+		return true
+	}
+
+	if len(code) != 3 {
+		return false
+	}
+
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -23,3 +23,16 @@ func TestCurrenciesFromPair(t *testing.T) {
 		assert.Equal(t, "", to)
 	})
 }
+
+func TestIsValidPair(t *testing.T) {
+	assert.True(t, IsValidPair("EUR_USD"))
+	assert.False(t, IsValidPair("EUR_EUR"))
+	assert.False(t, IsValidPair("eur_usd"))
+	assert.False(t, IsValidPair("EURO_USD"))
+	assert.False(t, IsValidPair("EURUSD"))
+	assert.True(t, IsValidPair("BTC_USD"))
+	assert.True(t, IsValidPair("ETH_EUR"))
+	assert.True(t, IsValidPair("USDT_BTC"))
+	assert.False(t, IsValidPair("USDT_USDT"))
+	assert.False(t, IsValidPair("FAKECOIN_USD"))
+}
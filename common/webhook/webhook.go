@@ -0,0 +1,44 @@
+// Package webhook defines the payloads posted to rate notification webhooks, and how they are signed.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+const (
+	// HeaderTimestamp : unix time (s) the payload was signed at, part of the signed content to prevent replays
+	HeaderTimestamp = "X-FXNow-Timestamp"
+	// HeaderSignature : hex encoded HMAC-SHA256 of "<timestamp>.<body>", keyed with the webhook secret
+	HeaderSignature = "X-FXNow-Signature"
+)
+
+// Payload : body posted to webhooks when their condition is met
+type Payload struct {
+	WebhookID string  `json:"webhook_id"`
+	Pair      string  `json:"pair"`
+	Condition string  `json:"condition"`
+	Threshold float64 `json:"threshold"`
+	Window    int64   `json:"window,omitempty"` // seconds
+	Rate      float64 `json:"rate"`
+	// ReferenceRate : the previous rate for crosses conditions, the rate at the start of the window for change ones
+	ReferenceRate float64 `json:"reference_rate"`
+	Timestamp     int64   `json:"timestamp"` // unix (s) of the rate
+}
+
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify : whether the signature was computed with the secret on the same timestamp and body. Receivers should
+// also reject timestamps too far in the past.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"pair":"EUR_USD"}`)
+	signature := Sign("secret", 1700000000, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		expected  bool
+	}{
+		{
+			name:      "valid",
+			secret:    "secret",
+			timestamp: 1700000000,
+			body:      body,
+			expected:  true,
+		},
+		{
+			name:      "other-secret",
+			secret:    "other",
+			timestamp: 1700000000,
+			body:      body,
+			expected:  false,
+		},
+		{
+			name:      "replayed-timestamp",
+			secret:    "secret",
+			timestamp: 1700000001,
+			body:      body,
+			expected:  false,
+		},
+		{
+			name:      "tampered-body",
+			secret:    "secret",
+			timestamp: 1700000000,
+			body:      []byte(`{"pair":"USD_JPY"}`),
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Verify(tc.secret, tc.timestamp, tc.body, signature))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package logic
+
+import (
+	"fmt"
+
+	"github.com/lruggieri/fxnow/common/model"
+)
+
+// EndpointUnitCosts : units consumed for each pair and period requested, by endpoint. Endpoints more expensive to
+// serve can cost more than one unit.
+var EndpointUnitCosts = map[string]int{
+	model.PlanEndpointRate: 1,
+}
+
+// Cost : units consumed by a request. Rate limits and quotas are counted in units rather than requests, so that
+// a request for many pairs weighs as much as many requests for a single pair.
+type Cost struct {
+	Endpoint string
+	Pairs    int
+	// Periods : points requested for each pair, e.g. the days of a range. 1 for the latest rates.
+	Periods  int
+	UnitCost int
+	Units    int
+}
+
+func NewCost(endpoint string, pairs, periods int) Cost {
+	unitCost, ok := EndpointUnitCosts[endpoint]
+	if !ok {
+		unitCost = 1
+	}
+
+	if periods < 1 {
+		periods = 1
+	}
+
+	return Cost{
+		Endpoint: endpoint,
+		Pairs:    pairs,
+		Periods:  periods,
+		UnitCost: unitCost,
+		Units:    pairs * periods * unitCost,
+	}
+}
+
+// Breakdown : how the units were computed, in a format suitable for HTTP headers
+func (c Cost) Breakdown() string {
+	return fmt.Sprintf("endpoint=%s; pairs=%d; periods=%d; unit-cost=%d; units=%d",
+		c.Endpoint, c.Pairs, c.Periods, c.UnitCost, c.Units)
+}
@@ -0,0 +1,92 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lruggieri/fxnow/common/cache"
+	"github.com/lruggieri/fxnow/common/model"
+)
+
+func TestNewCost(t *testing.T) {
+	tests := []struct {
+		name              string
+		endpoint          string
+		pairs             int
+		periods           int
+		expectedUnits     int
+		expectedBreakdown string
+	}{
+		{
+			name:              "rate",
+			endpoint:          model.PlanEndpointRate,
+			pairs:             50,
+			periods:           1,
+			expectedUnits:     50,
+			expectedBreakdown: "endpoint=rate; pairs=50; periods=1; unit-cost=1; units=50",
+		},
+		{
+			name:              "rate-range",
+			endpoint:          model.PlanEndpointRate,
+			pairs:             3,
+			periods:           30,
+			expectedUnits:     90,
+			expectedBreakdown: "endpoint=rate; pairs=3; periods=30; unit-cost=1; units=90",
+		},
+		{
+			name:              "unknown-endpoint-no-periods",
+			endpoint:          "other",
+			pairs:             2,
+			periods:           0,
+			expectedUnits:     2,
+			expectedBreakdown: "endpoint=other; pairs=2; periods=1; unit-cost=1; units=2",
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			cost := NewCost(tc.endpoint, tc.pairs, tc.periods)
+
+			assert.Equal(t, tc.expectedUnits, cost.Units)
+			assert.Equal(t, tc.expectedBreakdown, cost.Breakdown())
+		})
+	}
+}
+
+func TestAPIKeyUsagesWithinAllowedRange(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	usages := []cache.CachedAPIKeyUsage{
+		{Timestamp: now.Add(-2 * time.Minute).Unix(), Units: 10},
+		{Timestamp: now.Add(-30 * time.Second).Unix(), Units: 5},
+		// tracked before requests had a cost
+		{Timestamp: now.Add(-10 * time.Second).Unix()},
+	}
+
+	tests := []struct {
+		name           string
+		requestedUnits int
+		expected       bool
+	}{
+		{
+			name:           "within-range",
+			requestedUnits: 4,
+			expected:       true,
+		},
+		{
+			name:           "above-range",
+			requestedUnits: 5,
+			expected:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected,
+				APIKeyUsagesWithinAllowedRange(usages, now.Add(-RateLimitDuration), 10, tc.requestedUnits))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package logic
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,18 +11,33 @@ import (
 	"github.com/lruggieri/fxnow/common/cache"
 	"github.com/lruggieri/fxnow/common/clock"
 	cError "github.com/lruggieri/fxnow/common/error"
+	cHttp "github.com/lruggieri/fxnow/common/http"
 	"github.com/lruggieri/fxnow/common/logger"
 	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/oauth"
 	"github.com/lruggieri/fxnow/common/store"
 	"github.com/lruggieri/fxnow/common/util"
 )
 
 const (
-	RateLimitDuration  = time.Minute
-	RateLimitMaxUsages = 2
+	RateLimitDuration = time.Minute
+	// APIKeyRevalidationInterval : cached keys are checked against the DB at least this often, so that revocations
+	// and plan changes are picked up without reissuing keys
+	APIKeyRevalidationInterval = time.Minute
+	// PlanCacheLifetime : changes to the plan catalog are picked up within this time
+	PlanCacheLifetime = time.Minute
+	// UsagesCacheLifetime : per-minute usages are kept along with the key, which is revalidated far more often
+	UsagesCacheLifetime = 24 * time.Hour
+	// QuotaWarningRatio : share of a daily or monthly quota past which callers who opted in are warned
+	QuotaWarningRatio = 0.8
+	// quotaExpirationMargin : counters outlive their period a little, so that requests racing the calendar boundary
+	// never find them expired
+	quotaExpirationMargin = time.Hour
 )
 
 type Logic interface {
+	cHttp.APIKeyValidator
+
 	GetRate(context.Context, GetRateRequest) (*GetRateResponse, error)
 }
 
@@ -29,86 +45,354 @@ type Impl struct {
 	Store store.Store
 	Cache cache.Cache
 	Clock clock.Clock
+	// Tokens : verifies OAuth2 access tokens issued by identity. If nil, only API keys are accepted.
+	Tokens *oauth.Signer
+	// QuotaLocation : timezone of the calendar daily and monthly quotas reset on. If nil, UTC is used.
+	QuotaLocation *time.Location
 }
 
-func (i *Impl) GetRate(ctx context.Context, req GetRateRequest) (*GetRateResponse, error) {
-	apiKeyID := GetAPIKeyIDFromContext(ctx)
-	if len(apiKeyID) == 0 {
-		return nil, errors.Wrap(cError.ErrNotAuthorized, "API key not set")
+// ValidateAPIKey : resolves the API key from cache, or from DB if not cached or not validated recently. OAuth2 access
+// tokens are verified locally, the client registration they were issued for is carried by the token itself.
+func (i *Impl) ValidateAPIKey(ctx context.Context, apiKeyID string) (*model.APIKey, error) {
+	if oauth.IsAccessToken(apiKeyID) {
+		return i.validateAccessToken(apiKeyID)
 	}
 
-	// check if API Key is valid by taking it from cache
 	var cak cache.CachedAPIKey
 
-	exist, err := i.Cache.Get(ctx, cache.GenerateCacheKeyAPIKey(apiKeyID), &cak)
+	cacheKey := cache.GenerateCacheKeyAPIKey(apiKeyID)
+
+	exist, err := i.Cache.Get(ctx, cacheKey, &cak)
 	if err != nil {
 		return nil, err
 	}
 
-	// if it's not found, search in DB
-	if !exist {
-		var res *store.GetAPIKeyResponse
+	now := i.Clock.Now()
 
-		// fetch it from DB
-		res, err = i.Store.GetAPIKey(ctx, store.GetAPIKeyRequest{APIKeyID: apiKeyID})
-		if err != nil {
-			return nil, err
-		}
+	// keys cached before plans were introduced are resolved from DB
+	if exist && cak.PlanID != "" && now.Sub(time.Unix(cak.ValidatedAt, 0)) < APIKeyRevalidationInterval {
+		return &model.APIKey{
+			APIKeyID:       cak.APIKeyID,
+			UserID:         cak.UserID,
+			OrganizationID: cak.OrganizationID,
+			PlanID:         cak.PlanID,
+		}, nil
+	}
 
-		cak = cache.CachedAPIKey{
-			APIKeyID: apiKeyID,
-			Type:     res.APIKey.Type.Uint8(),
+	res, err := i.Store.GetAPIKey(ctx, store.GetAPIKeyRequest{APIKeyID: apiKeyID})
+	if err != nil {
+		if errors.Is(err, cError.ErrNotFound) {
+			return nil, errors.Wrap(cError.ErrNotAuthenticated, "invalid API key")
 		}
+
+		return nil, err
+	}
+
+	// usages, if any, are kept
+	cak.APIKeyID = res.APIKey.APIKeyID
+	cak.UserID = res.APIKey.UserID
+	cak.OrganizationID = res.APIKey.OrganizationID
+	cak.PlanID = res.APIKey.PlanID
+	cak.ValidatedAt = now.Unix()
+
+	if err = i.Cache.Set(ctx, cacheKey, cak, UsagesCacheLifetime); err != nil {
+		return nil, err
+	}
+
+	return res.APIKey, nil
+}
+
+func (i *Impl) validateAccessToken(token string) (*model.APIKey, error) {
+	if i.Tokens == nil {
+		return nil, errors.Wrap(cError.ErrNotAuthenticated, "access tokens are not accepted")
+	}
+
+	claims, err := i.Tokens.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.APIKey{
+		APIKeyID:   claims.ClientID,
+		UserID:     claims.UserID,
+		PlanID:     claims.PlanID,
+		Expiration: claims.Expiration,
+		Scopes:     claims.Scopes,
+	}, nil
+}
+
+func (i *Impl) GetRate(ctx context.Context, req GetRateRequest) (*GetRateResponse, error) {
+	apiKey := cHttp.GetAPIKeyFromContext(ctx)
+	if apiKey == nil {
+		return nil, errors.Wrap(cError.ErrNotAuthorized, "API key not set")
+	}
+
+	pairs, err := validPairs(req.Pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := i.plan(ctx, apiKey.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !plan.AllowsEndpoint(model.PlanEndpointRate) {
+		return nil, errors.Wrap(cError.ErrNotAuthorized, fmt.Sprintf("plan '%s' does not include rates", plan.PlanID))
+	}
+
+	if plan.MaxPairsPerRequest > 0 && len(pairs) > plan.MaxPairsPerRequest {
+		return nil, errors.Wrap(cError.ErrInvalidParameter,
+			fmt.Sprintf("plan '%s' allows up to %d pairs per request", plan.PlanID, plan.MaxPairsPerRequest))
+	}
+
+	cost := NewCost(model.PlanEndpointRate, len(pairs), 1)
+
+	// usages of the API Key are tracked in cache, pooled across the keys of the same organization
+	var cak cache.CachedAPIKey
+
+	usagesKey := usagesCacheKey(apiKey)
+
+	if _, err = i.Cache.Get(ctx, usagesKey, &cak); err != nil {
+		return nil, err
 	}
 
-	timeFrameOfInterest := i.Clock.Now().Add(-RateLimitDuration)
+	now := i.Clock.Now()
+	timeFrameOfInterest := now.Add(-RateLimitDuration)
 
-	// based on the API key Type, perform rate limiting
-	if cak.Type == model.APIKeyTypeLimited.Uint8() &&
-		!APIKeyUsagesWithinAllowedRange(cak.Usages, timeFrameOfInterest, RateLimitMaxUsages) {
+	// perform rate limiting based on the API key plan
+	if plan.RequestsPerMinute > 0 &&
+		!APIKeyUsagesWithinAllowedRange(cak.Usages, timeFrameOfInterest, plan.RequestsPerMinute, cost.Units) {
 		return nil, cError.ErrTooManyRequests
 	}
 
-	responseRates, err := i.fetchRates(ctx, req.Pairs)
+	quotas := i.quotas(usagesKey, plan, now)
+
+	if err = i.consumeQuotas(ctx, quotas, cost.Units); err != nil {
+		return nil, err
+	}
+
+	responseRates, err := i.fetchRates(ctx, pairs, plan)
 	if err != nil {
+		// failed requests do not count towards quotas
+		i.releaseQuotas(ctx, quotas, cost.Units)
+
 		return nil, err
 	}
 
 	// remove useless usages
 	cak.Usages = RemoveUsages(cak.Usages, timeFrameOfInterest.Unix())
 	// add this usage
-	cak.Usages = append(cak.Usages, cache.CachedAPIKeyUsage{Timestamp: i.Clock.Now().Unix()})
+	cak.Usages = append(cak.Usages, cache.CachedAPIKeyUsage{Timestamp: now.Unix(), Units: cost.Units})
 	// update cached value
-	if err = i.Cache.Set(ctx, cache.GenerateCacheKeyAPIKey(apiKeyID), cak, cache.MaxCacheLifetime); err != nil {
+	if err = i.Cache.Set(ctx, usagesKey, cak, UsagesCacheLifetime); err != nil {
+		i.releaseQuotas(ctx, quotas, cost.Units)
+
 		return nil, err
 	}
 
-	return &GetRateResponse{
+	res := &GetRateResponse{
 		Rates: responseRates,
-	}, nil
+		Cost:  cost,
+	}
+
+	if req.QuotaWarnings {
+		res.QuotaWarnings = quotaWarnings(quotas)
+	}
+
+	return res, nil
+}
+
+// validPairs : pairs in upper case, without duplicates, so that a pair requested twice is charged once. Returns
+// ErrInvalidParameter if any pair is not made of two distinct valid currencies.
+func validPairs(pairs []string) ([]string, error) {
+	if len(pairs) == 0 {
+		return nil, errors.Wrap(cError.ErrInvalidParameter, "no pairs requested")
+	}
+
+	valid := make([]string, 0, len(pairs))
+	seen := make(map[string]bool, len(pairs))
+
+	for _, pair := range pairs {
+		upper := strings.ToUpper(pair)
+		if !util.IsValidPair(upper) {
+			return nil, errors.Wrap(cError.ErrInvalidParameter, fmt.Sprintf("invalid pair '%s'", pair))
+		}
+
+		if seen[upper] {
+			continue
+		}
+
+		seen[upper] = true
+		valid = append(valid, upper)
+	}
+
+	return valid, nil
+}
+
+// quota : units counter over a calendar period
+type quota struct {
+	name       string
+	key        string
+	limit      int
+	expiration time.Duration
+	used       int64
+}
+
+// quotas : daily and monthly quotas of the plan, on the calendar of QuotaLocation. Unlimited periods are omitted.
+func (i *Impl) quotas(usagesKey string, plan *model.Plan, now time.Time) []*quota {
+	loc := i.QuotaLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	monthStart := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+
+	var quotas []*quota
+
+	if plan.RequestsPerDay > 0 {
+		quotas = append(quotas, &quota{
+			name:       "daily",
+			key:        cache.GenerateCacheKeyQuota(usagesKey, "day_"+local.Format("2006-01-02")),
+			limit:      plan.RequestsPerDay,
+			expiration: dayStart.AddDate(0, 0, 1).Sub(now) + quotaExpirationMargin,
+		})
+	}
+
+	if plan.RequestsPerMonth > 0 {
+		quotas = append(quotas, &quota{
+			name:       "monthly",
+			key:        cache.GenerateCacheKeyQuota(usagesKey, "month_"+local.Format("2006-01")),
+			limit:      plan.RequestsPerMonth,
+			expiration: monthStart.AddDate(0, 1, 0).Sub(now) + quotaExpirationMargin,
+		})
+	}
+
+	return quotas
+}
+
+// consumeQuotas : counts the units of the request towards every quota. Counters are incremented before checking
+// them, so that concurrent requests cannot exceed the quota together. If any quota is exceeded, the request is not
+// counted.
+func (i *Impl) consumeQuotas(ctx context.Context, quotas []*quota, units int) error {
+	for idx, q := range quotas {
+		used, err := i.Cache.Increment(ctx, q.key, int64(units), q.expiration)
+		if err != nil {
+			i.releaseQuotas(ctx, quotas[:idx], units)
+
+			return err
+		}
+
+		q.used = used
+
+		if used > int64(q.limit) {
+			i.releaseQuotas(ctx, quotas[:idx+1], units)
+
+			return errors.Wrap(cError.ErrTooManyRequests, fmt.Sprintf("%s quota exceeded", q.name))
+		}
+	}
+
+	return nil
+}
+
+// releaseQuotas : gives back units counted by consumeQuotas. Errors are not returned, as the request is failing
+// already: at worst the caller is charged for it.
+func (i *Impl) releaseQuotas(ctx context.Context, quotas []*quota, units int) {
+	for _, q := range quotas {
+		if _, err := i.Cache.Increment(ctx, q.key, -int64(units), q.expiration); err != nil {
+			logger.WithError(err).WithField("key", q.key).Error("cannot release quota")
+		}
+	}
+}
+
+func quotaWarnings(quotas []*quota) []string {
+	var warnings []string
+
+	for _, q := range quotas {
+		if float64(q.used) >= QuotaWarningRatio*float64(q.limit) {
+			warnings = append(warnings, fmt.Sprintf("%s quota %d%% used (%d/%d units)",
+				q.name, q.used*100/int64(q.limit), q.used, q.limit))
+		}
+	}
+
+	return warnings
+}
+
+// plan : plans are cached for a short time, as they rarely change
+func (i *Impl) plan(ctx context.Context, planID string) (*model.Plan, error) {
+	// access tokens issued before plans were introduced do not carry any
+	if planID == "" {
+		planID = model.PlanFree
+	}
+
+	var plan model.Plan
+
+	exist, err := i.Cache.Get(ctx, cache.GenerateCacheKeyPlan(planID), &plan)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist {
+		return &plan, nil
+	}
+
+	res, err := i.Store.GetPlan(ctx, store.GetPlanRequest{PlanID: planID})
+	if err != nil {
+		if errors.Is(err, cError.ErrNotFound) {
+			return nil, fmt.Errorf("plan '%s' not found", planID)
+		}
+
+		return nil, err
+	}
+
+	if err = i.Cache.Set(ctx, cache.GenerateCacheKeyPlan(planID), res.Plan, PlanCacheLifetime); err != nil {
+		return nil, err
+	}
+
+	return res.Plan, nil
+}
+
+// usagesCacheKey : OAuth2 clients, the only credentials restricted by scopes, are tracked apart from API keys.
+// Keys owned by an organization share its quota.
+func usagesCacheKey(apiKey *model.APIKey) string {
+	switch {
+	case apiKey.Scopes != nil:
+		return cache.GenerateCacheKeyOAuthClient(apiKey.APIKeyID)
+	case apiKey.OrganizationID != "":
+		return cache.GenerateCacheKeyOrganization(apiKey.OrganizationID)
+	}
+
+	return cache.GenerateCacheKeyAPIKey(apiKey.APIKeyID)
 }
 
-func (i *Impl) fetchRates(ctx context.Context, pairs []string) ([]GetRateResponseRate, error) {
+func (i *Impl) fetchRates(ctx context.Context, pairs []string, plan *model.Plan) ([]GetRateResponseRate, error) {
 	responseRates := make([]GetRateResponseRate, 0, len(pairs))
 
 	for _, pair := range pairs {
 		from, to := util.CurrenciesFromPair(pair)
 
-		var cachedRate cache.CachedRate
-
-		exist, err := i.Cache.Get(ctx, cache.GenerateCacheKeyRate(from, to), &cachedRate)
+		cachedRate, err := i.fetchRate(ctx, from, to, plan.FreshnessDuration())
 		if err != nil {
 			return nil, err
 		}
 
-		if !exist {
+		if cachedRate == nil {
 			logger.WithField("pair", pair).Error("rate for pair not found")
 			return nil, errors.Wrap(cError.ErrNotFound, fmt.Sprintf("rate for pair '%s' not found", pair))
 		}
 
+		bid, ask := cachedRate.Bid, cachedRate.Ask
+		if bid == 0 || ask == 0 {
+			bid, ask = plan.SyntheticBidAsk(cachedRate.Rate)
+		}
+
 		responseRates = append(responseRates, GetRateResponseRate{
 			Pair:      pair,
 			Rate:      cachedRate.Rate,
+			Bid:       bid,
+			Ask:       ask,
 			Timestamp: cachedRate.Timestamp,
 		})
 	}
@@ -116,16 +400,63 @@ func (i *Impl) fetchRates(ctx context.Context, pairs []string) ([]GetRateRespons
 	return responseRates, nil
 }
 
-func APIKeyUsagesWithinAllowedRange(usages []cache.CachedAPIKeyUsage, fromTime time.Time, maxAllowedUsages int) bool {
-	usagesWithinTimeRange := 0
+// fetchRate : plans that are not real-time are served a snapshot of the rate, taken at most once per freshness
+// period. Returns nil if the rate is not available.
+func (i *Impl) fetchRate(ctx context.Context, from, to string, freshness time.Duration) (*cache.CachedRate, error) {
+	var cachedRate cache.CachedRate
+
+	if freshness > 0 {
+		exist, err := i.Cache.Get(ctx, cache.GenerateCacheKeyRateSnapshot(from, to, freshness), &cachedRate)
+		if err != nil {
+			return nil, err
+		}
+
+		if exist {
+			return &cachedRate, nil
+		}
+	}
+
+	exist, err := i.Cache.Get(ctx, cache.GenerateCacheKeyRate(from, to), &cachedRate)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exist {
+		return nil, nil
+	}
+
+	if freshness > 0 {
+		snapshotKey := cache.GenerateCacheKeyRateSnapshot(from, to, freshness)
+		if err = i.Cache.Set(ctx, snapshotKey, cachedRate, freshness); err != nil {
+			return nil, err
+		}
+	}
+
+	return &cachedRate, nil
+}
+
+// APIKeyUsagesWithinAllowedRange : whether the units of the usages since fromTime, plus the ones requested, are within
+// the allowed ones
+func APIKeyUsagesWithinAllowedRange(
+	usages []cache.CachedAPIKeyUsage, fromTime time.Time, maxAllowedUnits, requestedUnits int,
+) bool {
+	unitsWithinTimeRange := 0
 
 	for _, usage := range usages {
 		if !time.Unix(usage.Timestamp, 0).Before(fromTime) {
-			usagesWithinTimeRange++
+			unitsWithinTimeRange += usageUnits(usage)
 		}
 	}
 
-	return usagesWithinTimeRange < maxAllowedUsages
+	return unitsWithinTimeRange+requestedUnits <= maxAllowedUnits
+}
+
+func usageUnits(usage cache.CachedAPIKeyUsage) int {
+	if usage.Units == 0 {
+		return 1
+	}
+
+	return usage.Units
 }
 
 // RemoveUsages : in order to spare space in the cache and make searches faster, let's remove Usages that we already
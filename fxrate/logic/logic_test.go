@@ -9,19 +9,24 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/lruggieri/fxnow/common/cache"
 	cError "github.com/lruggieri/fxnow/common/error"
+	cHttp "github.com/lruggieri/fxnow/common/http"
 	mockcache "github.com/lruggieri/fxnow/common/mock/cache"
 	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
 	mockstore "github.com/lruggieri/fxnow/common/mock/store"
 	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/oauth"
 	"github.com/lruggieri/fxnow/common/store"
 )
 
 func TestLogicGetRate(t *testing.T) {
 	testErr := errors.New("error")
 	now := time.Now()
+	dayQuotaKey := cache.GenerateCacheKeyQuota("api_key_api_key", "day_"+now.UTC().Format("2006-01-02"))
+	monthQuotaKey := cache.GenerateCacheKeyQuota("api_key_api_key", "month_"+now.UTC().Format("2006-01"))
 
 	type deps struct {
 		store *mockstore.Store
@@ -35,7 +40,72 @@ func TestLogicGetRate(t *testing.T) {
 	}
 
 	apiKey := "api_key"
-	apiKeyCtx := context.WithValue(context.Background(), ContextKeyAPIKey, apiKey)
+	apiKeyCtx := context.WithValue(context.Background(), cHttp.ContextKeyAPIKey, &model.APIKey{
+		APIKeyID: apiKey,
+		UserID:   "user_id",
+		PlanID:   model.PlanFree,
+	})
+	organizationCtx := context.WithValue(context.Background(), cHttp.ContextKeyAPIKey, &model.APIKey{
+		APIKeyID:       apiKey,
+		OrganizationID: "organization_id",
+		PlanID:         model.PlanFree,
+	})
+	clientCtx := context.WithValue(context.Background(), cHttp.ContextKeyAPIKey, &model.APIKey{
+		APIKeyID: "client_id",
+		UserID:   "user_id",
+		PlanID:   model.PlanUnlimited,
+		Scopes:   []string{oauth.ScopeRatesRead},
+	})
+
+	freePlan := model.Plan{
+		PlanID:             model.PlanFree,
+		RequestsPerMinute:  4,
+		RequestsPerDay:     1000,
+		RequestsPerMonth:   25000,
+		MaxPairsPerRequest: 10,
+		Endpoints:          []string{model.PlanEndpointRate},
+	}
+	unlimitedPlan := model.Plan{
+		PlanID:    model.PlanUnlimited,
+		Endpoints: []string{model.PlanEndpointRate},
+	}
+
+	mockPlan := func(ctx context.Context, d deps, plan model.Plan) {
+		d.cache.EXPECT().Get(
+			ctx,
+			cache.GenerateCacheKeyPlan(plan.PlanID),
+			mock.AnythingOfType("*model.Plan"),
+		).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
+			reflect.ValueOf(i).Elem().Set(reflect.ValueOf(plan))
+			return true, nil
+		}).Once()
+	}
+	mockUsages := func(ctx context.Context, d deps, key string, cak cache.CachedAPIKey) {
+		d.cache.EXPECT().Get(
+			ctx,
+			key,
+			mock.AnythingOfType("*cache.CachedAPIKey"),
+		).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
+			reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cak))
+			return true, nil
+		}).Once()
+	}
+	mockQuota := func(ctx context.Context, d deps, key string, delta, value int64) {
+		d.cache.EXPECT().Increment(ctx, key, delta, mock.AnythingOfType("time.Duration")).Return(value, nil).Once()
+	}
+	mockRate := func(ctx context.Context, d deps, key string, rate float64) {
+		d.cache.EXPECT().Get(
+			ctx,
+			key,
+			mock.AnythingOfType("*cache.CachedRate"),
+		).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
+			reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedRate{
+				Rate:      rate,
+				Timestamp: now.Unix(),
+			}))
+			return true, nil
+		}).Once()
+	}
 
 	tests := []struct {
 		name      string
@@ -63,7 +133,49 @@ func TestLogicGetRate(t *testing.T) {
 			},
 		},
 		{
-			name: "error-get-cache-api-key",
+			name: "error-malformed-pair",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY", "foo"},
+				},
+			},
+			mock: func(args args, d deps) {},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-pair-without-separator",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"BTCUSD"},
+				},
+			},
+			mock: func(args args, d deps) {},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-same-currency-pair",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_USD"},
+				},
+			},
+			mock: func(args args, d deps) {},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-get-cache-plan",
 			args: args{
 				ctx: apiKeyCtx,
 				req: GetRateRequest{
@@ -73,8 +185,8 @@ func TestLogicGetRate(t *testing.T) {
 			mock: func(args args, d deps) {
 				d.cache.EXPECT().Get(
 					args.ctx,
-					cache.GenerateCacheKeyAPIKey(apiKey),
-					mock.AnythingOfType("*cache.CachedAPIKey"),
+					cache.GenerateCacheKeyPlan(model.PlanFree),
+					mock.AnythingOfType("*model.Plan"),
 				).Return(false, testErr).Once()
 			},
 			assertion: func(t *testing.T, res *GetRateResponse, err error) {
@@ -83,7 +195,7 @@ func TestLogicGetRate(t *testing.T) {
 			},
 		},
 		{
-			name: "error-get-cache-rate",
+			name: "error-unknown-plan",
 			args: args{
 				ctx: apiKeyCtx,
 				req: GetRateRequest{
@@ -93,34 +205,57 @@ func TestLogicGetRate(t *testing.T) {
 			mock: func(args args, d deps) {
 				d.cache.EXPECT().Get(
 					args.ctx,
-					cache.GenerateCacheKeyAPIKey(apiKey),
-					mock.AnythingOfType("*cache.CachedAPIKey"),
-				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
-					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedAPIKey{
-						APIKeyID: apiKey,
-						Type:     model.APIKeyTypeLimited.Uint8(),
-						Usages: []cache.CachedAPIKeyUsage{
-							{Timestamp: now.Unix()},
-						},
-					}))
-					return true, nil
-				}).Once()
+					cache.GenerateCacheKeyPlan(model.PlanFree),
+					mock.AnythingOfType("*model.Plan"),
+				).Return(false, nil).Once()
 
-				d.clock.EXPECT().Now().Return(now).Once()
+				d.store.EXPECT().GetPlan(args.ctx, store.GetPlanRequest{PlanID: model.PlanFree}).
+					Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, res)
+				// a key on a plan missing from the catalog is a server side issue
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, cError.ErrNotFound)
+			},
+		},
+		{
+			name: "error-endpoint-not-in-plan",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY", "EUR_USD"},
+				},
+			},
+			mock: func(args args, d deps) {
+				mockPlan(args.ctx, d, model.Plan{PlanID: model.PlanFree, Endpoints: []string{"other"}})
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "error-too-many-pairs",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY", "EUR_USD"},
+				},
+			},
+			mock: func(args args, d deps) {
+				plan := freePlan
+				plan.MaxPairsPerRequest = 1
 
-				d.cache.EXPECT().Get(
-					args.ctx,
-					cache.GenerateCacheKeyRate("USD", "JPY"),
-					mock.AnythingOfType("*cache.CachedRate"),
-				).Return(false, testErr).Once()
+				mockPlan(args.ctx, d, plan)
 			},
 			assertion: func(t *testing.T, res *GetRateResponse, err error) {
 				assert.Nil(t, res)
-				assert.ErrorIs(t, err, testErr)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
 			},
 		},
 		{
-			name: "error-db-fetch",
+			name: "error-get-cache-api-key",
 			args: args{
 				ctx: apiKeyCtx,
 				req: GetRateRequest{
@@ -128,14 +263,13 @@ func TestLogicGetRate(t *testing.T) {
 				},
 			},
 			mock: func(args args, d deps) {
+				mockPlan(args.ctx, d, freePlan)
+
 				d.cache.EXPECT().Get(
 					args.ctx,
 					cache.GenerateCacheKeyAPIKey(apiKey),
 					mock.AnythingOfType("*cache.CachedAPIKey"),
-				).Return(false, nil).Once()
-
-				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: apiKey}).
-					Return(nil, testErr).Once()
+				).Return(false, testErr).Once()
 			},
 			assertion: func(t *testing.T, res *GetRateResponse, err error) {
 				assert.Nil(t, res)
@@ -143,7 +277,7 @@ func TestLogicGetRate(t *testing.T) {
 			},
 		},
 		{
-			name: "error-set-cache",
+			name: "error-get-cache-rate",
 			args: args{
 				ctx: apiKeyCtx,
 				req: GetRateRequest{
@@ -151,62 +285,79 @@ func TestLogicGetRate(t *testing.T) {
 				},
 			},
 			mock: func(args args, d deps) {
-				d.cache.EXPECT().Get(
-					args.ctx,
-					cache.GenerateCacheKeyAPIKey(apiKey),
-					mock.AnythingOfType("*cache.CachedAPIKey"),
-				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
-					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedAPIKey{
-						APIKeyID: apiKey,
-						Type:     model.APIKeyTypeLimited.Uint8(),
-						Usages: []cache.CachedAPIKeyUsage{
-							{Timestamp: now.Unix()},
-						},
-					}))
-					return true, nil
-				}).Once()
+				mockPlan(args.ctx, d, freePlan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{
+					APIKeyID: apiKey,
+					Usages: []cache.CachedAPIKeyUsage{
+						{Timestamp: now.Unix()},
+					},
+				})
 
 				d.clock.EXPECT().Now().Return(now).Once()
 
+				mockQuota(args.ctx, d, dayQuotaKey, 2, 10)
+				mockQuota(args.ctx, d, monthQuotaKey, 2, 100)
+
 				d.cache.EXPECT().Get(
 					args.ctx,
 					cache.GenerateCacheKeyRate("USD", "JPY"),
 					mock.AnythingOfType("*cache.CachedRate"),
-				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
-					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedRate{
-						Rate:      42.42,
-						Timestamp: now.Unix(),
-					}))
-					return true, nil
-				}).Once()
+				).Return(false, testErr).Once()
 
-				d.cache.EXPECT().Get(
-					args.ctx,
-					cache.GenerateCacheKeyRate("EUR", "USD"),
-					mock.AnythingOfType("*cache.CachedRate"),
-				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
-					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedRate{
-						Rate:      24.24,
-						Timestamp: now.Unix(),
-					}))
-					return true, nil
-				}).Once()
+				// failed requests are not counted
+				mockQuota(args.ctx, d, dayQuotaKey, -2, 9)
+				mockQuota(args.ctx, d, monthQuotaKey, -2, 99)
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "error-set-cache",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY", "EUR_USD"},
+				},
+			},
+			mock: func(args args, d deps) {
+				mockPlan(args.ctx, d, freePlan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{
+					APIKeyID: apiKey,
+					UserID:   "user_id",
+					PlanID:   model.PlanFree,
+					Usages: []cache.CachedAPIKeyUsage{
+						{Timestamp: now.Unix()},
+					},
+				})
 
 				d.clock.EXPECT().Now().Return(now).Once()
 
+				mockQuota(args.ctx, d, dayQuotaKey, 2, 10)
+				mockQuota(args.ctx, d, monthQuotaKey, 2, 100)
+
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("USD", "JPY"), 42.42)
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("EUR", "USD"), 24.24)
+
 				d.cache.EXPECT().Set(
 					args.ctx,
 					cache.GenerateCacheKeyAPIKey(apiKey),
 					cache.CachedAPIKey{
 						APIKeyID: apiKey,
-						Type:     model.APIKeyTypeLimited.Uint8(),
+						UserID:   "user_id",
+						PlanID:   model.PlanFree,
 						Usages: []cache.CachedAPIKeyUsage{
 							{Timestamp: now.Unix()},
-							{Timestamp: now.Unix()},
+							{Timestamp: now.Unix(), Units: 2},
 						},
 					},
-					cache.MaxCacheLifetime,
+					UsagesCacheLifetime,
 				).Return(testErr).Once()
+
+				// failed requests are not counted
+				mockQuota(args.ctx, d, dayQuotaKey, -2, 9)
+				mockQuota(args.ctx, d, monthQuotaKey, -2, 99)
 			},
 			assertion: func(t *testing.T, res *GetRateResponse, err error) {
 				assert.Nil(t, res)
@@ -222,21 +373,14 @@ func TestLogicGetRate(t *testing.T) {
 				},
 			},
 			mock: func(args args, d deps) {
-				d.cache.EXPECT().Get(
-					args.ctx,
-					cache.GenerateCacheKeyAPIKey(apiKey),
-					mock.AnythingOfType("*cache.CachedAPIKey"),
-				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
-					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedAPIKey{
-						APIKeyID: apiKey,
-						Type:     model.APIKeyTypeLimited.Uint8(),
-						Usages: []cache.CachedAPIKeyUsage{
-							{Timestamp: now.Unix()},
-							{Timestamp: now.Unix()},
-						},
-					}))
-					return true, nil
-				}).Once()
+				mockPlan(args.ctx, d, freePlan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{
+					APIKeyID: apiKey,
+					Usages: []cache.CachedAPIKeyUsage{
+						{Timestamp: now.Unix(), Units: 2},
+						{Timestamp: now.Unix(), Units: 2},
+					},
+				})
 
 				d.clock.EXPECT().Now().Return(now).Once()
 			},
@@ -246,7 +390,7 @@ func TestLogicGetRate(t *testing.T) {
 			},
 		},
 		{
-			name: "happy-path-limited-within-range",
+			name: "error-daily-quota-exceeded",
 			args: args{
 				ctx: apiKeyCtx,
 				req: GetRateRequest{
@@ -254,83 +398,50 @@ func TestLogicGetRate(t *testing.T) {
 				},
 			},
 			mock: func(args args, d deps) {
-				d.cache.EXPECT().Get(
-					args.ctx,
-					cache.GenerateCacheKeyAPIKey(apiKey),
-					mock.AnythingOfType("*cache.CachedAPIKey"),
-				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
-					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedAPIKey{
-						APIKeyID: apiKey,
-						Type:     model.APIKeyTypeLimited.Uint8(),
-						Usages: []cache.CachedAPIKeyUsage{
-							{Timestamp: now.Unix()},
-						},
-					}))
-					return true, nil
-				}).Once()
+				mockPlan(args.ctx, d, freePlan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{
+					APIKeyID: apiKey,
+				})
 
 				d.clock.EXPECT().Now().Return(now).Once()
 
-				d.cache.EXPECT().Get(
-					args.ctx,
-					cache.GenerateCacheKeyRate("USD", "JPY"),
-					mock.AnythingOfType("*cache.CachedRate"),
-				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
-					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedRate{
-						Rate:      42.42,
-						Timestamp: now.Unix(),
-					}))
-					return true, nil
-				}).Once()
-
-				d.cache.EXPECT().Get(
-					args.ctx,
-					cache.GenerateCacheKeyRate("EUR", "USD"),
-					mock.AnythingOfType("*cache.CachedRate"),
-				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
-					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedRate{
-						Rate:      24.24,
-						Timestamp: now.Unix(),
-					}))
-					return true, nil
-				}).Once()
+				// the counter going past the quota is rolled back
+				mockQuota(args.ctx, d, dayQuotaKey, 2, 1001)
+				mockQuota(args.ctx, d, dayQuotaKey, -2, 999)
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrTooManyRequests)
+			},
+		},
+		{
+			name: "error-monthly-quota-exceeded",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY", "EUR_USD"},
+				},
+			},
+			mock: func(args args, d deps) {
+				mockPlan(args.ctx, d, freePlan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{
+					APIKeyID: apiKey,
+				})
 
 				d.clock.EXPECT().Now().Return(now).Once()
 
-				d.cache.EXPECT().Set(
-					args.ctx,
-					cache.GenerateCacheKeyAPIKey(apiKey),
-					cache.CachedAPIKey{
-						APIKeyID: apiKey,
-						Type:     model.APIKeyTypeLimited.Uint8(),
-						Usages: []cache.CachedAPIKeyUsage{
-							{Timestamp: now.Unix()},
-							{Timestamp: now.Unix()},
-						},
-					},
-					cache.MaxCacheLifetime,
-				).Return(nil).Once()
+				mockQuota(args.ctx, d, dayQuotaKey, 2, 10)
+				mockQuota(args.ctx, d, monthQuotaKey, 2, 25001)
+				mockQuota(args.ctx, d, dayQuotaKey, -2, 9)
+				mockQuota(args.ctx, d, monthQuotaKey, -2, 25000)
 			},
 			assertion: func(t *testing.T, res *GetRateResponse, err error) {
-				assert.Nil(t, err)
-				assert.Equal(t, &GetRateResponse{
-					Rates: []GetRateResponseRate{
-						{
-							Pair:      "USD_JPY",
-							Rate:      42.42,
-							Timestamp: now.Unix(),
-						},
-						{
-							Pair:      "EUR_USD",
-							Rate:      24.24,
-							Timestamp: now.Unix(),
-						},
-					},
-				}, res)
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrTooManyRequests)
 			},
 		},
 		{
-			name: "happy-path-no-api-key-cache",
+			name: "error-increment-quota",
 			args: args{
 				ctx: apiKeyCtx,
 				req: GetRateRequest{
@@ -338,57 +449,95 @@ func TestLogicGetRate(t *testing.T) {
 				},
 			},
 			mock: func(args args, d deps) {
-				d.cache.EXPECT().Get(
-					args.ctx,
-					cache.GenerateCacheKeyAPIKey(apiKey),
-					mock.AnythingOfType("*cache.CachedAPIKey"),
-				).Return(false, nil).Once()
-
-				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: apiKey}).
-					Return(&store.GetAPIKeyResponse{APIKey: &model.APIKey{
-						APIKeyID: "api_key",
-						Type:     model.APIKeyTypeLimited,
-					}}, nil).Once()
+				mockPlan(args.ctx, d, freePlan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{
+					APIKeyID: apiKey,
+				})
 
 				d.clock.EXPECT().Now().Return(now).Once()
 
-				d.cache.EXPECT().Get(
-					args.ctx,
-					cache.GenerateCacheKeyRate("USD", "JPY"),
-					mock.AnythingOfType("*cache.CachedRate"),
-				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
-					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedRate{
-						Rate:      42.42,
-						Timestamp: now.Unix(),
-					}))
-					return true, nil
-				}).Once()
+				mockQuota(args.ctx, d, dayQuotaKey, 2, 10)
+				d.cache.EXPECT().Increment(args.ctx, monthQuotaKey, int64(2), mock.AnythingOfType("time.Duration")).
+					Return(0, testErr).Once()
+				mockQuota(args.ctx, d, dayQuotaKey, -2, 9)
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path-quota-warnings",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs:         []string{"USD_JPY"},
+					QuotaWarnings: true,
+				},
+			},
+			mock: func(args args, d deps) {
+				mockPlan(args.ctx, d, freePlan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{})
 
-				d.cache.EXPECT().Get(
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				mockQuota(args.ctx, d, dayQuotaKey, 1, 800)
+				mockQuota(args.ctx, d, monthQuotaKey, 1, 19999)
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("USD", "JPY"), 42.42)
+
+				d.cache.EXPECT().Set(
 					args.ctx,
-					cache.GenerateCacheKeyRate("EUR", "USD"),
-					mock.AnythingOfType("*cache.CachedRate"),
-				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
-					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedRate{
-						Rate:      24.24,
-						Timestamp: now.Unix(),
-					}))
-					return true, nil
-				}).Once()
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					mock.AnythingOfType("cache.CachedAPIKey"),
+					UsagesCacheLifetime,
+				).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, err)
+				// the monthly quota is still below the warning ratio
+				assert.Equal(t, []string{"daily quota 80% used (800/1000 units)"}, res.QuotaWarnings)
+			},
+		},
+		{
+			name: "happy-path-limited-within-range",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY", "EUR_USD"},
+				},
+			},
+			mock: func(args args, d deps) {
+				mockPlan(args.ctx, d, freePlan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{
+					APIKeyID: apiKey,
+					UserID:   "user_id",
+					PlanID:   model.PlanFree,
+					Usages: []cache.CachedAPIKeyUsage{
+						{Timestamp: now.Unix()},
+					},
+				})
 
 				d.clock.EXPECT().Now().Return(now).Once()
 
+				mockQuota(args.ctx, d, dayQuotaKey, 2, 10)
+				mockQuota(args.ctx, d, monthQuotaKey, 2, 100)
+
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("USD", "JPY"), 42.42)
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("EUR", "USD"), 24.24)
+
 				d.cache.EXPECT().Set(
 					args.ctx,
 					cache.GenerateCacheKeyAPIKey(apiKey),
 					cache.CachedAPIKey{
 						APIKeyID: apiKey,
-						Type:     model.APIKeyTypeLimited.Uint8(),
+						UserID:   "user_id",
+						PlanID:   model.PlanFree,
 						Usages: []cache.CachedAPIKeyUsage{
 							{Timestamp: now.Unix()},
+							{Timestamp: now.Unix(), Units: 2},
 						},
 					},
-					cache.MaxCacheLifetime,
+					UsagesCacheLifetime,
 				).Return(nil).Once()
 			},
 			assertion: func(t *testing.T, res *GetRateResponse, err error) {
@@ -406,9 +555,308 @@ func TestLogicGetRate(t *testing.T) {
 							Timestamp: now.Unix(),
 						},
 					},
+					Cost: Cost{Endpoint: model.PlanEndpointRate, Pairs: 2, Periods: 1, UnitCost: 1, Units: 2},
+				}, res)
+			},
+		},
+		{
+			name: "happy-path-crypto-pair-charged-once",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"BTC_USD", "btc_usd"},
+				},
+			},
+			mock: func(args args, d deps) {
+				mockPlan(args.ctx, d, freePlan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{})
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				mockQuota(args.ctx, d, dayQuotaKey, 1, 1)
+				mockQuota(args.ctx, d, monthQuotaKey, 1, 1)
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("BTC", "USD"), 34512.5)
+
+				d.cache.EXPECT().Set(
+					args.ctx,
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					cache.CachedAPIKey{
+						Usages: []cache.CachedAPIKeyUsage{{Timestamp: now.Unix(), Units: 1}},
+					},
+					UsagesCacheLifetime,
+				).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &GetRateResponse{
+					Rates: []GetRateResponseRate{
+						{
+							Pair:      "BTC_USD",
+							Rate:      34512.5,
+							Timestamp: now.Unix(),
+						},
+					},
+					Cost: Cost{Endpoint: model.PlanEndpointRate, Pairs: 1, Periods: 1, UnitCost: 1, Units: 1},
 				}, res)
 			},
 		},
+		{
+			name: "happy-path-no-cache",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY", "EUR_USD"},
+				},
+			},
+			mock: func(args args, d deps) {
+				d.cache.EXPECT().Get(
+					args.ctx,
+					cache.GenerateCacheKeyPlan(model.PlanFree),
+					mock.AnythingOfType("*model.Plan"),
+				).Return(false, nil).Once()
+
+				d.store.EXPECT().GetPlan(args.ctx, store.GetPlanRequest{PlanID: model.PlanFree}).
+					Return(&store.GetPlanResponse{Plan: &freePlan}, nil).Once()
+
+				d.cache.EXPECT().Set(args.ctx, cache.GenerateCacheKeyPlan(model.PlanFree), &freePlan, PlanCacheLifetime).
+					Return(nil).Once()
+
+				d.cache.EXPECT().Get(
+					args.ctx,
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					mock.AnythingOfType("*cache.CachedAPIKey"),
+				).Return(false, nil).Once()
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				mockQuota(args.ctx, d, dayQuotaKey, 2, 10)
+				mockQuota(args.ctx, d, monthQuotaKey, 2, 100)
+
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("USD", "JPY"), 42.42)
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("EUR", "USD"), 24.24)
+
+				d.cache.EXPECT().Set(
+					args.ctx,
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					cache.CachedAPIKey{
+						Usages: []cache.CachedAPIKeyUsage{
+							{Timestamp: now.Unix(), Units: 2},
+						},
+					},
+					UsagesCacheLifetime,
+				).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &GetRateResponse{
+					Rates: []GetRateResponseRate{
+						{
+							Pair:      "USD_JPY",
+							Rate:      42.42,
+							Timestamp: now.Unix(),
+						},
+						{
+							Pair:      "EUR_USD",
+							Rate:      24.24,
+							Timestamp: now.Unix(),
+						},
+					},
+					Cost: Cost{Endpoint: model.PlanEndpointRate, Pairs: 2, Periods: 1, UnitCost: 1, Units: 2},
+				}, res)
+			},
+		},
+		{
+			name: "happy-path-rate-snapshot",
+			args: args{
+				ctx: apiKeyCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY", "EUR_USD"},
+				},
+			},
+			mock: func(args args, d deps) {
+				plan := freePlan
+				plan.Freshness = 60
+
+				mockPlan(args.ctx, d, plan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{})
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				mockQuota(args.ctx, d, dayQuotaKey, 2, 10)
+				mockQuota(args.ctx, d, monthQuotaKey, 2, 100)
+
+				// the snapshot is served as long as it exists
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRateSnapshot("USD", "JPY", time.Minute), 40.40)
+
+				// the snapshot is taken from the live rate when missing
+				d.cache.EXPECT().Get(
+					args.ctx,
+					cache.GenerateCacheKeyRateSnapshot("EUR", "USD", time.Minute),
+					mock.AnythingOfType("*cache.CachedRate"),
+				).Return(false, nil).Once()
+
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("EUR", "USD"), 24.24)
+
+				d.cache.EXPECT().Set(
+					args.ctx,
+					cache.GenerateCacheKeyRateSnapshot("EUR", "USD", time.Minute),
+					cache.CachedRate{Rate: 24.24, Timestamp: now.Unix()},
+					time.Minute,
+				).Return(nil).Once()
+
+				d.cache.EXPECT().Set(
+					args.ctx,
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					mock.AnythingOfType("cache.CachedAPIKey"),
+					UsagesCacheLifetime,
+				).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []GetRateResponseRate{
+					{
+						Pair:      "USD_JPY",
+						Rate:      40.40,
+						Timestamp: now.Unix(),
+					},
+					{
+						Pair:      "EUR_USD",
+						Rate:      24.24,
+						Timestamp: now.Unix(),
+					},
+				}, res.Rates)
+			},
+		},
+		{
+			name: "error-organization-quota-exhausted",
+			args: args{
+				ctx: organizationCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY"},
+				},
+			},
+			mock: func(args args, d deps) {
+				mockPlan(args.ctx, d, freePlan)
+				// usages of other keys of the organization count towards the same quota
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyOrganization("organization_id"), cache.CachedAPIKey{
+					APIKeyID:       "other_api_key",
+					OrganizationID: "organization_id",
+					Usages: []cache.CachedAPIKeyUsage{
+						{Timestamp: now.Unix(), Units: 2},
+						{Timestamp: now.Unix(), Units: 2},
+					},
+				})
+
+				d.clock.EXPECT().Now().Return(now).Once()
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrTooManyRequests)
+			},
+		},
+		{
+			name: "happy-path-oauth-client",
+			args: args{
+				ctx: clientCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY"},
+				},
+			},
+			mock: func(args args, d deps) {
+				mockPlan(args.ctx, d, unlimitedPlan)
+				// usages of OAuth2 clients never share keys with API keys
+				d.cache.EXPECT().Get(
+					args.ctx,
+					cache.GenerateCacheKeyOAuthClient("client_id"),
+					mock.AnythingOfType("*cache.CachedAPIKey"),
+				).Return(false, nil).Once()
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("USD", "JPY"), 42.42)
+
+				d.cache.EXPECT().Set(
+					args.ctx,
+					cache.GenerateCacheKeyOAuthClient("client_id"),
+					cache.CachedAPIKey{
+						Usages: []cache.CachedAPIKeyUsage{
+							{Timestamp: now.Unix(), Units: 1},
+						},
+					},
+					UsagesCacheLifetime,
+				).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []GetRateResponseRate{
+					{
+						Pair:      "USD_JPY",
+						Rate:      42.42,
+						Timestamp: now.Unix(),
+					},
+				}, res.Rates)
+			},
+		},
+		{
+			name: "happy-path-bid-ask",
+			args: args{
+				ctx: clientCtx,
+				req: GetRateRequest{
+					Pairs: []string{"USD_JPY", "EUR_USD"},
+				},
+			},
+			mock: func(args args, d deps) {
+				plan := unlimitedPlan
+				plan.SpreadBps = 20
+
+				mockPlan(args.ctx, d, plan)
+				mockUsages(args.ctx, d, cache.GenerateCacheKeyOAuthClient("client_id"), cache.CachedAPIKey{})
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				// bid and ask provided by the source are served as they are
+				d.cache.EXPECT().Get(
+					args.ctx,
+					cache.GenerateCacheKeyRate("USD", "JPY"),
+					mock.AnythingOfType("*cache.CachedRate"),
+				).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
+					reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cache.CachedRate{
+						Rate:      149.8,
+						Bid:       149.79,
+						Ask:       149.81,
+						Timestamp: now.Unix(),
+					}))
+					return true, nil
+				}).Once()
+				mockRate(args.ctx, d, cache.GenerateCacheKeyRate("EUR", "USD"), 1.06)
+
+				d.cache.EXPECT().Set(
+					args.ctx,
+					cache.GenerateCacheKeyOAuthClient("client_id"),
+					mock.AnythingOfType("cache.CachedAPIKey"),
+					UsagesCacheLifetime,
+				).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, res *GetRateResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []GetRateResponseRate{
+					{
+						Pair:      "USD_JPY",
+						Rate:      149.8,
+						Bid:       149.79,
+						Ask:       149.81,
+						Timestamp: now.Unix(),
+					},
+					{
+						Pair:      "EUR_USD",
+						Rate:      1.06,
+						Bid:       1.05894,
+						Ask:       1.06106,
+						Timestamp: now.Unix(),
+					},
+				}, res.Rates)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -434,3 +882,422 @@ func TestLogicGetRate(t *testing.T) {
 		})
 	}
 }
+
+func TestLogicValidateAPIKey(t *testing.T) {
+	testErr := errors.New("error")
+	now := time.Unix(1700000000, 0)
+
+	type deps struct {
+		store *mockstore.Store
+		cache *mockcache.Cache
+		clock *mockclock.Clock
+	}
+
+	apiKey := "api_key"
+
+	mockCachedKey := func(d deps, cak cache.CachedAPIKey) {
+		d.cache.EXPECT().Get(
+			mock.Anything,
+			cache.GenerateCacheKeyAPIKey(apiKey),
+			mock.AnythingOfType("*cache.CachedAPIKey"),
+		).RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
+			reflect.ValueOf(i).Elem().Set(reflect.ValueOf(cak))
+			return true, nil
+		}).Once()
+	}
+
+	tests := []struct {
+		name      string
+		mock      func(d deps)
+		assertion func(t *testing.T, res *model.APIKey, err error)
+	}{
+		{
+			name: "error-get-cache",
+			mock: func(d deps) {
+				d.cache.EXPECT().Get(
+					mock.Anything,
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					mock.AnythingOfType("*cache.CachedAPIKey"),
+				).Return(false, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "error-db-fetch",
+			mock: func(d deps) {
+				d.cache.EXPECT().Get(
+					mock.Anything,
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					mock.AnythingOfType("*cache.CachedAPIKey"),
+				).Return(false, nil).Once()
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				d.store.EXPECT().GetAPIKey(mock.Anything, store.GetAPIKeyRequest{APIKeyID: apiKey}).
+					Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "error-unknown-key",
+			mock: func(d deps) {
+				d.cache.EXPECT().Get(
+					mock.Anything,
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					mock.AnythingOfType("*cache.CachedAPIKey"),
+				).Return(false, nil).Once()
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				d.store.EXPECT().GetAPIKey(mock.Anything, store.GetAPIKeyRequest{APIKeyID: apiKey}).
+					Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-set-cache",
+			mock: func(d deps) {
+				d.cache.EXPECT().Get(
+					mock.Anything,
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					mock.AnythingOfType("*cache.CachedAPIKey"),
+				).Return(false, nil).Once()
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				d.store.EXPECT().GetAPIKey(mock.Anything, store.GetAPIKeyRequest{APIKeyID: apiKey}).
+					Return(&store.GetAPIKeyResponse{APIKey: &model.APIKey{
+						APIKeyID: apiKey,
+						UserID:   "user_id",
+						PlanID:   model.PlanFree,
+					}}, nil).Once()
+
+				d.cache.EXPECT().Set(
+					mock.Anything,
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					mock.AnythingOfType("cache.CachedAPIKey"),
+					UsagesCacheLifetime,
+				).Return(testErr).Once()
+			},
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path-cached",
+			mock: func(d deps) {
+				mockCachedKey(d, cache.CachedAPIKey{
+					APIKeyID:    apiKey,
+					UserID:      "user_id",
+					PlanID:      model.PlanFree,
+					ValidatedAt: now.Add(-30 * time.Second).Unix(),
+				})
+
+				d.clock.EXPECT().Now().Return(now).Once()
+			},
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &model.APIKey{
+					APIKeyID: apiKey,
+					UserID:   "user_id",
+					PlanID:   model.PlanFree,
+				}, res)
+			},
+		},
+		{
+			name: "happy-path-revalidated",
+			mock: func(d deps) {
+				mockCachedKey(d, cache.CachedAPIKey{
+					APIKeyID:    apiKey,
+					UserID:      "user_id",
+					PlanID:      model.PlanFree,
+					ValidatedAt: now.Add(-APIKeyRevalidationInterval).Unix(),
+					Usages: []cache.CachedAPIKeyUsage{
+						{Timestamp: now.Unix()},
+					},
+				})
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				d.store.EXPECT().GetAPIKey(mock.Anything, store.GetAPIKeyRequest{APIKeyID: apiKey}).
+					Return(&store.GetAPIKeyResponse{APIKey: &model.APIKey{
+						APIKeyID: apiKey,
+						UserID:   "user_id",
+						PlanID:   model.PlanUnlimited,
+					}}, nil).Once()
+
+				// the plan change is picked up, usages are kept
+				d.cache.EXPECT().Set(mock.Anything, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{
+					APIKeyID:    apiKey,
+					UserID:      "user_id",
+					PlanID:      model.PlanUnlimited,
+					ValidatedAt: now.Unix(),
+					Usages: []cache.CachedAPIKeyUsage{
+						{Timestamp: now.Unix()},
+					},
+				}, UsagesCacheLifetime).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, model.PlanUnlimited, res.PlanID)
+			},
+		},
+		{
+			name: "happy-path-cached-without-plan",
+			mock: func(d deps) {
+				mockCachedKey(d, cache.CachedAPIKey{
+					APIKeyID:    apiKey,
+					UserID:      "user_id",
+					ValidatedAt: now.Unix(),
+				})
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				d.store.EXPECT().GetAPIKey(mock.Anything, store.GetAPIKeyRequest{APIKeyID: apiKey}).
+					Return(&store.GetAPIKeyResponse{APIKey: &model.APIKey{
+						APIKeyID: apiKey,
+						UserID:   "user_id",
+						PlanID:   model.PlanFree,
+					}}, nil).Once()
+
+				d.cache.EXPECT().Set(mock.Anything, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{
+					APIKeyID:    apiKey,
+					UserID:      "user_id",
+					PlanID:      model.PlanFree,
+					ValidatedAt: now.Unix(),
+				}, UsagesCacheLifetime).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, model.PlanFree, res.PlanID)
+			},
+		},
+		{
+			name: "happy-path-organization-key",
+			mock: func(d deps) {
+				d.cache.EXPECT().Get(
+					mock.Anything,
+					cache.GenerateCacheKeyAPIKey(apiKey),
+					mock.AnythingOfType("*cache.CachedAPIKey"),
+				).Return(false, nil).Once()
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				d.store.EXPECT().GetAPIKey(mock.Anything, store.GetAPIKeyRequest{APIKeyID: apiKey}).
+					Return(&store.GetAPIKeyResponse{APIKey: &model.APIKey{
+						APIKeyID:       apiKey,
+						OrganizationID: "organization_id",
+						PlanID:         model.PlanFree,
+					}}, nil).Once()
+
+				d.cache.EXPECT().Set(mock.Anything, cache.GenerateCacheKeyAPIKey(apiKey), cache.CachedAPIKey{
+					APIKeyID:       apiKey,
+					OrganizationID: "organization_id",
+					PlanID:         model.PlanFree,
+					ValidatedAt:    now.Unix(),
+				}, UsagesCacheLifetime).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "organization_id", res.OrganizationID)
+			},
+		},
+		{
+			name: "happy-path-organization-key-cached",
+			mock: func(d deps) {
+				mockCachedKey(d, cache.CachedAPIKey{
+					APIKeyID:       apiKey,
+					OrganizationID: "organization_id",
+					PlanID:         model.PlanFree,
+					ValidatedAt:    now.Unix(),
+				})
+
+				d.clock.EXPECT().Now().Return(now).Once()
+			},
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &model.APIKey{
+					APIKeyID:       apiKey,
+					OrganizationID: "organization_id",
+					PlanID:         model.PlanFree,
+				}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				store: mockstore.NewStore(t),
+				cache: mockcache.NewCache(t),
+				clock: mockclock.NewClock(t),
+			}
+
+			l := Impl{
+				Store: d.store,
+				Cache: d.cache,
+				Clock: d.clock,
+			}
+
+			tc.mock(d)
+
+			res, err := l.ValidateAPIKey(context.Background(), apiKey)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestLogicValidateAPIKey_AccessToken(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	c := mockclock.NewClock(t)
+	c.EXPECT().Now().Return(now).Maybe()
+
+	signer, err := oauth.NewSigner([]byte("0123456789abcdef0123456789abcdef"), c)
+	require.NoError(t, err)
+
+	otherSigner, err := oauth.NewSigner([]byte("fedcba9876543210fedcba9876543210"), c)
+	require.NoError(t, err)
+
+	claims := oauth.Claims{
+		ClientID: "client_id",
+		UserID:   "user_id",
+		Scopes:   []string{oauth.ScopeRatesRead},
+		PlanID:   model.PlanUnlimited,
+	}
+
+	token, expiration, err := signer.Issue(claims)
+	require.NoError(t, err)
+
+	forgedToken, _, err := otherSigner.Issue(claims)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		tokens    *oauth.Signer
+		token     string
+		assertion func(t *testing.T, res *model.APIKey, err error)
+	}{
+		{
+			name:   "error-tokens-not-accepted",
+			tokens: nil,
+			token:  token,
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name:   "error-invalid-signature",
+			tokens: signer,
+			token:  forgedToken,
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name:   "happy-path",
+			tokens: signer,
+			token:  token,
+			assertion: func(t *testing.T, res *model.APIKey, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &model.APIKey{
+					APIKeyID:   "client_id",
+					UserID:     "user_id",
+					PlanID:     model.PlanUnlimited,
+					Expiration: expiration,
+					Scopes:     []string{oauth.ScopeRatesRead},
+				}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			// tokens are verified without cache nor DB lookups
+			l := Impl{
+				Store:  mockstore.NewStore(t),
+				Cache:  mockcache.NewCache(t),
+				Tokens: tc.tokens,
+			}
+
+			res, err := l.ValidateAPIKey(context.Background(), tc.token)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImplQuotas(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	// 2023-11-01 08:30 in Tokyo
+	now := time.Date(2023, 10, 31, 23, 30, 0, 0, time.UTC)
+	plan := &model.Plan{RequestsPerDay: 1000, RequestsPerMonth: 25000}
+
+	tests := []struct {
+		name      string
+		location  *time.Location
+		plan      *model.Plan
+		assertion func(t *testing.T, quotas []*quota)
+	}{
+		{
+			name:     "utc-by-default",
+			location: nil,
+			plan:     plan,
+			assertion: func(t *testing.T, quotas []*quota) {
+				require.Len(t, quotas, 2)
+				assert.Equal(t, "quota_day_2023-10-31_usages", quotas[0].key)
+				assert.Equal(t, 30*time.Minute+quotaExpirationMargin, quotas[0].expiration)
+				assert.Equal(t, "quota_month_2023-10_usages", quotas[1].key)
+				assert.Equal(t, 30*time.Minute+quotaExpirationMargin, quotas[1].expiration)
+			},
+		},
+		{
+			name:     "calendar-of-location",
+			location: tokyo,
+			plan:     plan,
+			assertion: func(t *testing.T, quotas []*quota) {
+				require.Len(t, quotas, 2)
+				assert.Equal(t, "quota_day_2023-11-01_usages", quotas[0].key)
+				assert.Equal(t, 15*time.Hour+30*time.Minute+quotaExpirationMargin, quotas[0].expiration)
+				assert.Equal(t, "quota_month_2023-11_usages", quotas[1].key)
+				assert.Equal(t, 29*24*time.Hour+15*time.Hour+30*time.Minute+quotaExpirationMargin, quotas[1].expiration)
+			},
+		},
+		{
+			name:     "unlimited-periods-omitted",
+			location: nil,
+			plan:     &model.Plan{RequestsPerMonth: 25000},
+			assertion: func(t *testing.T, quotas []*quota) {
+				require.Len(t, quotas, 1)
+				assert.Equal(t, "monthly", quotas[0].name)
+				assert.Equal(t, 25000, quotas[0].limit)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			l := Impl{QuotaLocation: tc.location}
+
+			tc.assertion(t, l.quotas("usages", tc.plan, now))
+		})
+	}
+}
@@ -2,14 +2,23 @@ package logic
 
 type GetRateRequest struct {
 	Pairs []string
+	// QuotaWarnings : whether to report quotas close to exhaustion
+	QuotaWarnings bool
 }
 
 type GetRateResponseRate struct {
-	Pair      string
-	Rate      float64
+	Pair string
+	// Rate : mid rate
+	Rate float64
+	// Bid, Ask : from the source if provided, otherwise quoted with the spread of the plan. 0 if neither is available.
+	Bid       float64
+	Ask       float64
 	Timestamp int64
 }
 
 type GetRateResponse struct {
 	Rates []GetRateResponseRate
+	Cost  Cost
+	// QuotaWarnings : set only if requested, one entry per quota past QuotaWarningRatio
+	QuotaWarnings []string
 }
@@ -8,14 +8,18 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	_ "time/tzdata" // quota timezones must be available in minimal images
 
 	"github.com/benbjohnson/clock"
 	"github.com/gin-gonic/gin"
 
+	"github.com/lruggieri/fxnow/common/app"
 	"github.com/lruggieri/fxnow/common/cache/redis"
+	"github.com/lruggieri/fxnow/common/health"
 	cHttp "github.com/lruggieri/fxnow/common/http"
 	"github.com/lruggieri/fxnow/common/logger"
 	"github.com/lruggieri/fxnow/common/logger/zap"
+	"github.com/lruggieri/fxnow/common/oauth"
 	"github.com/lruggieri/fxnow/common/store"
 	"github.com/lruggieri/fxnow/common/store/mysql"
 	"github.com/lruggieri/fxnow/common/util"
@@ -23,6 +27,15 @@ import (
 	"github.com/lruggieri/fxnow/fxrate/logic"
 )
 
+const (
+	// HeaderQuotaWarnings : request header opting in to quota warnings (e.g. "true")
+	HeaderQuotaWarnings = "X-Quota-Warnings"
+	// HeaderQuotaWarning : response header reporting a quota close to exhaustion, repeated for each quota
+	HeaderQuotaWarning = "X-Quota-Warning"
+	// HeaderRequestCost : response header with the units charged for the request, and how they were computed
+	HeaderRequestCost = "X-Request-Cost"
+)
+
 var (
 	l logic.Logic
 
@@ -35,6 +48,8 @@ func main() {
 		Level:       logger.LevelDebug,
 	}))
 
+	application := app.New("fxrate")
+
 	port := os.Getenv("PORT")
 
 	mysqlPort, err := strconv.Atoi(os.Getenv("MYSQL_PORT"))
@@ -53,16 +68,51 @@ func main() {
 		panic(err)
 	}
 
+	application.OnShutdown("mysql", func(ctx context.Context) error {
+		return str.Close()
+	})
+
+	redisClient := redis.NewClient(redis.Config{
+		Addrs: []string{os.Getenv("REDIS_ADDRS")},
+	})
+
+	application.OnShutdown("redis", func(ctx context.Context) error {
+		return redisClient.Close()
+	})
+
 	cache := &redis.Cacher{
-		Client: redis.NewClient(redis.Config{
-			Addrs: []string{os.Getenv("REDIS_ADDRS")},
-		}),
+		Client: redisClient,
+	}
+
+	// rates are served from the cache, and keys and quotas from the store
+	readiness := &health.Registry{Clock: clock.New()}
+	readiness.Register(health.Check{Name: "mysql", Checker: health.CheckerFunc(str.Ping)})
+	readiness.Register(health.Check{Name: "redis", Checker: health.CheckerFunc(cache.Ping)})
+
+	// OAuth2 access tokens are accepted only when sharing the signing key with identity
+	var tokens *oauth.Signer
+
+	if tokenKey := os.Getenv("OAUTH_TOKEN_KEY"); tokenKey != "" {
+		if tokens, err = oauth.NewSigner([]byte(tokenKey), clock.New()); err != nil {
+			panic(err)
+		}
+	}
+
+	// daily and monthly quotas reset at midnight of this timezone
+	quotaLocation := time.UTC
+
+	if tz := os.Getenv("QUOTA_TIMEZONE"); tz != "" {
+		if quotaLocation, err = time.LoadLocation(tz); err != nil {
+			panic(err)
+		}
 	}
 
 	l = &logic.Impl{
-		Store: str,
-		Cache: cache,
-		Clock: clock.New(),
+		Store:         str,
+		Cache:         cache,
+		Clock:         clock.New(),
+		Tokens:        tokens,
+		QuotaLocation: quotaLocation,
 	}
 
 	if err != nil {
@@ -71,11 +121,18 @@ func main() {
 
 	r := gin.Default()
 	r.GET("/fxrate/health", HandleHealth)
+	r.GET("/fxrate/livez", readiness.HandleLive)
+	r.GET("/fxrate/readyz", readiness.HandleReady)
 
-	v1 := r.Group("/fxrate/v1")
-	v1.GET("/rate", HandleGetRate)
+	// every API requires an API key, or an OAuth2 access token granted the relevant scope
+	v1 := r.Group("/fxrate/v1", cHttp.APIKeyAuth(l))
+	v1.GET("/rate", cHttp.RequireScope(oauth.ScopeRatesRead), HandleGetRate)
 
-	panic(r.Run(fmt.Sprintf(":%s", port)))
+	application.Serve(app.NewServer(fmt.Sprintf(":%s", port), r))
+
+	if err = application.Run(); err != nil {
+		panic(err)
+	}
 }
 
 func HandleHealth(c *gin.Context) {
@@ -88,20 +145,12 @@ func HandleGetRate(c *gin.Context) {
 	// pairs: a list of currency pairs separated by comma (e.g. "USD_JPY,EUR_USD,GBP_CAD")
 	pairsStr := c.Query("pairs")
 
-	apiKey := c.Query("api-key")
-
 	if pairsStr == "" {
 		cHttp.HTTPResponse(c, nil, fmt.Errorf("missing 'pairs' parameter"), http.StatusBadRequest)
 
 		return
 	}
 
-	if apiKey == "" {
-		cHttp.HTTPResponse(c, nil, fmt.Errorf("missing 'api-key' parameter"), http.StatusBadRequest)
-
-		return
-	}
-
 	pairs := strings.Split(pairsStr, ",")
 
 	cleanPairs := util.Map(pairs, func(item string) string {
@@ -112,8 +161,12 @@ func HandleGetRate(c *gin.Context) {
 		return item != ""
 	})
 
-	res, err := l.GetRate(context.WithValue(c, logic.ContextKeyAPIKey, apiKey), logic.GetRateRequest{
-		Pairs: cleanPairs,
+	// quota warnings are opt-in, as most clients would ignore them
+	quotaWarnings, _ := strconv.ParseBool(c.GetHeader(HeaderQuotaWarnings))
+
+	res, err := l.GetRate(c.Request.Context(), logic.GetRateRequest{
+		Pairs:         cleanPairs,
+		QuotaWarnings: quotaWarnings,
 	})
 	if err != nil {
 		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
@@ -121,9 +174,20 @@ func HandleGetRate(c *gin.Context) {
 		return
 	}
 
+	c.Header(HeaderRequestCost, res.Cost.Breakdown())
+
+	for _, warning := range res.QuotaWarnings {
+		c.Writer.Header().Add(HeaderQuotaWarning, warning)
+	}
+
+	// rate is kept along with mid for existing clients. bid and ask are omitted if neither the source nor the plan
+	// provide them.
 	type responseRate struct {
 		Pair      string  `json:"pair"`
 		Rate      float64 `json:"rate"`
+		Mid       float64 `json:"mid"`
+		Bid       float64 `json:"bid,omitempty"`
+		Ask       float64 `json:"ask,omitempty"`
 		Timestamp int64   `json:"timestamp"`
 	}
 
@@ -133,6 +197,9 @@ func HandleGetRate(c *gin.Context) {
 		rates = append(rates, responseRate{
 			Pair:      rate.Pair,
 			Rate:      rate.Rate,
+			Mid:       rate.Rate,
+			Bid:       rate.Bid,
+			Ask:       rate.Ask,
 			Timestamp: rate.Timestamp,
 		})
 	}
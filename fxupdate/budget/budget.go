@@ -0,0 +1,190 @@
+// Package budget keeps the calls made to paid FX sources within their daily or monthly budget, by slowing down the
+// refresh of the rates as the budget runs low.
+package budget
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lruggieri/fxnow/common/cache"
+	"github.com/lruggieri/fxnow/common/clock"
+)
+
+const (
+	PeriodUndefined Period = iota
+	PeriodDay
+	PeriodMonth
+)
+
+const (
+	// LowPriorityFactor : interval multiplier of all the groups but the first one, once calls are ahead of the
+	// budget
+	LowPriorityFactor = 4
+	// MaxFactor : interval multiplier of the first group when the budget is almost exhausted
+	MaxFactor = 10
+	// pausePace : below this pace, only the first group is refreshed
+	pausePace = 0.5
+	// expirationMargin : counters outlive their period, so that clock skews between replicas cannot reset them
+	expirationMargin = time.Hour
+)
+
+type Period uint8
+
+func (p Period) String() string {
+	switch p {
+	case PeriodDay:
+		return "day"
+	case PeriodMonth:
+		return "month"
+	default:
+		return "undefined"
+	}
+}
+
+// PeriodFromString : inverse of Period.String. Returns PeriodUndefined for unknown periods.
+func PeriodFromString(period string) Period {
+	switch strings.ToLower(strings.TrimSpace(period)) {
+	case PeriodDay.String():
+		return PeriodDay
+	case PeriodMonth.String():
+		return PeriodMonth
+	default:
+		return PeriodUndefined
+	}
+}
+
+type Budget interface {
+	// Record : counts calls made to the source towards the budget
+	Record(ctx context.Context, calls int64) error
+	// Refresh : loads the calls counted by other replicas
+	Refresh(ctx context.Context) error
+	Status() Status
+}
+
+type Status struct {
+	Source    string    `json:"source"`
+	Period    string    `json:"period"`
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+	// Pace : share of the budget remaining over share of the period remaining. Below 1, calls are ahead of the
+	// budget.
+	Pace float64 `json:"pace"`
+}
+
+// Factor : multiplier of the refresh interval of a currency group, 0 if the group must not be refreshed. Groups are
+// slowed down by priority (0 being the highest), so that the most requested currencies stay fresh the longest.
+func (s Status) Factor(priority int) float64 {
+	switch {
+	case s.Remaining <= 0:
+		return 0
+	case s.Pace >= 1:
+		return 1
+	case priority > 0 && s.Pace < pausePace:
+		return 0
+	case priority > 0:
+		return LowPriorityFactor
+	case s.Pace < pausePace:
+		return math.Min(1/s.Pace, MaxFactor)
+	default:
+		return 1
+	}
+}
+
+// Tracker : counts the calls in the cache, so that they are shared by replicas and survive restarts
+type Tracker struct {
+	Cache cache.Cache
+	Clock clock.Clock
+	// Source : name of the FX source the budget applies to
+	Source string
+	// Limit : calls allowed within each period
+	Limit  int64
+	Period Period
+	// Location : optional, where periods start. Defaults to UTC.
+	Location *time.Location
+
+	mu sync.Mutex
+	// used : calls counted within usedPeriod, as of the last update of the counter
+	used       int64
+	usedPeriod string
+}
+
+func (t *Tracker) Record(ctx context.Context, calls int64) error {
+	key, expiration, _ := t.period(t.Clock.Now())
+
+	used, err := t.Cache.Increment(ctx, cache.GenerateCacheKeyBudget(t.Source, key), calls, expiration)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.used = used
+	t.usedPeriod = key
+
+	return nil
+}
+
+func (t *Tracker) Refresh(ctx context.Context) error {
+	return t.Record(ctx, 0)
+}
+
+func (t *Tracker) Status() Status {
+	now := t.Clock.Now()
+	key, _, bounds := t.period(now)
+
+	t.mu.Lock()
+	used := t.used
+
+	if t.usedPeriod != key {
+		used = 0
+	}
+	t.mu.Unlock()
+
+	remaining := t.Limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	pace := 1.0
+	if timeLeft := bounds[1].Sub(now); timeLeft > 0 && t.Limit > 0 {
+		pace = (float64(remaining) / float64(t.Limit)) / (float64(timeLeft) / float64(bounds[1].Sub(bounds[0])))
+	}
+
+	return Status{
+		Source:    t.Source,
+		Period:    t.Period.String(),
+		Limit:     t.Limit,
+		Used:      used,
+		Remaining: remaining,
+		ResetsAt:  bounds[1],
+		Pace:      pace,
+	}
+}
+
+// period : counter key and expiration of the period including now, and its start and end
+func (t *Tracker) period(now time.Time) (key string, expiration time.Duration, bounds [2]time.Time) {
+	loc := t.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+
+	if t.Period == PeriodDay {
+		start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		bounds = [2]time.Time{start, start.AddDate(0, 0, 1)}
+		key = "day_" + local.Format("2006-01-02")
+	} else {
+		start := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+		bounds = [2]time.Time{start, start.AddDate(0, 1, 0)}
+		key = "month_" + local.Format("2006-01")
+	}
+
+	return key, bounds[1].Sub(now) + expirationMargin, bounds
+}
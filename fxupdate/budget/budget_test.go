@@ -0,0 +1,106 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lruggieri/fxnow/common/cache"
+	mockcache "github.com/lruggieri/fxnow/common/mock/cache"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+)
+
+func TestStatus_Factor(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   Status
+		expected []float64 // by priority
+	}{
+		{
+			name:     "on-pace",
+			status:   Status{Remaining: 100, Pace: 1.2},
+			expected: []float64{1, 1, 1},
+		},
+		{
+			name:     "ahead-of-budget",
+			status:   Status{Remaining: 100, Pace: 0.8},
+			expected: []float64{1, LowPriorityFactor, LowPriorityFactor},
+		},
+		{
+			name:     "running-low",
+			status:   Status{Remaining: 100, Pace: 0.25},
+			expected: []float64{4, 0, 0},
+		},
+		{
+			name:     "almost-exhausted",
+			status:   Status{Remaining: 1, Pace: 0.01},
+			expected: []float64{MaxFactor, 0, 0},
+		},
+		{
+			name:     "exhausted",
+			status:   Status{Remaining: 0, Pace: 0},
+			expected: []float64{0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			for priority, expected := range tc.expected {
+				assert.Equal(t, expected, tc.status.Factor(priority), "priority %d", priority)
+			}
+		})
+	}
+}
+
+func TestTracker(t *testing.T) {
+	testErr := errors.New("error")
+	ctx := context.Background()
+	// 10 days into a 30 days month
+	now := time.Date(2023, time.November, 11, 0, 0, 0, 0, time.UTC)
+
+	c := mockcache.NewCache(t)
+	clk := mockclock.NewClock(t)
+	clk.EXPECT().Now().RunAndReturn(func() time.Time {
+		return now
+	})
+
+	tracker := Tracker{
+		Cache:  c,
+		Clock:  clk,
+		Source: "fastforex",
+		Limit:  1000,
+		Period: PeriodMonth,
+	}
+
+	key := cache.GenerateCacheKeyBudget("fastforex", "month_2023-11")
+	expiration := 20*24*time.Hour + expirationMargin
+
+	// other replicas already used half of the budget
+	c.EXPECT().Increment(ctx, key, int64(0), expiration).Return(500, nil).Once()
+	assert.Nil(t, tracker.Refresh(ctx))
+
+	c.EXPECT().Increment(ctx, key, int64(7), expiration).Return(507, nil).Once()
+	assert.Nil(t, tracker.Record(ctx, 7))
+
+	c.EXPECT().Increment(ctx, key, int64(3), expiration).Return(0, testErr).Once()
+	assert.ErrorIs(t, tracker.Record(ctx, 3), testErr)
+
+	status := tracker.Status()
+	assert.Equal(t, "month", status.Period)
+	assert.Equal(t, int64(507), status.Used)
+	assert.Equal(t, int64(493), status.Remaining)
+	assert.Equal(t, time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC), status.ResetsAt)
+	assert.InDelta(t, 0.493/(20.0/30.0), status.Pace, 0.0001)
+
+	// the counter of the previous month does not apply to the new one
+	now = time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	status = tracker.Status()
+	assert.Equal(t, int64(0), status.Used)
+	assert.Equal(t, int64(1000), status.Remaining)
+	assert.InDelta(t, 1, status.Pace, 0.0001)
+}
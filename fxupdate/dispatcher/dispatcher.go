@@ -0,0 +1,305 @@
+// Package dispatcher evaluates the webhooks registered by users against the rates fetched by fxupdate, and posts
+// the signed payloads of the ones whose condition is met.
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/client/httpclient"
+	"github.com/lruggieri/fxnow/common/clock"
+	"github.com/lruggieri/fxnow/common/fxsource"
+	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/store"
+	"github.com/lruggieri/fxnow/common/util"
+	"github.com/lruggieri/fxnow/common/webhook"
+)
+
+const (
+	// WebhooksRefreshInterval : how often registered webhooks are reloaded from the store
+	WebhooksRefreshInterval = time.Minute
+	// MaxDeliveryAttempts : attempts before a payload is moved to the dead-letter list
+	MaxDeliveryAttempts = 5
+	// DefaultBackoff : delay before the first retry, doubled at every following attempt
+	DefaultBackoff = time.Second
+	// DeliveryTimeout : maximum duration of a single delivery attempt
+	DeliveryTimeout = 10 * time.Second
+	// DeadLetterTimeout : maximum duration of the write of a dead letter
+	DeadLetterTimeout = 5 * time.Second
+)
+
+type Dispatcher interface {
+	// Dispatch : evaluates the webhooks against the rates of an update cycle. Deliveries happen asynchronously.
+	Dispatch(ctx context.Context, rates []fxsource.Rate)
+}
+
+type point struct {
+	rate      float64
+	timestamp int64 // unix (s)
+}
+
+type Impl struct {
+	Store      store.Store
+	HTTPClient httpclient.Client
+	Clock      clock.Clock
+	// Backoff : optional, defaults to DefaultBackoff
+	Backoff time.Duration
+
+	mu               sync.Mutex
+	webhooks         []*model.Webhook
+	webhooksLoadedAt time.Time
+	// history : rates of each pair within model.MaxWebhookWindow, oldest first
+	history map[string][]point
+	// lastFired : change conditions are not notified again until their window has elapsed
+	lastFired map[string]time.Time
+
+	deliveries sync.WaitGroup
+}
+
+func (i *Impl) Dispatch(ctx context.Context, rates []fxsource.Rate) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := i.Clock.Now()
+
+	i.refreshWebhooks(ctx, now)
+
+	if i.history == nil {
+		i.history = make(map[string][]point)
+		i.lastFired = make(map[string]time.Time)
+	}
+
+	updated := make(map[string]bool, len(rates))
+
+	for _, rate := range rates {
+		pair := util.PairFromCurrencies(rate.From, rate.To)
+
+		if i.record(pair, point{rate: rate.Rate, timestamp: rate.Timestamp}) {
+			updated[pair] = true
+		}
+	}
+
+	for _, w := range i.webhooks {
+		if !updated[w.Pair] {
+			continue
+		}
+
+		payload, ok := i.evaluate(w, now)
+		if !ok {
+			continue
+		}
+
+		i.deliveries.Add(1)
+
+		go func(w *model.Webhook, payload webhook.Payload) {
+			defer i.deliveries.Done()
+
+			i.deliver(ctx, w, payload)
+		}(w, payload)
+	}
+}
+
+// Wait : blocks until the pending deliveries are completed
+func (i *Impl) Wait() {
+	i.deliveries.Wait()
+}
+
+// refreshWebhooks : keeps using the previous webhooks if they cannot be reloaded
+func (i *Impl) refreshWebhooks(ctx context.Context, now time.Time) {
+	if !i.webhooksLoadedAt.IsZero() && now.Sub(i.webhooksLoadedAt) < WebhooksRefreshInterval {
+		return
+	}
+
+	res, err := i.Store.ListWebhooks(ctx, store.ListWebhooksRequest{})
+	if err != nil {
+		logger.WithError(err).Error("cannot load webhooks")
+
+		return
+	}
+
+	i.webhooks = res.Webhooks
+	i.webhooksLoadedAt = now
+}
+
+// record : adds the point to the history of the pair, returning false if the rate was not updated since the
+// previous cycle
+func (i *Impl) record(pair string, p point) bool {
+	history := i.history[pair]
+	if len(history) > 0 && history[len(history)-1].timestamp >= p.timestamp {
+		return false
+	}
+
+	history = append(history, p)
+
+	oldest := p.timestamp - int64(model.MaxWebhookWindow.Seconds())
+	for len(history) > 0 && history[0].timestamp < oldest {
+		history = history[1:]
+	}
+
+	i.history[pair] = history
+
+	return true
+}
+
+// evaluate : whether the latest rate of the pair meets the condition of the webhook
+func (i *Impl) evaluate(w *model.Webhook, now time.Time) (webhook.Payload, bool) {
+	history := i.history[w.Pair]
+	if len(history) < 2 {
+		return webhook.Payload{}, false
+	}
+
+	current := history[len(history)-1]
+	payload := webhook.Payload{
+		WebhookID: w.WebhookID,
+		Pair:      w.Pair,
+		Condition: w.Condition.String(),
+		Threshold: w.Threshold,
+		Rate:      current.rate,
+		Timestamp: current.timestamp,
+	}
+
+	switch w.Condition {
+	case model.WebhookConditionCrosses:
+		previous := history[len(history)-2]
+		if (previous.rate < w.Threshold) == (current.rate < w.Threshold) {
+			return webhook.Payload{}, false
+		}
+
+		payload.ReferenceRate = previous.rate
+	case model.WebhookConditionChange:
+		if last, ok := i.lastFired[w.WebhookID]; ok && now.Sub(last) < w.WindowDuration() {
+			return webhook.Payload{}, false
+		}
+
+		reference, ok := referencePoint(history, current.timestamp-w.Window)
+		if !ok || reference.rate == 0 {
+			return webhook.Payload{}, false
+		}
+
+		if math.Abs(current.rate-reference.rate)/reference.rate*100 < w.Threshold {
+			return webhook.Payload{}, false
+		}
+
+		i.lastFired[w.WebhookID] = now
+		payload.Window = w.Window
+		payload.ReferenceRate = reference.rate
+	default:
+		return webhook.Payload{}, false
+	}
+
+	return payload, true
+}
+
+// referencePoint : oldest point within the window, excluding the latest one
+func referencePoint(history []point, from int64) (point, bool) {
+	for _, p := range history[:len(history)-1] {
+		if p.timestamp >= from {
+			return p, true
+		}
+	}
+
+	return point{}, false
+}
+
+// deliver : posts the payload until it is accepted, then moves it to the dead-letter list. Once the context is done
+// (e.g. on shutdown), the attempt in flight completes but no other is made, and the payload is moved to the
+// dead-letter list right away.
+func (i *Impl) deliver(ctx context.Context, w *model.Webhook, payload webhook.Payload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.WithError(err).WithField("webhook_id", w.WebhookID).Error("cannot marshal webhook payload")
+
+		return
+	}
+
+	backoff := i.Backoff
+	if backoff <= 0 {
+		backoff = DefaultBackoff
+	}
+
+	detached := util.WithoutCancel(ctx)
+	attempts := 0
+
+	for attempts < MaxDeliveryAttempts {
+		attempts++
+
+		if err = i.post(detached, w, body); err == nil {
+			return
+		}
+
+		if attempts == MaxDeliveryAttempts || !sleep(ctx, backoff) {
+			break
+		}
+
+		backoff *= 2
+	}
+
+	logger.WithError(err).WithField("webhook_id", w.WebhookID).Error("webhook delivery failed")
+
+	dlCtx, cancel := context.WithTimeout(detached, DeadLetterTimeout)
+	defer cancel()
+
+	if _, dlErr := i.Store.CreateWebhookDeadLetter(dlCtx, store.CreateWebhookDeadLetterRequest{
+		WebhookID: w.WebhookID,
+		Payload:   string(body),
+		Error:     err.Error(),
+		Attempts:  attempts,
+	}); dlErr != nil {
+		logger.WithError(dlErr).WithField("webhook_id", w.WebhookID).Error("cannot store webhook dead letter")
+	}
+}
+
+func (i *Impl) post(ctx context.Context, w *model.Webhook, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, DeliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot create request")
+	}
+
+	timestamp := i.Clock.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(w.Secret, timestamp, body))
+
+	resp, err := i.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot post payload")
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// sleep : returns false if the context is done before the duration elapses
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,297 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lruggieri/fxnow/common/fxsource"
+	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/logger/zap"
+	mockhttpclient "github.com/lruggieri/fxnow/common/mock/client/httpclient"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+	mockstore "github.com/lruggieri/fxnow/common/mock/store"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/store"
+	"github.com/lruggieri/fxnow/common/webhook"
+)
+
+func TestImpl_evaluate(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	crosses := &model.Webhook{
+		WebhookID: "webhook_id",
+		Pair:      "EUR_USD",
+		Condition: model.WebhookConditionCrosses,
+		Threshold: 1.1,
+	}
+	change := &model.Webhook{
+		WebhookID: "webhook_id",
+		Pair:      "EUR_USD",
+		Condition: model.WebhookConditionChange,
+		Threshold: 0.5,
+		Window:    3600,
+	}
+
+	tests := []struct {
+		name              string
+		webhook           *model.Webhook
+		history           []point
+		lastFired         map[string]time.Time
+		expectedOK        bool
+		expectedReference float64
+	}{
+		{
+			name:    "crosses-single-point",
+			webhook: crosses,
+			history: []point{{rate: 1.11, timestamp: 100}},
+		},
+		{
+			name:              "crosses-upwards",
+			webhook:           crosses,
+			history:           []point{{rate: 1.09, timestamp: 100}, {rate: 1.1, timestamp: 120}},
+			expectedOK:        true,
+			expectedReference: 1.09,
+		},
+		{
+			name:              "crosses-downwards",
+			webhook:           crosses,
+			history:           []point{{rate: 1.12, timestamp: 100}, {rate: 1.08, timestamp: 120}},
+			expectedOK:        true,
+			expectedReference: 1.12,
+		},
+		{
+			name:    "crosses-same-side",
+			webhook: crosses,
+			history: []point{{rate: 1.11, timestamp: 100}, {rate: 1.12, timestamp: 120}},
+		},
+		{
+			name:    "change-below-threshold",
+			webhook: change,
+			history: []point{{rate: 1, timestamp: 100}, {rate: 1.004, timestamp: 1000}},
+		},
+		{
+			name:    "change-outside-window",
+			webhook: change,
+			history: []point{{rate: 1, timestamp: 100}, {rate: 1.1, timestamp: 4000}},
+		},
+		{
+			name:              "change-above-threshold",
+			webhook:           change,
+			history:           []point{{rate: 1, timestamp: 100}, {rate: 1.002, timestamp: 500}, {rate: 0.99, timestamp: 1000}},
+			expectedOK:        true,
+			expectedReference: 1,
+		},
+		{
+			name:      "change-cooldown",
+			webhook:   change,
+			history:   []point{{rate: 1, timestamp: 100}, {rate: 0.99, timestamp: 1000}},
+			lastFired: map[string]time.Time{"webhook_id": now.Add(-30 * time.Minute)},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			lastFired := tc.lastFired
+			if lastFired == nil {
+				lastFired = make(map[string]time.Time)
+			}
+
+			d := Impl{
+				history:   map[string][]point{"EUR_USD": tc.history},
+				lastFired: lastFired,
+			}
+
+			payload, ok := d.evaluate(tc.webhook, now)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedReference, payload.ReferenceRate)
+
+			if ok {
+				assert.Equal(t, tc.history[len(tc.history)-1].rate, payload.Rate)
+				assert.Equal(t, tc.webhook.Condition.String(), payload.Condition)
+			}
+		})
+	}
+}
+
+func TestImpl_Dispatch(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	testErr := errors.New("error")
+	now := time.Unix(1700000000, 0)
+
+	w := &model.Webhook{
+		WebhookID: "webhook_id",
+		URL:       "https://example.com/hook",
+		Secret:    "secret",
+		Pair:      "EUR_USD",
+		Condition: model.WebhookConditionCrosses,
+		Threshold: 1.1,
+	}
+
+	cycles := [][]fxsource.Rate{
+		{{From: "EUR", To: "USD", Rate: 1.09, Timestamp: now.Unix() - 20}},
+		{{From: "EUR", To: "USD", Rate: 1.11, Timestamp: now.Unix()}},
+	}
+
+	response := func(status int) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(""))}
+	}
+
+	type deps struct {
+		store      *mockstore.Store
+		httpClient *mockhttpclient.Client
+		clock      *mockclock.Clock
+	}
+
+	tests := []struct {
+		name string
+		mock func(d deps)
+	}{
+		{
+			name: "error-list-webhooks",
+			mock: func(d deps) {
+				d.store.EXPECT().ListWebhooks(mock.Anything, store.ListWebhooksRequest{}).Return(nil, testErr).Twice()
+			},
+		},
+		{
+			name: "happy-path",
+			mock: func(d deps) {
+				d.store.EXPECT().ListWebhooks(mock.Anything, store.ListWebhooksRequest{}).
+					Return(&store.ListWebhooksResponse{Webhooks: []*model.Webhook{w}}, nil).Once()
+				d.httpClient.EXPECT().Do(mock.Anything).RunAndReturn(func(req *http.Request) (*http.Response, error) {
+					body, err := io.ReadAll(req.Body)
+					assert.Nil(t, err)
+
+					var payload webhook.Payload
+					assert.Nil(t, json.Unmarshal(body, &payload))
+					assert.Equal(t, webhook.Payload{
+						WebhookID:     "webhook_id",
+						Pair:          "EUR_USD",
+						Condition:     "crosses",
+						Threshold:     1.1,
+						Rate:          1.11,
+						ReferenceRate: 1.09,
+						Timestamp:     now.Unix(),
+					}, payload)
+
+					timestamp, err := strconv.ParseInt(req.Header.Get(webhook.HeaderTimestamp), 10, 64)
+					assert.Nil(t, err)
+					assert.True(t, webhook.Verify("secret", timestamp, body, req.Header.Get(webhook.HeaderSignature)))
+
+					return response(http.StatusNoContent), nil
+				}).Once()
+			},
+		},
+		{
+			name: "dead-letter",
+			mock: func(d deps) {
+				d.store.EXPECT().ListWebhooks(mock.Anything, store.ListWebhooksRequest{}).
+					Return(&store.ListWebhooksResponse{Webhooks: []*model.Webhook{w}}, nil).Once()
+				d.httpClient.EXPECT().Do(mock.Anything).Return(response(http.StatusInternalServerError), nil).
+					Times(MaxDeliveryAttempts)
+				d.store.EXPECT().CreateWebhookDeadLetter(mock.Anything, mock.MatchedBy(
+					func(req store.CreateWebhookDeadLetterRequest) bool {
+						return req.WebhookID == "webhook_id" && req.Attempts == MaxDeliveryAttempts &&
+							req.Error == "unexpected status code 500"
+					},
+				)).Return(&store.CreateWebhookDeadLetterResponse{}, nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				store:      mockstore.NewStore(t),
+				httpClient: mockhttpclient.NewClient(t),
+				clock:      mockclock.NewClock(t),
+			}
+
+			d.clock.EXPECT().Now().Return(now)
+
+			dispatcher := Impl{
+				Store:      d.store,
+				HTTPClient: d.httpClient,
+				Clock:      d.clock,
+				Backoff:    time.Nanosecond,
+			}
+
+			tc.mock(d)
+
+			for _, rates := range cycles {
+				dispatcher.Dispatch(context.Background(), rates)
+			}
+
+			dispatcher.Wait()
+		})
+	}
+}
+
+func TestImpl_Dispatch_shutdown(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	now := time.Unix(1700000000, 0)
+
+	w := &model.Webhook{
+		WebhookID: "webhook_id",
+		URL:       "https://example.com/hook",
+		Secret:    "secret",
+		Pair:      "EUR_USD",
+		Condition: model.WebhookConditionCrosses,
+		Threshold: 1.1,
+	}
+
+	s := mockstore.NewStore(t)
+	httpClient := mockhttpclient.NewClient(t)
+	clk := mockclock.NewClock(t)
+	clk.EXPECT().Now().Return(now)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.EXPECT().ListWebhooks(mock.Anything, store.ListWebhooksRequest{}).
+		Return(&store.ListWebhooksResponse{Webhooks: []*model.Webhook{w}}, nil).Once()
+
+	// the shutdown starts while the first attempt is in flight, which completes
+	httpClient.EXPECT().Do(mock.Anything).RunAndReturn(func(req *http.Request) (*http.Response, error) {
+		cancel()
+		assert.Nil(t, req.Context().Err())
+
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(""))},
+			nil
+	}).Once()
+
+	// no retry is made, and the dead letter is written despite the shutdown
+	s.EXPECT().CreateWebhookDeadLetter(mock.Anything, mock.MatchedBy(func(req store.CreateWebhookDeadLetterRequest) bool {
+		return req.WebhookID == "webhook_id" && req.Attempts == 1
+	})).RunAndReturn(func(
+		ctx context.Context, req store.CreateWebhookDeadLetterRequest,
+	) (*store.CreateWebhookDeadLetterResponse, error) {
+		assert.Nil(t, ctx.Err())
+
+		return &store.CreateWebhookDeadLetterResponse{}, nil
+	}).Once()
+
+	dispatcher := Impl{
+		Store:      s,
+		HTTPClient: httpClient,
+		Clock:      clk,
+		Backoff:    time.Hour,
+	}
+
+	dispatcher.Dispatch(ctx, []fxsource.Rate{{From: "EUR", To: "USD", Rate: 1.09, Timestamp: now.Unix() - 20}})
+	dispatcher.Dispatch(ctx, []fxsource.Rate{{From: "EUR", To: "USD", Rate: 1.11, Timestamp: now.Unix()}})
+	dispatcher.Wait()
+}
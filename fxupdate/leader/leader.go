@@ -0,0 +1,238 @@
+// Package leader elects the single fxupdate replica fetching the rates, so that calls to paid FX sources are not
+// multiplied by the replicas and their writes do not race. Followers keep their state up to date, so that they can
+// take over as soon as the lease of the leader is released or expires.
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/clock"
+	"github.com/lruggieri/fxnow/common/logger"
+)
+
+const (
+	RoleFollower Role = iota
+	RoleLeader
+)
+
+const (
+	// DefaultTTL : how long the lease lasts unless renewed, which bounds the takeover after a leader crashes
+	DefaultTTL = 10 * time.Second
+	// renewalsPerTTL : the lease is renewed, or campaigned for, this many times within its TTL
+	renewalsPerTTL = 3
+	// driftMargin : share of the TTL the lease is considered lost in advance, to absorb clock drifts with Redis
+	driftMargin = 0.1
+	// releaseTimeout : the lease is released on shutdown, when the context of the election is already done
+	releaseTimeout = time.Second
+)
+
+var ErrNotLeader = errors.New("not the leader")
+
+type Role uint8
+
+func (r Role) String() string {
+	switch r {
+	case RoleLeader:
+		return "leader"
+	default:
+		return "follower"
+	}
+}
+
+// Lease : see redis.Lease
+type Lease interface {
+	Acquire(ctx context.Context, ttl time.Duration) (int64, error)
+	Release(ctx context.Context, token int64) error
+	Check(ctx context.Context, token int64) (bool, error)
+}
+
+type Elector interface {
+	// Run : campaigns for the lease until the context is done, then releases it
+	Run(ctx context.Context)
+	// IsLeader : whether the lease is held and not expired, as far as the replica knows
+	IsLeader() bool
+	// Fence : returns the fencing token of the lease, or ErrNotLeader unless the lease is still held with it. To be
+	// called right before writing, as the lease may have been lost without noticing (e.g. after a long pause). The
+	// lease can still be lost right after, so writes must be conditional on the token (see cache.Cache.SetFenced).
+	Fence(ctx context.Context) (int64, error)
+	Status() Status
+}
+
+type Status struct {
+	Role   string `json:"role"`
+	Holder string `json:"holder"`
+	// Token : fencing token of the leader, 0 for followers
+	Token int64 `json:"token,omitempty"`
+	// Since : when the replica became leader
+	Since *time.Time `json:"since,omitempty"`
+}
+
+type LeaseElector struct {
+	Lease Lease
+	Clock clock.Clock
+	// Holder : identifier of the replica, reported by Status
+	Holder string
+	// TTL : optional, defaults to DefaultTTL
+	TTL time.Duration
+
+	mu         sync.Mutex
+	token      int64
+	validUntil time.Time
+	since      time.Time
+}
+
+func (e *LeaseElector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.ttl() / renewalsPerTTL)
+	defer ticker.Stop()
+
+	e.campaign(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.release()
+
+			return
+		case <-ticker.C:
+			e.campaign(ctx)
+		}
+	}
+}
+
+func (e *LeaseElector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.isLeader()
+}
+
+func (e *LeaseElector) Fence(ctx context.Context) (int64, error) {
+	e.mu.Lock()
+	token := e.token
+	leader := e.isLeader()
+	e.mu.Unlock()
+
+	if !leader {
+		return 0, ErrNotLeader
+	}
+
+	held, err := e.Lease.Check(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+
+	if !held {
+		e.demote(token)
+
+		return 0, ErrNotLeader
+	}
+
+	return token, nil
+}
+
+func (e *LeaseElector) Status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status := Status{
+		Role:   RoleFollower.String(),
+		Holder: e.Holder,
+	}
+
+	if e.isLeader() {
+		since := e.since
+		status.Role = RoleLeader.String()
+		status.Token = e.token
+		status.Since = &since
+	}
+
+	return status
+}
+
+// campaign : acquires or renews the lease. The lease is considered valid from before the request was sent, so that
+// the replica never outlives it.
+func (e *LeaseElector) campaign(ctx context.Context) {
+	start := e.Clock.Now()
+
+	token, err := e.Lease.Acquire(ctx, e.ttl())
+	if err != nil {
+		// the lease may still be valid, until it expires
+		logger.WithError(err).Error("cannot campaign for leadership")
+
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if token == 0 {
+		if e.token != 0 {
+			logger.Info("lost leadership with fencing token %d", e.token)
+		}
+
+		e.token = 0
+
+		return
+	}
+
+	if token != e.token || !e.isLeader() {
+		logger.Info("became leader with fencing token %d", token)
+
+		e.since = start
+	}
+
+	e.token = token
+	e.validUntil = start.Add(e.ttl() - time.Duration(driftMargin*float64(e.ttl())))
+}
+
+// release : lets followers take over right away
+func (e *LeaseElector) release() {
+	e.mu.Lock()
+	token := e.token
+	leader := e.isLeader()
+	e.token = 0
+	e.mu.Unlock()
+
+	if !leader {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	if err := e.Lease.Release(ctx, token); err != nil {
+		logger.WithError(err).Error("cannot release leadership")
+
+		return
+	}
+
+	logger.Info("released leadership with fencing token %d", token)
+}
+
+// demote : the lease was lost, unless it has been acquired again meanwhile
+func (e *LeaseElector) demote(token int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.token == token {
+		logger.Info("lost leadership with fencing token %d", token)
+
+		e.token = 0
+	}
+}
+
+func (e *LeaseElector) isLeader() bool {
+	return e.token != 0 && e.Clock.Now().Before(e.validUntil)
+}
+
+func (e *LeaseElector) ttl() time.Duration {
+	if e.TTL > 0 {
+		return e.TTL
+	}
+
+	return DefaultTTL
+}
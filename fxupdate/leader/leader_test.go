@@ -0,0 +1,172 @@
+package leader_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/logger/zap"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+
+	"github.com/lruggieri/fxnow/fxrate/leader"
+	mockleader "github.com/lruggieri/fxnow/fxrate/mock/leader"
+)
+
+func TestLeaseElector(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	testErr := errors.New("error")
+	now := time.Date(2023, time.October, 20, 10, 0, 0, 0, time.UTC)
+
+	// signal : notifies the first campaign
+	signal := func(campaigned chan<- struct{}) func(context.Context, time.Duration) {
+		return func(context.Context, time.Duration) {
+			select {
+			case campaigned <- struct{}{}:
+			default:
+			}
+		}
+	}
+
+	tests := []struct {
+		name string
+		mock func(lease *mockleader.Lease, campaigned chan<- struct{})
+		// leader : whether the first campaign is won
+		leader    bool
+		assertion func(t *testing.T, e *leader.LeaseElector, lease *mockleader.Lease)
+	}{
+		{
+			name: "follower",
+			mock: func(lease *mockleader.Lease, campaigned chan<- struct{}) {
+				lease.EXPECT().Acquire(mock.Anything, 30*time.Second).Run(signal(campaigned)).Return(0, nil)
+			},
+			assertion: func(t *testing.T, e *leader.LeaseElector, lease *mockleader.Lease) {
+				assert.False(t, e.IsLeader())
+				_, err := e.Fence(context.Background())
+				assert.ErrorIs(t, err, leader.ErrNotLeader)
+				assert.Equal(t, leader.Status{Role: "follower", Holder: "replica-1"}, e.Status())
+			},
+		},
+		{
+			name: "error-campaign",
+			mock: func(lease *mockleader.Lease, campaigned chan<- struct{}) {
+				lease.EXPECT().Acquire(mock.Anything, 30*time.Second).Run(signal(campaigned)).Return(0, testErr)
+			},
+			assertion: func(t *testing.T, e *leader.LeaseElector, lease *mockleader.Lease) {
+				assert.False(t, e.IsLeader())
+			},
+		},
+		{
+			name:   "leader",
+			leader: true,
+			mock: func(lease *mockleader.Lease, campaigned chan<- struct{}) {
+				lease.EXPECT().Acquire(mock.Anything, 30*time.Second).Return(7, nil)
+				lease.EXPECT().Check(mock.Anything, int64(7)).Return(true, nil).Once()
+				// leadership is handed over on shutdown
+				lease.EXPECT().Release(mock.Anything, int64(7)).Return(nil).Once()
+			},
+			assertion: func(t *testing.T, e *leader.LeaseElector, lease *mockleader.Lease) {
+				assert.True(t, e.IsLeader())
+				token, err := e.Fence(context.Background())
+				assert.Nil(t, err)
+				assert.Equal(t, int64(7), token)
+				assert.Equal(t, leader.Status{Role: "leader", Holder: "replica-1", Token: 7, Since: &now}, e.Status())
+			},
+		},
+		{
+			name:   "leadership-lost",
+			leader: true,
+			mock: func(lease *mockleader.Lease, campaigned chan<- struct{}) {
+				lease.EXPECT().Acquire(mock.Anything, 30*time.Second).Return(7, nil)
+				// taken over by another replica, e.g. after a long pause
+				lease.EXPECT().Check(mock.Anything, int64(7)).Return(false, nil).Once()
+			},
+			assertion: func(t *testing.T, e *leader.LeaseElector, lease *mockleader.Lease) {
+				_, err := e.Fence(context.Background())
+				assert.ErrorIs(t, err, leader.ErrNotLeader)
+				assert.False(t, e.IsLeader())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			lease := mockleader.NewLease(t)
+			clk := mockclock.NewClock(t)
+			clk.EXPECT().Now().Return(now)
+
+			e := &leader.LeaseElector{
+				Lease:  lease,
+				Clock:  clk,
+				Holder: "replica-1",
+				TTL:    30 * time.Second,
+			}
+
+			campaigned := make(chan struct{}, 1)
+			tc.mock(lease, campaigned)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+
+			go func() {
+				e.Run(ctx)
+				close(done)
+			}()
+
+			if tc.leader {
+				assert.Eventually(t, e.IsLeader, time.Second, time.Millisecond)
+			} else {
+				<-campaigned
+			}
+
+			tc.assertion(t, e, lease)
+
+			cancel()
+			<-done
+		})
+	}
+}
+
+func TestLeaseElector_expiration(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	now := time.Date(2023, time.October, 20, 10, 0, 0, 0, time.UTC)
+
+	lease := mockleader.NewLease(t)
+	clk := mockclock.NewClock(t)
+	clk.EXPECT().Now().RunAndReturn(func() time.Time {
+		return now
+	})
+
+	e := &leader.LeaseElector{
+		Lease: lease,
+		Clock: clk,
+		TTL:   30 * time.Second,
+	}
+
+	lease.EXPECT().Acquire(mock.Anything, 30*time.Second).Return(7, nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		e.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, e.IsLeader, time.Second, time.Millisecond)
+
+	// without renewals, the lease is considered lost before it expires in Redis
+	now = now.Add(27 * time.Second)
+
+	assert.False(t, e.IsLeader())
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/lruggieri/fxnow/common/cache"
+	"github.com/lruggieri/fxnow/common/fxsource"
+	"github.com/lruggieri/fxnow/common/logger"
+)
+
+const (
+	day = 24 * time.Hour
+)
+
+// backfillHistory : stores the daily rates of the pairs of the highest priority group, for the days not backfilled
+// yet. Only sources providing time series are supported. It is a no-op once the previous day has been backfilled.
+func (i *Impl) backfillHistory(ctx context.Context, now time.Time) {
+	if i.BackfillDays <= 0 || i.schedule == nil || len(i.schedule.Groups) == 0 || !i.isLeader() {
+		return
+	}
+
+	source, ok := i.FXSource.(fxsource.TimeSeriesSource)
+	if !ok {
+		return
+	}
+
+	// the rates of a day are final once it is over
+	end := now.UTC().Truncate(day).Add(-day)
+	if !i.backfilledUntil.IsZero() && !end.After(i.backfilledUntil) {
+		return
+	}
+
+	start := end.Add(-time.Duration(i.BackfillDays-1) * day)
+	if !i.backfilledUntil.IsZero() && i.backfilledUntil.Add(day).After(start) {
+		start = i.backfilledUntil.Add(day)
+	}
+
+	defer i.trackCalls(ctx)()
+
+	currencies := i.schedule.Groups[0].Currencies
+	completed := true
+
+	for _, from := range currencies {
+		to := make([]string, 0, len(currencies)-1)
+
+		for _, currency := range currencies {
+			if currency != from {
+				to = append(to, currency)
+			}
+		}
+
+		if len(to) == 0 {
+			continue
+		}
+
+		if err := i.backfillCurrency(ctx, source, from, to, start, end); err != nil {
+			logger.WithError(err).WithField("currency", from).Error("cannot backfill history")
+
+			completed = false
+		}
+	}
+
+	// failed currencies are retried at the next backfill
+	if completed {
+		i.backfilledUntil = end
+	}
+}
+
+func (i *Impl) backfillCurrency(
+	ctx context.Context, source fxsource.TimeSeriesSource, from string, to []string, start, end time.Time,
+) error {
+	res, err := source.FetchTimeSeries(ctx, fxsource.FetchTimeSeriesRequest{
+		From:  from,
+		To:    to,
+		Start: start,
+		End:   end,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, rate := range res.Rates {
+		rateDay := time.Unix(rate.Timestamp, 0)
+
+		if err = i.Cache.Set(ctx, cache.GenerateCacheKeyRateHistory(rate.From, rate.To, rateDay), cache.CachedRate{
+			Rate:      rate.Rate,
+			Bid:       rate.Bid,
+			Ask:       rate.Ask,
+			Timestamp: rate.Timestamp,
+		}, cache.HistoryLifetime); err != nil {
+			return err
+		}
+	}
+
+	logger.WithFields(logger.Fields{
+		"pairs": len(to),
+		"start": start.Format(time.DateOnly),
+		"end":   end.Format(time.DateOnly),
+	}).Info("backfilled history of %s", from)
+
+	return nil
+}
@@ -2,65 +2,346 @@ package logic
 
 import (
 	"context"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/lruggieri/fxnow/common/cache"
 	"github.com/lruggieri/fxnow/common/fxsource"
 	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/util"
+
+	"github.com/lruggieri/fxnow/fxrate/budget"
+	"github.com/lruggieri/fxnow/fxrate/dispatcher"
+	"github.com/lruggieri/fxnow/fxrate/leader"
+	"github.com/lruggieri/fxnow/fxrate/notifier"
+	"github.com/lruggieri/fxnow/fxrate/schedule"
+	"github.com/lruggieri/fxnow/fxrate/validation"
 )
 
 const (
-	UpdateTicker = 20 * time.Second
+	// SchedulerTick : how often groups are checked for a refresh
+	SchedulerTick = time.Second
+	// ScheduleReloadInterval : how often the schedule is reloaded, so that it can change without a restart
+	ScheduleReloadInterval = 30 * time.Second
 )
 
 type Logic interface {
 	StartFXUpdate(ctx context.Context)
+	// LastUpdate : when a group of currencies was last updated without errors by this replica, zero if never
+	LastUpdate() time.Time
 }
 
 type Impl struct {
 	Cache    cache.Cache
 	FXSource fxsource.FXSource
+	// Dispatcher : optional, notifies the webhooks whose condition is met by the updated rates
+	Dispatcher dispatcher.Dispatcher
+	// Monitor : optional, alerts operators when updates keep failing
+	Monitor notifier.Monitor
+	// Schedule : optional, defaults to schedule.DefaultConfig
+	Schedule schedule.Source
+	// Budget : optional, slows down the refresh of the groups as the budget of calls to the FX source runs low
+	Budget budget.Budget
+	// Elector : optional, lets a single replica fetch the rates. Followers keep the schedule and the budget up to
+	// date, so that they can take over right away.
+	Elector leader.Elector
+	// Validator : optional, keeps the previous value of the rates that look anomalous
+	Validator validation.Validator
+	// BackfillDays : optional, days of daily rates of the highest priority group kept in the cache, for sources
+	// providing time series
+	BackfillDays int
+
+	scheduler schedule.Scheduler
+	// schedule : last applied configuration
+	schedule *schedule.Config
+	// backfilledUntil : last day whose rates have been backfilled
+	backfilledUntil time.Time
+
+	// mu : guards lastUpdate, read by the health checks
+	mu         sync.Mutex
+	lastUpdate time.Time
 }
 
 func (i *Impl) StartFXUpdate(ctx context.Context) {
-	ticker := time.NewTicker(UpdateTicker)
+	ticker := time.NewTicker(SchedulerTick)
+	defer ticker.Stop()
 
-	logger.Info("starting FX update loop with %s tick loops", UpdateTicker.String())
+	reloadTicker := time.NewTicker(ScheduleReloadInterval)
+	defer reloadTicker.Stop()
 
-	if err := i.fxUpdate(ctx); err != nil {
-		// TODO better management with notifications
-		logger.WithError(err).Error("fx update error")
+	logger.Info("starting FX update loop with %s tick loops", SchedulerTick.String())
+
+	if i.Budget != nil {
+		i.scheduler.Throttle = func(priority int) float64 {
+			return i.Budget.Status().Factor(priority)
+		}
+
+		i.refreshBudget(ctx)
 	}
 
+	i.reloadSchedule(ctx, time.Now())
+	i.updateDueGroups(ctx, time.Now())
+	i.backfillHistory(ctx, time.Now())
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-reloadTicker.C:
+			i.reloadSchedule(ctx, time.Now())
+			i.refreshBudget(ctx)
+			i.backfillHistory(ctx, time.Now())
 		case <-ticker.C:
-			if err := i.fxUpdate(ctx); err != nil {
-				// TODO better management with notifications
-				logger.WithError(err).Error("fx update error")
-			}
+			i.updateDueGroups(ctx, time.Now())
+		}
+	}
+}
+
+// reloadSchedule : keeps the previous schedule if the new one cannot be loaded, or the default one at startup
+func (i *Impl) reloadSchedule(ctx context.Context, now time.Time) {
+	source := i.Schedule
+	if source == nil {
+		source = &schedule.Static{Config: schedule.DefaultConfig()}
+	}
+
+	config, err := source.Load(ctx)
+	if err != nil {
+		if i.schedule != nil {
+			logger.WithError(err).Error("cannot reload schedule, keeping the previous one")
+
+			return
+		}
+
+		logger.WithError(err).Error("cannot load schedule, using the default one")
+
+		config = schedule.DefaultConfig()
+	}
+
+	if reflect.DeepEqual(config, i.schedule) {
+		return
+	}
+
+	for _, g := range config.Groups {
+		logger.WithFields(logger.Fields{
+			"currencies": g.Currencies,
+			"interval":   g.Interval.String(),
+			"jitter":     g.Jitter.String(),
+		}).Info("scheduling group %s", g.Name)
+	}
+
+	i.scheduler.Apply(config, now)
+	i.schedule = config
+}
+
+// refreshBudget : other replicas may have called the source too
+func (i *Impl) refreshBudget(ctx context.Context) {
+	if i.Budget == nil {
+		return
+	}
+
+	if err := i.Budget.Refresh(ctx); err != nil {
+		logger.WithError(err).Error("cannot refresh FX source budget")
+	}
+}
+
+// trackCalls : the returned function records the calls made to the FX source since trackCalls was called
+func (i *Impl) trackCalls(ctx context.Context) func() {
+	if i.Budget == nil {
+		return func() {}
+	}
+
+	callsBefore := i.FXSource.Calls()
+
+	return func() {
+		if err := i.Budget.Record(ctx, i.FXSource.Calls()-callsBefore); err != nil {
+			logger.WithError(err).Error("cannot record FX source calls")
+		}
+	}
+}
+
+// updateDueGroups : followers skip the due groups, so that they resume on schedule once leading
+func (i *Impl) updateDueGroups(ctx context.Context, now time.Time) {
+	for _, g := range i.scheduler.Due(now) {
+		if !i.isLeader() {
+			continue
+		}
+
+		err := i.fxUpdate(ctx, g.Currencies)
+		if err == nil {
+			i.mu.Lock()
+			i.lastUpdate = now
+			i.mu.Unlock()
 		}
+
+		i.report(ctx, g.Name, err)
+	}
+}
+
+func (i *Impl) LastUpdate() time.Time {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.lastUpdate
+}
+
+func (i *Impl) isLeader() bool {
+	return i.Elector == nil || i.Elector.IsLeader()
+}
+
+// report : logs failed updates, and lets the monitor alert operators when needed. Updates interrupted by the loss
+// of the leadership are not failures.
+func (i *Impl) report(ctx context.Context, group string, err error) {
+	if errors.Is(err, leader.ErrNotLeader) {
+		logger.Info("fx update discarded, leadership lost")
+
+		return
+	}
+
+	if err != nil {
+		logger.WithError(err).Error("fx update error")
+	}
+
+	if i.Monitor == nil {
+		return
+	}
+
+	if err != nil {
+		i.Monitor.Failed(ctx, group, err)
+	} else {
+		i.Monitor.Succeeded(ctx, group)
 	}
 }
 
-func (i *Impl) fxUpdate(ctx context.Context) error {
+// fxUpdate : refreshes the rates from the base currencies. The rates of the currencies that could be fetched are
+// updated even if others failed, which are then reported as an error.
+func (i *Impl) fxUpdate(ctx context.Context, currencies []string) error {
+	defer i.trackCalls(ctx)()
+
 	rates, err := i.FXSource.FetchAllRates(ctx, fxsource.FetchAllRatesRequest{
-		Limit: []string{"USD", "GBP", "EUR", "JPY", "CHF", "CAD", "AUD"},
+		Limit: currencies,
 	})
 	if err != nil {
 		return err
 	}
 
-	for _, rate := range rates.Rates {
-		if err = i.Cache.Set(ctx, cache.GenerateCacheKeyRate(rate.From, rate.To), cache.CachedRate{
+	// a new leader may have been elected while fetching
+	var token int64
+	if i.Elector != nil {
+		if token, err = i.Elector.Fence(ctx); err != nil {
+			return err
+		}
+	}
+
+	valid := i.validate(ctx, rates.Rates, token)
+
+	for _, rate := range valid {
+		if err = i.setRate(ctx, rate.From, rate.To, cache.CachedRate{
 			Rate:      rate.Rate,
+			Bid:       rate.Bid,
+			Ask:       rate.Ask,
 			Timestamp: rate.Timestamp,
-		}, cache.MaxCacheLifetime); err != nil {
+		}, token); err != nil {
 			return err
 		}
 	}
 
+	if i.Dispatcher != nil {
+		i.Dispatcher.Dispatch(ctx, valid)
+	}
+
+	if len(rates.Errors) > 0 {
+		failed := make([]string, 0, len(rates.Errors))
+		for _, currencyErr := range rates.Errors {
+			failed = append(failed, currencyErr.Error())
+		}
+
+		return errors.Errorf("cannot fetch %d base currencies: %s", len(rates.Errors), strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+// validate : rejected rates are not cached, so that the previous value is kept. Pairs unknown to the validator (e.g.
+// after a restart or a change of leader) are checked against their cached rate.
+func (i *Impl) validate(ctx context.Context, rates []fxsource.Rate, token int64) []fxsource.Rate {
+	if i.Validator == nil {
+		return rates
+	}
+
+	for _, rate := range rates {
+		if i.Validator.Known(rate.From, rate.To) {
+			continue
+		}
+
+		if cached, ok := i.cachedRate(ctx, rate.From, rate.To); ok {
+			i.Validator.Seed(rate.From, rate.To, cached.Rate)
+		}
+	}
+
+	valid, rejections := i.Validator.Validate(rates)
+
+	for _, rejection := range rejections {
+		logger.WithFields(logger.Fields{
+			"pair":   util.PairFromCurrencies(rejection.Rate.From, rejection.Rate.To),
+			"rate":   rejection.Rate.Rate,
+			"reason": rejection.Reason.String(),
+		}).Error("rejected rate: %s", rejection.Detail)
+
+		i.keepRate(ctx, rejection.Rate.From, rejection.Rate.To, token)
+	}
+
+	return valid
+}
+
+// keepRate : extends the lifetime of the cached rate of a pair, so that its last good rate is still served while
+// the new ones are rejected. The timestamp of the rate is left unchanged.
+func (i *Impl) keepRate(ctx context.Context, from, to string, token int64) {
+	cached, ok := i.cachedRate(ctx, from, to)
+	if !ok {
+		return
+	}
+
+	if err := i.setRate(ctx, from, to, cached, token); err != nil {
+		logger.WithError(err).Error("cannot keep the previous rate of %s", util.PairFromCurrencies(from, to))
+	}
+}
+
+// setRate : with leader election, the rate is written only if the fencing token is not lower than the one of the
+// cached rate, as the lease may have been lost since it was fenced. Returns ErrNotLeader if a newer leader wrote it.
+func (i *Impl) setRate(ctx context.Context, from, to string, rate cache.CachedRate, token int64) error {
+	key := cache.GenerateCacheKeyRate(from, to)
+
+	if i.Elector == nil {
+		return i.Cache.Set(ctx, key, rate, cache.MaxCacheLifetime)
+	}
+
+	rate.FencingToken = token
+
+	set, err := i.Cache.SetFenced(ctx, key, rate, token, cache.MaxCacheLifetime)
+	if err != nil {
+		return err
+	}
+
+	if !set {
+		return errors.Wrapf(leader.ErrNotLeader, "rate of %s written by a newer leader", util.PairFromCurrencies(from, to))
+	}
+
 	return nil
 }
+
+func (i *Impl) cachedRate(ctx context.Context, from, to string) (cache.CachedRate, bool) {
+	var cached cache.CachedRate
+
+	ok, err := i.Cache.Get(ctx, cache.GenerateCacheKeyRate(from, to), &cached)
+	if err != nil {
+		logger.WithError(err).Error("cannot get the cached rate of %s", util.PairFromCurrencies(from, to))
+
+		return cached, false
+	}
+
+	return cached, ok
+}
@@ -3,15 +3,29 @@ package logic
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/lruggieri/fxnow/common/cache"
 	"github.com/lruggieri/fxnow/common/fxsource"
+	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/logger/zap"
 	mockcache "github.com/lruggieri/fxnow/common/mock/cache"
 	mockfxsource "github.com/lruggieri/fxnow/common/mock/fxsource"
+
+	"github.com/lruggieri/fxnow/fxrate/leader"
+	mockbudget "github.com/lruggieri/fxnow/fxrate/mock/budget"
+	mockdispatcher "github.com/lruggieri/fxnow/fxrate/mock/dispatcher"
+	mockleader "github.com/lruggieri/fxnow/fxrate/mock/leader"
+	mocknotifier "github.com/lruggieri/fxnow/fxrate/mock/notifier"
+	mockschedule "github.com/lruggieri/fxnow/fxrate/mock/schedule"
+	mockvalidation "github.com/lruggieri/fxnow/fxrate/mock/validation"
+	"github.com/lruggieri/fxnow/fxrate/schedule"
+	"github.com/lruggieri/fxnow/fxrate/validation"
 )
 
 func TestImpl_fxUpdate(t *testing.T) {
@@ -19,8 +33,9 @@ func TestImpl_fxUpdate(t *testing.T) {
 	now := time.Now()
 
 	type deps struct {
-		cache    *mockcache.Cache
-		fxSource *mockfxsource.FXSource
+		cache      *mockcache.Cache
+		fxSource   *mockfxsource.FXSource
+		dispatcher *mockdispatcher.Dispatcher
 	}
 
 	type args struct {
@@ -113,6 +128,8 @@ func TestImpl_fxUpdate(t *testing.T) {
 							From:      "USD",
 							To:        "JPY",
 							Rate:      42.42,
+							Bid:       42.41,
+							Ask:       42.43,
 							Timestamp: now.Unix(),
 						},
 						{
@@ -129,6 +146,8 @@ func TestImpl_fxUpdate(t *testing.T) {
 					cache.GenerateCacheKeyRate("USD", "JPY"),
 					cache.CachedRate{
 						Rate:      42.42,
+						Bid:       42.41,
+						Ask:       42.43,
 						Timestamp: now.Unix(),
 					},
 					cache.MaxCacheLifetime,
@@ -143,31 +162,413 @@ func TestImpl_fxUpdate(t *testing.T) {
 					},
 					cache.MaxCacheLifetime,
 				).Return(nil).Once()
+
+				d.dispatcher.EXPECT().Dispatch(args.ctx, []fxsource.Rate{
+					{
+						From:      "USD",
+						To:        "JPY",
+						Rate:      42.42,
+						Bid:       42.41,
+						Ask:       42.43,
+						Timestamp: now.Unix(),
+					},
+					{
+						From:      "EUR",
+						To:        "CAD",
+						Rate:      42.43,
+						Timestamp: now.Unix(),
+					},
+				}).Return().Once()
 			},
 			assertion: func(t *testing.T, err error) {
 				assert.Nil(t, err)
 			},
 		},
+		{
+			name: "error-partial-results",
+			args: args{
+				ctx: context.Background(),
+			},
+			mock: func(args args, d deps) {
+				rates := []fxsource.Rate{
+					{
+						From:      "USD",
+						To:        "JPY",
+						Rate:      42.42,
+						Timestamp: now.Unix(),
+					},
+				}
+
+				d.fxSource.EXPECT().FetchAllRates(
+					args.ctx,
+					fxsource.FetchAllRatesRequest{
+						Limit: []string{"USD", "GBP", "EUR", "JPY", "CHF", "CAD", "AUD"},
+					},
+				).Return(&fxsource.FetchAllRatesResponse{
+					Rates:  rates,
+					Errors: []fxsource.CurrencyError{{Currency: "GBP", Err: testErr}},
+				}, nil).Once()
+
+				d.cache.EXPECT().Set(
+					args.ctx,
+					cache.GenerateCacheKeyRate("USD", "JPY"),
+					cache.CachedRate{
+						Rate:      42.42,
+						Timestamp: now.Unix(),
+					},
+					cache.MaxCacheLifetime,
+				).Return(nil).Once()
+
+				d.dispatcher.EXPECT().Dispatch(args.ctx, rates).Return().Once()
+			},
+			assertion: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "cannot fetch 1 base currencies: GBP: error")
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		tc := tt // avoid loop closure issue
 		t.Run(tc.name, func(t *testing.T) {
 			d := deps{
-				cache:    mockcache.NewCache(t),
-				fxSource: mockfxsource.NewFXSource(t),
+				cache:      mockcache.NewCache(t),
+				fxSource:   mockfxsource.NewFXSource(t),
+				dispatcher: mockdispatcher.NewDispatcher(t),
 			}
 
 			l := Impl{
-				Cache:    d.cache,
-				FXSource: d.fxSource,
+				Cache:      d.cache,
+				FXSource:   d.fxSource,
+				Dispatcher: d.dispatcher,
 			}
 
 			tc.mock(tc.args, d)
 
-			err := l.fxUpdate(tc.args.ctx)
+			err := l.fxUpdate(tc.args.ctx, []string{"USD", "GBP", "EUR", "JPY", "CHF", "CAD", "AUD"})
 
 			tc.assertion(t, err)
 		})
 	}
 }
+
+func TestImpl_report(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	testErr := errors.New("error")
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		err  error
+		mock func(m *mocknotifier.Monitor)
+	}{
+		{
+			name: "failed",
+			err:  testErr,
+			mock: func(m *mocknotifier.Monitor) {
+				m.EXPECT().Failed(ctx, "majors", testErr).Return().Once()
+			},
+		},
+		{
+			name: "succeeded",
+			mock: func(m *mocknotifier.Monitor) {
+				m.EXPECT().Succeeded(ctx, "majors").Return().Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			m := mocknotifier.NewMonitor(t)
+
+			l := Impl{
+				Monitor: m,
+			}
+
+			tc.mock(m)
+
+			l.report(ctx, "majors", tc.err)
+		})
+	}
+}
+
+func TestImpl_reloadSchedule(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	testErr := errors.New("error")
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+
+	config := &schedule.Config{
+		Groups: []schedule.Group{
+			{Name: "exotics", Currencies: []string{"TRY"}, Interval: 5 * time.Minute},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		previous *schedule.Config
+		mock     func(s *mockschedule.Source)
+		expected *schedule.Config
+	}{
+		{
+			name: "error-load-at-startup",
+			mock: func(s *mockschedule.Source) {
+				s.EXPECT().Load(ctx).Return(nil, testErr).Once()
+			},
+			expected: schedule.DefaultConfig(),
+		},
+		{
+			name:     "error-reload",
+			previous: config,
+			mock: func(s *mockschedule.Source) {
+				s.EXPECT().Load(ctx).Return(nil, testErr).Once()
+			},
+			expected: config,
+		},
+		{
+			name:     "happy-path",
+			previous: schedule.DefaultConfig(),
+			mock: func(s *mockschedule.Source) {
+				s.EXPECT().Load(ctx).Return(config, nil).Once()
+			},
+			expected: config,
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockschedule.NewSource(t)
+
+			l := Impl{
+				Schedule: s,
+			}
+
+			if tc.previous != nil {
+				l.scheduler.Apply(tc.previous, now)
+				l.schedule = tc.previous
+			}
+
+			tc.mock(s)
+
+			l.reloadSchedule(ctx, now)
+
+			assert.Equal(t, tc.expected, l.schedule)
+			assert.Len(t, l.scheduler.Due(now), len(tc.expected.Groups))
+		})
+	}
+}
+
+func TestImpl_fxUpdate_budget(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	testErr := errors.New("error")
+	ctx := context.Background()
+
+	fxSource := mockfxsource.NewFXSource(t)
+	b := mockbudget.NewBudget(t)
+
+	// failed calls are recorded too, as some of them may have been answered
+	fxSource.EXPECT().Calls().Return(10).Once()
+	fxSource.EXPECT().FetchAllRates(ctx, fxsource.FetchAllRatesRequest{Limit: []string{"USD"}}).
+		Return(nil, testErr).Once()
+	fxSource.EXPECT().Calls().Return(17).Once()
+	b.EXPECT().Record(ctx, int64(7)).Return(testErr).Once()
+
+	l := Impl{
+		FXSource: fxSource,
+		Budget:   b,
+	}
+
+	assert.ErrorIs(t, l.fxUpdate(ctx, []string{"USD"}), testErr)
+}
+
+func TestImpl_fxUpdate_validation(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	ctx := context.Background()
+	now := time.Now()
+
+	c := mockcache.NewCache(t)
+	fxSource := mockfxsource.NewFXSource(t)
+	d := mockdispatcher.NewDispatcher(t)
+	v := mockvalidation.NewValidator(t)
+
+	valid := fxsource.Rate{From: "USD", To: "JPY", Rate: 149.8, Timestamp: now.Unix()}
+	spike := fxsource.Rate{From: "EUR", To: "CAD", Rate: 144.6, Timestamp: now.Unix()}
+
+	fxSource.EXPECT().FetchAllRates(ctx, fxsource.FetchAllRatesRequest{Limit: []string{"USD", "EUR"}}).
+		Return(&fxsource.FetchAllRatesResponse{Rates: []fxsource.Rate{valid, spike}}, nil).Once()
+	previous := cache.CachedRate{Rate: 1.446, Timestamp: now.Add(-time.Minute).Unix()}
+
+	// the validator has just started, so the previous rate of the pair is read from the cache
+	v.EXPECT().Known("USD", "JPY").Return(true).Once()
+	v.EXPECT().Known("EUR", "CAD").Return(false).Once()
+	c.EXPECT().Get(ctx, cache.GenerateCacheKeyRate("EUR", "CAD"), mock.AnythingOfType("*cache.CachedRate")).
+		RunAndReturn(func(ctx context.Context, s string, i interface{}) (bool, error) {
+			reflect.ValueOf(i).Elem().Set(reflect.ValueOf(previous))
+			return true, nil
+		}).Twice()
+	v.EXPECT().Seed("EUR", "CAD", 1.446).Return().Once()
+	v.EXPECT().Validate([]fxsource.Rate{valid, spike}).Return([]fxsource.Rate{valid}, []validation.Rejection{
+		{Rate: spike, Reason: validation.ReasonJump, Detail: "9900.00% change from 1.446"},
+	}).Once()
+
+	// the rejected rate keeps its previous value, which is still served, and is not dispatched
+	c.EXPECT().Set(ctx, cache.GenerateCacheKeyRate("EUR", "CAD"), previous, cache.MaxCacheLifetime).Return(nil).Once()
+	c.EXPECT().Set(ctx, cache.GenerateCacheKeyRate("USD", "JPY"), cache.CachedRate{
+		Rate:      149.8,
+		Timestamp: now.Unix(),
+	}, cache.MaxCacheLifetime).Return(nil).Once()
+	d.EXPECT().Dispatch(ctx, []fxsource.Rate{valid}).Return().Once()
+
+	l := Impl{
+		Cache:      c,
+		FXSource:   fxSource,
+		Dispatcher: d,
+		Validator:  v,
+	}
+
+	assert.Nil(t, l.fxUpdate(ctx, []string{"USD", "EUR"}))
+}
+
+func TestImpl_leaderElection(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	ctx := context.Background()
+	now := time.Now()
+
+	c := mockcache.NewCache(t)
+	fxSource := mockfxsource.NewFXSource(t)
+	elector := mockleader.NewElector(t)
+	m := mocknotifier.NewMonitor(t)
+
+	l := Impl{
+		Cache:    c,
+		FXSource: fxSource,
+		Elector:  elector,
+		Monitor:  m,
+	}
+
+	l.scheduler.Apply(&schedule.Config{
+		Groups: []schedule.Group{{Name: "majors", Currencies: []string{"USD"}, Interval: time.Minute}},
+	}, now)
+
+	// followers do not fetch, but keep the schedule going
+	elector.EXPECT().IsLeader().Return(false).Once()
+
+	l.updateDueGroups(ctx, now)
+	assert.Empty(t, l.scheduler.Due(now))
+
+	// rates fetched by a leader that lost the lease meanwhile are discarded, and not reported as a failure
+	elector.EXPECT().IsLeader().Return(true).Once()
+	fxSource.EXPECT().FetchAllRates(ctx, fxsource.FetchAllRatesRequest{Limit: []string{"USD"}}).
+		Return(&fxsource.FetchAllRatesResponse{
+			Rates: []fxsource.Rate{{From: "USD", To: "JPY", Rate: 149.8, Timestamp: now.Unix()}},
+		}, nil).Once()
+	elector.EXPECT().Fence(ctx).Return(0, leader.ErrNotLeader).Once()
+
+	l.updateDueGroups(ctx, now.Add(2*time.Minute))
+	assert.True(t, l.LastUpdate().IsZero())
+
+	// successful updates are tracked for the readiness
+	elector.EXPECT().IsLeader().Return(true).Once()
+	fxSource.EXPECT().FetchAllRates(ctx, fxsource.FetchAllRatesRequest{Limit: []string{"USD"}}).
+		Return(&fxsource.FetchAllRatesResponse{
+			Rates: []fxsource.Rate{{From: "USD", To: "JPY", Rate: 149.8, Timestamp: now.Unix()}},
+		}, nil).Once()
+	elector.EXPECT().Fence(ctx).Return(7, nil).Once()
+	c.EXPECT().SetFenced(ctx, "rate_usd_jpy", cache.CachedRate{Rate: 149.8, Timestamp: now.Unix(), FencingToken: 7},
+		int64(7), cache.MaxCacheLifetime).Return(true, nil).Once()
+	m.EXPECT().Succeeded(ctx, "majors").Once()
+
+	l.updateDueGroups(ctx, now.Add(4*time.Minute))
+	assert.Equal(t, now.Add(4*time.Minute), l.LastUpdate())
+
+	// the lease is taken over between the fencing and the write, the rates of the new leader are kept
+	elector.EXPECT().IsLeader().Return(true).Once()
+	fxSource.EXPECT().FetchAllRates(ctx, fxsource.FetchAllRatesRequest{Limit: []string{"USD"}}).
+		Return(&fxsource.FetchAllRatesResponse{
+			Rates: []fxsource.Rate{{From: "USD", To: "JPY", Rate: 149.9, Timestamp: now.Unix()}},
+		}, nil).Once()
+	elector.EXPECT().Fence(ctx).Return(7, nil).Once()
+	c.EXPECT().SetFenced(ctx, "rate_usd_jpy", cache.CachedRate{Rate: 149.9, Timestamp: now.Unix(), FencingToken: 7},
+		int64(7), cache.MaxCacheLifetime).Return(false, nil).Once()
+
+	l.updateDueGroups(ctx, now.Add(6*time.Minute))
+	assert.Equal(t, now.Add(4*time.Minute), l.LastUpdate())
+}
+
+// timeSeriesSource : FX source providing time series
+type timeSeriesSource struct {
+	*mockfxsource.FXSource
+	*mockfxsource.TimeSeriesSource
+}
+
+func TestImpl_backfillHistory(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	testErr := errors.New("error")
+	ctx := context.Background()
+	now := time.Date(2023, time.October, 5, 10, 0, 0, 0, time.UTC)
+	day1 := time.Date(2023, time.October, 4, 0, 0, 0, 0, time.UTC)
+
+	c := mockcache.NewCache(t)
+	source := timeSeriesSource{
+		FXSource:         mockfxsource.NewFXSource(t),
+		TimeSeriesSource: mockfxsource.NewTimeSeriesSource(t),
+	}
+
+	l := Impl{
+		Cache:        c,
+		FXSource:     source,
+		BackfillDays: 3,
+		schedule: &schedule.Config{
+			Groups: []schedule.Group{
+				{Name: "majors", Currencies: []string{"USD", "EUR"}, Interval: time.Minute},
+				{Name: "exotics", Currencies: []string{"TRY"}, Interval: time.Hour},
+			},
+		},
+	}
+
+	// the first backfill covers the configured days, up to the previous one
+	source.TimeSeriesSource.EXPECT().FetchTimeSeries(ctx, fxsource.FetchTimeSeriesRequest{
+		From:  "USD",
+		To:    []string{"EUR"},
+		Start: day1.AddDate(0, 0, -2),
+		End:   day1,
+	}).Return(&fxsource.FetchTimeSeriesResponse{
+		Rates: []fxsource.Rate{{From: "USD", To: "EUR", Rate: 0.95, Timestamp: day1.Unix()}},
+	}, nil).Once()
+	c.EXPECT().Set(ctx, cache.GenerateCacheKeyRateHistory("USD", "EUR", day1),
+		cache.CachedRate{Rate: 0.95, Timestamp: day1.Unix()}, cache.HistoryLifetime).Return(nil).Once()
+	source.TimeSeriesSource.EXPECT().FetchTimeSeries(ctx, fxsource.FetchTimeSeriesRequest{
+		From:  "EUR",
+		To:    []string{"USD"},
+		Start: day1.AddDate(0, 0, -2),
+		End:   day1,
+	}).Return(nil, testErr).Once()
+
+	l.backfillHistory(ctx, now)
+	assert.True(t, l.backfilledUntil.IsZero())
+
+	// failed backfills are retried
+	source.TimeSeriesSource.EXPECT().FetchTimeSeries(ctx, mock.Anything).
+		Return(&fxsource.FetchTimeSeriesResponse{}, nil).Twice()
+
+	l.backfillHistory(ctx, now)
+	assert.Equal(t, day1, l.backfilledUntil)
+
+	// completed backfills are not repeated within the same day
+	l.backfillHistory(ctx, now.Add(time.Hour))
+
+	// the following day, only the new day is backfilled
+	source.TimeSeriesSource.EXPECT().FetchTimeSeries(ctx, mock.MatchedBy(func(req fxsource.FetchTimeSeriesRequest) bool {
+		return req.Start.Equal(day1.AddDate(0, 0, 1)) && req.End.Equal(day1.AddDate(0, 0, 1))
+	})).Return(&fxsource.FetchTimeSeriesResponse{}, nil).Twice()
+
+	l.backfillHistory(ctx, now.AddDate(0, 0, 1))
+	assert.Equal(t, day1.AddDate(0, 0, 1), l.backfilledUntil)
+}
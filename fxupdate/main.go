@@ -5,53 +5,371 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/lruggieri/fxnow/common/app"
 	"github.com/lruggieri/fxnow/common/cache/redis"
 	"github.com/lruggieri/fxnow/common/client/fastforex"
+	"github.com/lruggieri/fxnow/common/client/httpclient"
+	"github.com/lruggieri/fxnow/common/clock"
+	"github.com/lruggieri/fxnow/common/health"
 	cHttp "github.com/lruggieri/fxnow/common/http"
 	"github.com/lruggieri/fxnow/common/logger"
 	"github.com/lruggieri/fxnow/common/logger/zap"
+	"github.com/lruggieri/fxnow/common/store/mysql"
+	"github.com/lruggieri/fxnow/common/util"
 
+	"github.com/lruggieri/fxnow/fxrate/budget"
+	"github.com/lruggieri/fxnow/fxrate/dispatcher"
+	"github.com/lruggieri/fxnow/fxrate/leader"
 	"github.com/lruggieri/fxnow/fxrate/logic"
+	"github.com/lruggieri/fxnow/fxrate/notifier"
+	"github.com/lruggieri/fxnow/fxrate/schedule"
+	"github.com/lruggieri/fxnow/fxrate/validation"
 )
 
-var l logic.Logic
+var (
+	l logic.Logic
 
-func main() {
-	mainContext := context.Background()
+	// fxSourceClient : retries the calls to the FX source, and reports the state of its circuit breakers
+	fxSourceClient *httpclient.Resilient
+
+	// fxSourceBudget : optional, calls to the FX source left within the budget
+	fxSourceBudget budget.Budget
+
+	// rateValidator : counts the rates rejected as anomalous
+	rateValidator validation.Validator
+
+	// elector : whether this replica is the one fetching the rates
+	elector leader.Elector
+)
+
+// HealthResponse : the service is degraded while the FX source cannot be reached. Followers are healthy, as they are
+// ready to take over.
+type HealthResponse struct {
+	Status   string            `json:"status"`
+	Circuits map[string]string `json:"circuits"`
+	Budget   *budget.Status    `json:"budget,omitempty"`
+	Leader   leader.Status     `json:"leader"`
+	// Rejections : rates rejected as anomalous since startup, by reason
+	Rejections map[string]int64 `json:"rejections"`
+}
 
+func main() {
 	logger.InitLogger(zap.New(zap.Config{
 		Development: false,
 		Level:       logger.LevelDebug,
 	}))
 
+	application := app.New("fxupdate")
+
 	port := os.Getenv("PORT")
 
+	redisClient := redis.NewClient(redis.Config{
+		Addrs: []string{os.Getenv("REDIS_ADDRS")},
+	})
+
+	application.OnShutdown("redis", func(ctx context.Context) error {
+		return redisClient.Close()
+	})
+
 	cache := &redis.Cacher{
-		Client: redis.NewClient(redis.Config{
-			Addrs: []string{os.Getenv("REDIS_ADDRS")},
-		}),
+		Client: redisClient,
 	}
 
-	l = &logic.Impl{
-		Cache: cache,
-		FXSource: fastforex.NewClient(
-			os.Getenv("FASTFOREX_API_KEY"),
-			http.DefaultClient,
-		),
+	readiness := &health.Registry{Clock: clock.Default{}}
+	readiness.Register(health.Check{Name: "redis", Checker: health.CheckerFunc(cache.Ping)})
+
+	// calls are metered beneath the retries, as every attempt is charged
+	fxSourceMeter := &httpclient.Meter{Client: http.DefaultClient}
+
+	fxSourceClient = httpclient.NewResilient(fxSourceMeter, httpclient.ResilientConfig{
+		MaxAttempts:      intFromEnv("FX_SOURCE_MAX_ATTEMPTS", httpclient.DefaultMaxAttempts),
+		FailureThreshold: intFromEnv("FX_SOURCE_FAILURE_THRESHOLD", httpclient.DefaultFailureThreshold),
+		OpenTimeout:      durationFromEnv("FX_SOURCE_OPEN_TIMEOUT", httpclient.DefaultOpenTimeout),
+	}, clock.Default{})
+
+	fxSource := &fastforex.Client{
+		APIKey:         os.Getenv("FASTFOREX_API_KEY"),
+		HTTPClient:     fxSourceClient,
+		Meter:          fxSourceMeter,
+		PingClient:     http.DefaultClient,
+		MaxConcurrency: intFromEnv("FASTFOREX_MAX_CONCURRENCY", fastforex.DefaultMaxConcurrency),
+	}
+
+	readiness.Register(health.Check{
+		Name:     "fx-source",
+		Checker:  health.CheckerFunc(fxSource.Ping),
+		CacheTTL: time.Minute,
+	})
+
+	impl := &logic.Impl{
+		Cache:    cache,
+		FXSource: fxSource,
+		// days of daily rates of the first currency group to keep in the cache, 0 to disable
+		BackfillDays: intFromEnv("FX_BACKFILL_DAYS", 0),
 	}
 
-	// start service logic
-	go l.StartFXUpdate(mainContext)
+	// replicas elect the one fetching the rates, the others take over if its lease is not renewed
+	hostname, _ := os.Hostname()
+	holder := fmt.Sprintf("%s-%s", hostname, util.NewUUID())
+
+	elector = &leader.LeaseElector{
+		Lease: &redis.Lease{
+			Client: redisClient,
+			Name:   "fxupdate",
+			Holder: holder,
+		},
+		Clock:  clock.Default{},
+		Holder: holder,
+		TTL:    durationFromEnv("LEADER_LEASE_TTL", leader.DefaultTTL),
+	}
+	impl.Elector = elector
+
+	rateValidator = &validation.Impl{
+		MaxJump:  floatFromEnv("RATE_MAX_JUMP", validation.DefaultMaxJump),
+		MaxJumps: maxJumpsFromEnv("RATE_MAX_JUMPS"),
+	}
+	impl.Validator = rateValidator
+
+	// fastforex is metered: a budget of calls per day or month slows down the refresh as it runs low
+	if budgetLimit := intFromEnv("FX_SOURCE_BUDGET", 0); budgetLimit > 0 {
+		period := budget.PeriodFromString(os.Getenv("FX_SOURCE_BUDGET_PERIOD"))
+		if period == budget.PeriodUndefined {
+			period = budget.PeriodMonth
+		}
+
+		fxSourceBudget = &budget.Tracker{
+			Cache:  cache,
+			Clock:  clock.Default{},
+			Source: "fastforex",
+			Limit:  int64(budgetLimit),
+			Period: period,
+		}
+		impl.Budget = fxSourceBudget
+	}
+
+	// the schedule file is reloaded periodically, while the inline one requires a restart
+	if scheduleFile := os.Getenv("FXUPDATE_SCHEDULE_FILE"); scheduleFile != "" {
+		impl.Schedule = &schedule.File{Path: scheduleFile}
+	} else if inlineSchedule := os.Getenv("FXUPDATE_SCHEDULE"); inlineSchedule != "" {
+		config, err := schedule.Parse([]byte(inlineSchedule))
+		if err != nil {
+			panic(err)
+		}
+
+		impl.Schedule = &schedule.Static{Config: config}
+	}
+
+	// webhooks are only dispatched when the store they are registered on is configured
+	if os.Getenv("MYSQL_HOST") != "" {
+		mysqlPort, err := strconv.Atoi(os.Getenv("MYSQL_PORT"))
+		if err != nil {
+			panic(err)
+		}
+
+		str, err := mysql.New(mysql.Config{
+			Username: os.Getenv("MYSQL_USERNAME"),
+			Password: os.Getenv("MYSQL_PASSWORD"),
+			Host:     os.Getenv("MYSQL_HOST"),
+			Port:     mysqlPort,
+			DBName:   os.Getenv("MYSQL_DB_NAME"),
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		application.OnShutdown("mysql", func(ctx context.Context) error {
+			return str.Close()
+		})
+
+		readiness.Register(health.Check{Name: "mysql", Checker: health.CheckerFunc(str.Ping)})
+
+		webhookDispatcher := &dispatcher.Impl{
+			Store: str,
+			// webhook URLs are provided by users
+			HTTPClient: httpclient.NewPublic(),
+			Clock:      clock.Default{},
+		}
+
+		// pending deliveries record their outcome before the store is closed
+		application.OnShutdown("dispatcher", func(ctx context.Context) error {
+			webhookDispatcher.Wait()
+			return nil
+		})
+
+		impl.Dispatcher = webhookDispatcher
+	}
+
+	if n := notifierFromEnv(); n != nil {
+		impl.Monitor = &notifier.ThresholdMonitor{
+			Notifier:               n,
+			Clock:                  clock.Default{},
+			MaxConsecutiveFailures: intFromEnv("ALERT_MAX_CONSECUTIVE_FAILURES", notifier.DefaultMaxConsecutiveFailures),
+			MaxStaleness:           durationFromEnv("ALERT_MAX_STALENESS", notifier.DefaultMaxStaleness),
+			RepeatInterval:         durationFromEnv("ALERT_REPEAT_INTERVAL", notifier.DefaultRepeatInterval),
+			// followers do not update the rates
+			Active: elector.IsLeader,
+		}
+	}
+
+	l = impl
+
+	// the leader is not ready while the rates go stale, followers are as they do not update them
+	lastUpdate := &health.Freshness{
+		Clock:  clock.Default{},
+		Last:   l.LastUpdate,
+		MaxAge: durationFromEnv("READY_MAX_UPDATE_AGE", notifier.DefaultMaxStaleness),
+	}
+
+	readiness.Register(health.Check{Name: "last-update", Checker: health.CheckerFunc(func(ctx context.Context) error {
+		if !elector.IsLeader() {
+			return nil
+		}
+
+		return lastUpdate.Check(ctx)
+	})})
+
+	// start service logic, stopped on shutdown
+	application.Go("elector", elector.Run)
+	application.Go("fxupdate", l.StartFXUpdate)
+
+	if impl.Monitor != nil {
+		application.Go("monitor", impl.Monitor.Run)
+	}
 
 	r := gin.Default()
 	r.GET("/fxupdate/health", HandleHealth)
+	r.GET("/fxupdate/livez", readiness.HandleLive)
+	r.GET("/fxupdate/readyz", readiness.HandleReady)
 
-	panic(r.Run(fmt.Sprintf(":%s", port)))
+	application.Serve(app.NewServer(fmt.Sprintf(":%s", port), r))
+
+	if err := application.Run(); err != nil {
+		panic(err)
+	}
 }
 
 func HandleHealth(c *gin.Context) {
-	cHttp.HTTPResponse(c, "OK", nil, http.StatusOK)
+	resp := HealthResponse{
+		Status:   "OK",
+		Circuits: make(map[string]string),
+	}
+
+	for host, state := range fxSourceClient.State() {
+		resp.Circuits[host] = state.String()
+
+		if state != httpclient.CircuitClosed {
+			resp.Status = "DEGRADED"
+		}
+	}
+
+	if fxSourceBudget != nil {
+		status := fxSourceBudget.Status()
+		resp.Budget = &status
+
+		if status.Pace < 1 {
+			resp.Status = "DEGRADED"
+		}
+	}
+
+	resp.Rejections = rateValidator.Rejections()
+	resp.Leader = elector.Status()
+
+	cHttp.HTTPResponse(c, resp, nil, http.StatusOK)
+}
+
+// notifierFromEnv : returns nil if no notification channel is configured
+func notifierFromEnv() notifier.Notifier {
+	var notifiers notifier.Multi
+
+	if webhookURL := os.Getenv("ALERT_WEBHOOK_URL"); webhookURL != "" {
+		notifiers = append(notifiers, &notifier.Webhook{
+			URL:        webhookURL,
+			HTTPClient: http.DefaultClient,
+		})
+	}
+
+	if smtpAddr := os.Getenv("ALERT_SMTP_ADDR"); smtpAddr != "" {
+		notifiers = append(notifiers, &notifier.SMTP{
+			Addr:     smtpAddr,
+			Username: os.Getenv("ALERT_SMTP_USERNAME"),
+			Password: os.Getenv("ALERT_SMTP_PASSWORD"),
+			From:     os.Getenv("ALERT_SMTP_FROM"),
+			To:       util.PruneSlice(strings.Split(os.Getenv("ALERT_SMTP_TO"), ",")),
+		})
+	}
+
+	if len(notifiers) == 0 {
+		return nil
+	}
+
+	return notifiers
+}
+
+func intFromEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+func floatFromEnv(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+// maxJumpsFromEnv : thresholds in percent by currency, e.g. "ARS:50,TRY:30"
+func maxJumpsFromEnv(key string) map[string]float64 {
+	maxJumps := make(map[string]float64)
+
+	for _, entry := range util.PruneSlice(strings.Split(os.Getenv(key), ",")) {
+		currency, threshold, ok := strings.Cut(entry, ":")
+		if !ok {
+			panic(fmt.Sprintf("invalid %s entry '%s'", key, entry))
+		}
+
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(threshold), 64)
+		if err != nil {
+			panic(err)
+		}
+
+		maxJumps[strings.ToUpper(strings.TrimSpace(currency))] = parsed
+	}
+
+	return maxJumps
+}
+
+func durationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
 }
@@ -0,0 +1,165 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockbudget
+
+import (
+	context "context"
+
+	budget "github.com/lruggieri/fxnow/fxrate/budget"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Budget is an autogenerated mock type for the Budget type
+type Budget struct {
+	mock.Mock
+}
+
+type Budget_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Budget) EXPECT() *Budget_Expecter {
+	return &Budget_Expecter{mock: &_m.Mock}
+}
+
+// Record provides a mock function with given fields: ctx, calls
+func (_m *Budget) Record(ctx context.Context, calls int64) error {
+	ret := _m.Called(ctx, calls)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, calls)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Budget_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type Budget_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - calls int64
+func (_e *Budget_Expecter) Record(ctx interface{}, calls interface{}) *Budget_Record_Call {
+	return &Budget_Record_Call{Call: _e.mock.On("Record", ctx, calls)}
+}
+
+func (_c *Budget_Record_Call) Run(run func(ctx context.Context, calls int64)) *Budget_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Budget_Record_Call) Return(_a0 error) *Budget_Record_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Budget_Record_Call) RunAndReturn(run func(context.Context, int64) error) *Budget_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx
+func (_m *Budget) Refresh(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Budget_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type Budget_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Budget_Expecter) Refresh(ctx interface{}) *Budget_Refresh_Call {
+	return &Budget_Refresh_Call{Call: _e.mock.On("Refresh", ctx)}
+}
+
+func (_c *Budget_Refresh_Call) Run(run func(ctx context.Context)) *Budget_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Budget_Refresh_Call) Return(_a0 error) *Budget_Refresh_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Budget_Refresh_Call) RunAndReturn(run func(context.Context) error) *Budget_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Status provides a mock function with given fields:
+func (_m *Budget) Status() budget.Status {
+	ret := _m.Called()
+
+	var r0 budget.Status
+	if rf, ok := ret.Get(0).(func() budget.Status); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(budget.Status)
+	}
+
+	return r0
+}
+
+// Budget_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type Budget_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+func (_e *Budget_Expecter) Status() *Budget_Status_Call {
+	return &Budget_Status_Call{Call: _e.mock.On("Status")}
+}
+
+func (_c *Budget_Status_Call) Run(run func()) *Budget_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Budget_Status_Call) Return(_a0 budget.Status) *Budget_Status_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Budget_Status_Call) RunAndReturn(run func() budget.Status) *Budget_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewBudget interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBudget creates a new instance of Budget. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBudget(t mockConstructorTestingTNewBudget) *Budget {
+	mock := &Budget{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
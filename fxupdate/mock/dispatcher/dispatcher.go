@@ -0,0 +1,73 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockdispatcher
+
+import (
+	context "context"
+
+	fxsource "github.com/lruggieri/fxnow/common/fxsource"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Dispatcher is an autogenerated mock type for the Dispatcher type
+type Dispatcher struct {
+	mock.Mock
+}
+
+type Dispatcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Dispatcher) EXPECT() *Dispatcher_Expecter {
+	return &Dispatcher_Expecter{mock: &_m.Mock}
+}
+
+// Dispatch provides a mock function with given fields: ctx, rates
+func (_m *Dispatcher) Dispatch(ctx context.Context, rates []fxsource.Rate) {
+	_m.Called(ctx, rates)
+}
+
+// Dispatcher_Dispatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Dispatch'
+type Dispatcher_Dispatch_Call struct {
+	*mock.Call
+}
+
+// Dispatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rates []fxsource.Rate
+func (_e *Dispatcher_Expecter) Dispatch(ctx interface{}, rates interface{}) *Dispatcher_Dispatch_Call {
+	return &Dispatcher_Dispatch_Call{Call: _e.mock.On("Dispatch", ctx, rates)}
+}
+
+func (_c *Dispatcher_Dispatch_Call) Run(run func(ctx context.Context, rates []fxsource.Rate)) *Dispatcher_Dispatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]fxsource.Rate))
+	})
+	return _c
+}
+
+func (_c *Dispatcher_Dispatch_Call) Return() *Dispatcher_Dispatch_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Dispatcher_Dispatch_Call) RunAndReturn(run func(context.Context, []fxsource.Rate)) *Dispatcher_Dispatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewDispatcher interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewDispatcher creates a new instance of Dispatcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewDispatcher(t mockConstructorTestingTNewDispatcher) *Dispatcher {
+	mock := &Dispatcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,205 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockleader
+
+import (
+	context "context"
+
+	leader "github.com/lruggieri/fxnow/fxrate/leader"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Elector is an autogenerated mock type for the Elector type
+type Elector struct {
+	mock.Mock
+}
+
+type Elector_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Elector) EXPECT() *Elector_Expecter {
+	return &Elector_Expecter{mock: &_m.Mock}
+}
+
+// Fence provides a mock function with given fields: ctx
+func (_m *Elector) Fence(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Elector_Fence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Fence'
+type Elector_Fence_Call struct {
+	*mock.Call
+}
+
+// Fence is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Elector_Expecter) Fence(ctx interface{}) *Elector_Fence_Call {
+	return &Elector_Fence_Call{Call: _e.mock.On("Fence", ctx)}
+}
+
+func (_c *Elector_Fence_Call) Run(run func(ctx context.Context)) *Elector_Fence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Elector_Fence_Call) Return(_a0 int64, _a1 error) *Elector_Fence_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Elector_Fence_Call) RunAndReturn(run func(context.Context) (int64, error)) *Elector_Fence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsLeader provides a mock function with given fields:
+func (_m *Elector) IsLeader() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Elector_IsLeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLeader'
+type Elector_IsLeader_Call struct {
+	*mock.Call
+}
+
+// IsLeader is a helper method to define mock.On call
+func (_e *Elector_Expecter) IsLeader() *Elector_IsLeader_Call {
+	return &Elector_IsLeader_Call{Call: _e.mock.On("IsLeader")}
+}
+
+func (_c *Elector_IsLeader_Call) Run(run func()) *Elector_IsLeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Elector_IsLeader_Call) Return(_a0 bool) *Elector_IsLeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Elector_IsLeader_Call) RunAndReturn(run func() bool) *Elector_IsLeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *Elector) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// Elector_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type Elector_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Elector_Expecter) Run(ctx interface{}) *Elector_Run_Call {
+	return &Elector_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *Elector_Run_Call) Run(run func(ctx context.Context)) *Elector_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Elector_Run_Call) Return() *Elector_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Elector_Run_Call) RunAndReturn(run func(context.Context)) *Elector_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Status provides a mock function with given fields:
+func (_m *Elector) Status() leader.Status {
+	ret := _m.Called()
+
+	var r0 leader.Status
+	if rf, ok := ret.Get(0).(func() leader.Status); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(leader.Status)
+	}
+
+	return r0
+}
+
+// Elector_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type Elector_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+func (_e *Elector_Expecter) Status() *Elector_Status_Call {
+	return &Elector_Status_Call{Call: _e.mock.On("Status")}
+}
+
+func (_c *Elector_Status_Call) Run(run func()) *Elector_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Elector_Status_Call) Return(_a0 leader.Status) *Elector_Status_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Elector_Status_Call) RunAndReturn(run func() leader.Status) *Elector_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewElector interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewElector creates a new instance of Elector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewElector(t mockConstructorTestingTNewElector) *Elector {
+	mock := &Elector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,188 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockleader
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Lease is an autogenerated mock type for the Lease type
+type Lease struct {
+	mock.Mock
+}
+
+type Lease_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Lease) EXPECT() *Lease_Expecter {
+	return &Lease_Expecter{mock: &_m.Mock}
+}
+
+// Acquire provides a mock function with given fields: ctx, ttl
+func (_m *Lease) Acquire(ctx context.Context, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, ttl)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int64, error)); ok {
+		return rf(ctx, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int64); ok {
+		r0 = rf(ctx, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lease_Acquire_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Acquire'
+type Lease_Acquire_Call struct {
+	*mock.Call
+}
+
+// Acquire is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ttl time.Duration
+func (_e *Lease_Expecter) Acquire(ctx interface{}, ttl interface{}) *Lease_Acquire_Call {
+	return &Lease_Acquire_Call{Call: _e.mock.On("Acquire", ctx, ttl)}
+}
+
+func (_c *Lease_Acquire_Call) Run(run func(ctx context.Context, ttl time.Duration)) *Lease_Acquire_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Lease_Acquire_Call) Return(_a0 int64, _a1 error) *Lease_Acquire_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Lease_Acquire_Call) RunAndReturn(run func(context.Context, time.Duration) (int64, error)) *Lease_Acquire_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Check provides a mock function with given fields: ctx, token
+func (_m *Lease) Check(ctx context.Context, token int64) (bool, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lease_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type Lease_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token int64
+func (_e *Lease_Expecter) Check(ctx interface{}, token interface{}) *Lease_Check_Call {
+	return &Lease_Check_Call{Call: _e.mock.On("Check", ctx, token)}
+}
+
+func (_c *Lease_Check_Call) Run(run func(ctx context.Context, token int64)) *Lease_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Lease_Check_Call) Return(_a0 bool, _a1 error) *Lease_Check_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Lease_Check_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *Lease_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, token
+func (_m *Lease) Release(ctx context.Context, token int64) error {
+	ret := _m.Called(ctx, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Lease_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type Lease_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token int64
+func (_e *Lease_Expecter) Release(ctx interface{}, token interface{}) *Lease_Release_Call {
+	return &Lease_Release_Call{Call: _e.mock.On("Release", ctx, token)}
+}
+
+func (_c *Lease_Release_Call) Run(run func(ctx context.Context, token int64)) *Lease_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Lease_Release_Call) Return(_a0 error) *Lease_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Lease_Release_Call) RunAndReturn(run func(context.Context, int64) error) *Lease_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewLease interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLease creates a new instance of Lease. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLease(t mockConstructorTestingTNewLease) *Lease {
+	mock := &Lease{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,113 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mocklogic
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Logic is an autogenerated mock type for the Logic type
+type Logic struct {
+	mock.Mock
+}
+
+type Logic_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Logic) EXPECT() *Logic_Expecter {
+	return &Logic_Expecter{mock: &_m.Mock}
+}
+
+// LastUpdate provides a mock function with given fields:
+func (_m *Logic) LastUpdate() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// Logic_LastUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastUpdate'
+type Logic_LastUpdate_Call struct {
+	*mock.Call
+}
+
+// LastUpdate is a helper method to define mock.On call
+func (_e *Logic_Expecter) LastUpdate() *Logic_LastUpdate_Call {
+	return &Logic_LastUpdate_Call{Call: _e.mock.On("LastUpdate")}
+}
+
+func (_c *Logic_LastUpdate_Call) Run(run func()) *Logic_LastUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Logic_LastUpdate_Call) Return(_a0 time.Time) *Logic_LastUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Logic_LastUpdate_Call) RunAndReturn(run func() time.Time) *Logic_LastUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartFXUpdate provides a mock function with given fields: ctx
+func (_m *Logic) StartFXUpdate(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// Logic_StartFXUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartFXUpdate'
+type Logic_StartFXUpdate_Call struct {
+	*mock.Call
+}
+
+// StartFXUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Logic_Expecter) StartFXUpdate(ctx interface{}) *Logic_StartFXUpdate_Call {
+	return &Logic_StartFXUpdate_Call{Call: _e.mock.On("StartFXUpdate", ctx)}
+}
+
+func (_c *Logic_StartFXUpdate_Call) Run(run func(ctx context.Context)) *Logic_StartFXUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Logic_StartFXUpdate_Call) Return() *Logic_StartFXUpdate_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Logic_StartFXUpdate_Call) RunAndReturn(run func(context.Context)) *Logic_StartFXUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewLogic interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLogic creates a new instance of Logic. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLogic(t mockConstructorTestingTNewLogic) *Logic {
+	mock := &Logic{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
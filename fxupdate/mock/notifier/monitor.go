@@ -0,0 +1,139 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mocknotifier
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Monitor is an autogenerated mock type for the Monitor type
+type Monitor struct {
+	mock.Mock
+}
+
+type Monitor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Monitor) EXPECT() *Monitor_Expecter {
+	return &Monitor_Expecter{mock: &_m.Mock}
+}
+
+// Failed provides a mock function with given fields: ctx, group, err
+func (_m *Monitor) Failed(ctx context.Context, group string, err error) {
+	_m.Called(ctx, group, err)
+}
+
+// Monitor_Failed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Failed'
+type Monitor_Failed_Call struct {
+	*mock.Call
+}
+
+// Failed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group string
+//   - err error
+func (_e *Monitor_Expecter) Failed(ctx interface{}, group interface{}, err interface{}) *Monitor_Failed_Call {
+	return &Monitor_Failed_Call{Call: _e.mock.On("Failed", ctx, group, err)}
+}
+
+func (_c *Monitor_Failed_Call) Run(run func(ctx context.Context, group string, err error)) *Monitor_Failed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(error))
+	})
+	return _c
+}
+
+func (_c *Monitor_Failed_Call) Return() *Monitor_Failed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Monitor_Failed_Call) RunAndReturn(run func(context.Context, string, error)) *Monitor_Failed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *Monitor) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// Monitor_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type Monitor_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Monitor_Expecter) Run(ctx interface{}) *Monitor_Run_Call {
+	return &Monitor_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *Monitor_Run_Call) Run(run func(ctx context.Context)) *Monitor_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Monitor_Run_Call) Return() *Monitor_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Monitor_Run_Call) RunAndReturn(run func(context.Context)) *Monitor_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Succeeded provides a mock function with given fields: ctx, group
+func (_m *Monitor) Succeeded(ctx context.Context, group string) {
+	_m.Called(ctx, group)
+}
+
+// Monitor_Succeeded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Succeeded'
+type Monitor_Succeeded_Call struct {
+	*mock.Call
+}
+
+// Succeeded is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group string
+func (_e *Monitor_Expecter) Succeeded(ctx interface{}, group interface{}) *Monitor_Succeeded_Call {
+	return &Monitor_Succeeded_Call{Call: _e.mock.On("Succeeded", ctx, group)}
+}
+
+func (_c *Monitor_Succeeded_Call) Run(run func(ctx context.Context, group string)) *Monitor_Succeeded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Monitor_Succeeded_Call) Return() *Monitor_Succeeded_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Monitor_Succeeded_Call) RunAndReturn(run func(context.Context, string)) *Monitor_Succeeded_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMonitor interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMonitor creates a new instance of Monitor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMonitor(t mockConstructorTestingTNewMonitor) *Monitor {
+	mock := &Monitor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
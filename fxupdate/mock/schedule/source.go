@@ -0,0 +1,92 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockschedule
+
+import (
+	context "context"
+
+	schedule "github.com/lruggieri/fxnow/fxrate/schedule"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Source is an autogenerated mock type for the Source type
+type Source struct {
+	mock.Mock
+}
+
+type Source_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Source) EXPECT() *Source_Expecter {
+	return &Source_Expecter{mock: &_m.Mock}
+}
+
+// Load provides a mock function with given fields: ctx
+func (_m *Source) Load(ctx context.Context) (*schedule.Config, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *schedule.Config
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*schedule.Config, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *schedule.Config); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*schedule.Config)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Source_Load_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Load'
+type Source_Load_Call struct {
+	*mock.Call
+}
+
+// Load is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Source_Expecter) Load(ctx interface{}) *Source_Load_Call {
+	return &Source_Load_Call{Call: _e.mock.On("Load", ctx)}
+}
+
+func (_c *Source_Load_Call) Run(run func(ctx context.Context)) *Source_Load_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Source_Load_Call) Return(_a0 *schedule.Config, _a1 error) *Source_Load_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Source_Load_Call) RunAndReturn(run func(context.Context) (*schedule.Config, error)) *Source_Load_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewSource interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSource creates a new instance of Source. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSource(t mockConstructorTestingTNewSource) *Source {
+	mock := &Source{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
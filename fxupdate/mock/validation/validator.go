@@ -0,0 +1,215 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockvalidation
+
+import (
+	fxsource "github.com/lruggieri/fxnow/common/fxsource"
+	mock "github.com/stretchr/testify/mock"
+
+	validation "github.com/lruggieri/fxnow/fxrate/validation"
+)
+
+// Validator is an autogenerated mock type for the Validator type
+type Validator struct {
+	mock.Mock
+}
+
+type Validator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Validator) EXPECT() *Validator_Expecter {
+	return &Validator_Expecter{mock: &_m.Mock}
+}
+
+// Known provides a mock function with given fields: from, to
+func (_m *Validator) Known(from string, to string) bool {
+	ret := _m.Called(from, to)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(from, to)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Validator_Known_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Known'
+type Validator_Known_Call struct {
+	*mock.Call
+}
+
+// Known is a helper method to define mock.On call
+//   - from string
+//   - to string
+func (_e *Validator_Expecter) Known(from interface{}, to interface{}) *Validator_Known_Call {
+	return &Validator_Known_Call{Call: _e.mock.On("Known", from, to)}
+}
+
+func (_c *Validator_Known_Call) Run(run func(from string, to string)) *Validator_Known_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Validator_Known_Call) Return(_a0 bool) *Validator_Known_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Validator_Known_Call) RunAndReturn(run func(string, string) bool) *Validator_Known_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rejections provides a mock function with given fields:
+func (_m *Validator) Rejections() map[string]int64 {
+	ret := _m.Called()
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func() map[string]int64); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	return r0
+}
+
+// Validator_Rejections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rejections'
+type Validator_Rejections_Call struct {
+	*mock.Call
+}
+
+// Rejections is a helper method to define mock.On call
+func (_e *Validator_Expecter) Rejections() *Validator_Rejections_Call {
+	return &Validator_Rejections_Call{Call: _e.mock.On("Rejections")}
+}
+
+func (_c *Validator_Rejections_Call) Run(run func()) *Validator_Rejections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Validator_Rejections_Call) Return(_a0 map[string]int64) *Validator_Rejections_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Validator_Rejections_Call) RunAndReturn(run func() map[string]int64) *Validator_Rejections_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Seed provides a mock function with given fields: from, to, rate
+func (_m *Validator) Seed(from string, to string, rate float64) {
+	_m.Called(from, to, rate)
+}
+
+// Validator_Seed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Seed'
+type Validator_Seed_Call struct {
+	*mock.Call
+}
+
+// Seed is a helper method to define mock.On call
+//   - from string
+//   - to string
+//   - rate float64
+func (_e *Validator_Expecter) Seed(from interface{}, to interface{}, rate interface{}) *Validator_Seed_Call {
+	return &Validator_Seed_Call{Call: _e.mock.On("Seed", from, to, rate)}
+}
+
+func (_c *Validator_Seed_Call) Run(run func(from string, to string, rate float64)) *Validator_Seed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *Validator_Seed_Call) Return() *Validator_Seed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Validator_Seed_Call) RunAndReturn(run func(string, string, float64)) *Validator_Seed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Validate provides a mock function with given fields: rates
+func (_m *Validator) Validate(rates []fxsource.Rate) ([]fxsource.Rate, []validation.Rejection) {
+	ret := _m.Called(rates)
+
+	var r0 []fxsource.Rate
+	var r1 []validation.Rejection
+	if rf, ok := ret.Get(0).(func([]fxsource.Rate) ([]fxsource.Rate, []validation.Rejection)); ok {
+		return rf(rates)
+	}
+	if rf, ok := ret.Get(0).(func([]fxsource.Rate) []fxsource.Rate); ok {
+		r0 = rf(rates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]fxsource.Rate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]fxsource.Rate) []validation.Rejection); ok {
+		r1 = rf(rates)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]validation.Rejection)
+		}
+	}
+
+	return r0, r1
+}
+
+// Validator_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type Validator_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - rates []fxsource.Rate
+func (_e *Validator_Expecter) Validate(rates interface{}) *Validator_Validate_Call {
+	return &Validator_Validate_Call{Call: _e.mock.On("Validate", rates)}
+}
+
+func (_c *Validator_Validate_Call) Run(run func(rates []fxsource.Rate)) *Validator_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]fxsource.Rate))
+	})
+	return _c
+}
+
+func (_c *Validator_Validate_Call) Return(_a0 []fxsource.Rate, _a1 []validation.Rejection) *Validator_Validate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Validator_Validate_Call) RunAndReturn(run func([]fxsource.Rate) ([]fxsource.Rate, []validation.Rejection)) *Validator_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewValidator interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewValidator creates a new instance of Validator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewValidator(t mockConstructorTestingTNewValidator) *Validator {
+	mock := &Validator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
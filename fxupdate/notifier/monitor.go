@@ -0,0 +1,223 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lruggieri/fxnow/common/clock"
+	"github.com/lruggieri/fxnow/common/logger"
+)
+
+const (
+	DefaultMaxConsecutiveFailures = 3
+	DefaultMaxStaleness           = 5 * time.Minute
+	DefaultRepeatInterval         = time.Hour
+
+	// DefaultCheckInterval : how often Run checks the thresholds
+	DefaultCheckInterval = 30 * time.Second
+)
+
+// urlQuery : query strings of the URLs found in errors, which may carry credentials (e.g. api_key=...)
+var urlQuery = regexp.MustCompile(`(https?://[^\s"'?]*)\?[^\s"']*`)
+
+// Monitor : tracks the outcome of the update cycles and decides when operators must be alerted
+type Monitor interface {
+	// Run : checks the thresholds periodically until the context is done, so that operators are alerted even if the
+	// updates stop altogether
+	Run(ctx context.Context)
+	// Succeeded : an update of the group succeeded
+	Succeeded(ctx context.Context, group string)
+	// Failed : an update of the group failed. Failures are counted by group, so that a group always failing is
+	// alerted on even if others succeed meanwhile.
+	Failed(ctx context.Context, group string, err error)
+}
+
+// ThresholdMonitor : alerts once either threshold is reached, then stays silent until the alert is repeated or the
+// updates recover. A recovery notification is sent when an update succeeds after an alert.
+type ThresholdMonitor struct {
+	Notifier Notifier
+	Clock    clock.Clock
+	// MaxConsecutiveFailures : consecutive failed updates before alerting, 0 to disable
+	MaxConsecutiveFailures int
+	// MaxStaleness : time without a successful update before alerting, 0 to disable
+	MaxStaleness time.Duration
+	// RepeatInterval : how often an ongoing alert is notified again, 0 to notify it only once
+	RepeatInterval time.Duration
+	// Active : optional, whether updates are expected (e.g. on the leader). While inactive, the staleness is not
+	// checked, and is then measured from when the monitor becomes active.
+	Active func() bool
+	// CheckInterval : optional, defaults to DefaultCheckInterval
+	CheckInterval time.Duration
+
+	mu          sync.Mutex
+	startedAt   time.Time
+	lastSuccess time.Time
+	// failures : consecutive failures by group
+	failures map[string]int
+	// incidentFailures : failures since the updates were last fine, reported on recovery
+	incidentFailures int
+	lastErr          error
+	alerting         bool
+	alertedAt        time.Time
+}
+
+func (m *ThresholdMonitor) Run(ctx context.Context) {
+	interval := m.CheckInterval
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+// Succeeded : recovers from the alert once no threshold is reached anymore, e.g. not while other groups keep failing
+func (m *ThresholdMonitor) Succeeded(ctx context.Context, group string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.Clock.Now()
+	m.init(now)
+
+	delete(m.failures, group)
+	m.lastSuccess = now
+
+	if m.thresholdReached(now) != "" {
+		return
+	}
+
+	if m.alerting {
+		m.notify(ctx, Notification{
+			Subject:  "fxupdate recovered",
+			Message:  fmt.Sprintf("Rates are updated again after %d failed updates.", m.incidentFailures),
+			Recovery: true,
+		})
+	}
+
+	if len(m.failures) == 0 {
+		m.incidentFailures = 0
+		m.lastErr = nil
+	}
+
+	m.alerting = false
+}
+
+func (m *ThresholdMonitor) Failed(ctx context.Context, group string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.Clock.Now()
+	m.init(now)
+
+	m.failures[group]++
+	m.incidentFailures++
+	m.lastErr = err
+
+	m.alert(ctx, now)
+}
+
+// check : alerts if the updates went stale without being reported as failed
+func (m *ThresholdMonitor) check(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.Clock.Now()
+	m.init(now)
+
+	if m.Active != nil && !m.Active() {
+		m.lastSuccess = now
+
+		return
+	}
+
+	m.alert(ctx, now)
+}
+
+// alert : notifies the threshold reached, if any
+func (m *ThresholdMonitor) alert(ctx context.Context, now time.Time) {
+	reason := m.thresholdReached(now)
+	if reason == "" {
+		return
+	}
+
+	// deduplication: a single alert per incident, unless it must be repeated
+	if m.alerting && (m.RepeatInterval <= 0 || now.Sub(m.alertedAt) < m.RepeatInterval) {
+		return
+	}
+
+	m.alerting = true
+	m.alertedAt = now
+
+	message := reason
+	if m.lastErr != nil {
+		message = fmt.Sprintf("%s. Last error: %s", reason, redact(m.lastErr))
+	}
+
+	m.notify(ctx, Notification{
+		Subject: "fxupdate is failing",
+		Message: message,
+	})
+}
+
+func (m *ThresholdMonitor) init(now time.Time) {
+	if m.startedAt.IsZero() {
+		m.startedAt = now
+		m.failures = make(map[string]int)
+	}
+}
+
+// thresholdReached : describes the threshold reached, if any
+func (m *ThresholdMonitor) thresholdReached(now time.Time) string {
+	if m.MaxConsecutiveFailures > 0 {
+		groups := make([]string, 0, len(m.failures))
+		for group := range m.failures {
+			groups = append(groups, group)
+		}
+
+		// the same group is reported while several are failing
+		sort.Strings(groups)
+
+		for _, group := range groups {
+			if failures := m.failures[group]; failures >= m.MaxConsecutiveFailures {
+				return fmt.Sprintf("%d consecutive updates of %s failed", failures, group)
+			}
+		}
+	}
+
+	// before the first success, staleness is measured since the monitor started
+	since := m.lastSuccess
+	if since.IsZero() {
+		since = m.startedAt
+	}
+
+	if m.MaxStaleness > 0 && now.Sub(since) >= m.MaxStaleness {
+		return fmt.Sprintf("no successful update for %s", now.Sub(since).Truncate(time.Second))
+	}
+
+	return ""
+}
+
+// redact : alerts are sent to third parties, errors must not leak credentials
+func redact(err error) string {
+	return urlQuery.ReplaceAllString(err.Error(), "$1?REDACTED")
+}
+
+// notify : notification errors are only logged, they must not stop the update loop
+func (m *ThresholdMonitor) notify(ctx context.Context, n Notification) {
+	if err := m.Notifier.Notify(ctx, n); err != nil {
+		logger.WithError(err).WithField("subject", n.Subject).Error("cannot send notification")
+	}
+}
@@ -0,0 +1,291 @@
+package notifier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/logger/zap"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+
+	mocknotifier "github.com/lruggieri/fxnow/fxrate/mock/notifier"
+	"github.com/lruggieri/fxnow/fxrate/notifier"
+)
+
+func TestThresholdMonitor(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	testErr := errors.New("error")
+	start := time.Unix(1700000000, 0)
+
+	// update : outcome of an update of the group (majors if empty), happening at start+offset
+	type update struct {
+		offset time.Duration
+		group  string
+		err    error
+	}
+
+	tests := []struct {
+		name     string
+		monitor  func() *notifier.ThresholdMonitor
+		updates  []update
+		mock     func(n *mocknotifier.Notifier)
+		expected []notifier.Notification
+	}{
+		{
+			name: "below-thresholds",
+			monitor: func() *notifier.ThresholdMonitor {
+				return &notifier.ThresholdMonitor{MaxConsecutiveFailures: 3, MaxStaleness: 5 * time.Minute}
+			},
+			updates: []update{
+				{offset: 0, err: testErr},
+				{offset: 20 * time.Second, err: testErr},
+				{offset: 40 * time.Second},
+				{offset: 60 * time.Second, err: testErr},
+			},
+		},
+		{
+			name: "consecutive-failures-deduplicated",
+			monitor: func() *notifier.ThresholdMonitor {
+				return &notifier.ThresholdMonitor{MaxConsecutiveFailures: 2}
+			},
+			updates: []update{
+				{offset: 0, err: testErr},
+				{offset: 20 * time.Second, err: testErr},
+				{offset: 40 * time.Second, err: testErr},
+				{offset: 60 * time.Second, err: testErr},
+			},
+			expected: []notifier.Notification{
+				{Subject: "fxupdate is failing", Message: "2 consecutive updates of majors failed. Last error: error"},
+			},
+		},
+		{
+			name: "staleness-repeated-then-recovered",
+			monitor: func() *notifier.ThresholdMonitor {
+				return &notifier.ThresholdMonitor{MaxStaleness: time.Minute, RepeatInterval: 2 * time.Minute}
+			},
+			updates: []update{
+				{offset: 0},
+				{offset: 40 * time.Second, err: testErr},
+				{offset: 80 * time.Second, err: testErr},
+				{offset: 120 * time.Second, err: testErr},
+				{offset: 200 * time.Second, err: testErr},
+				{offset: 220 * time.Second},
+				{offset: 240 * time.Second},
+			},
+			expected: []notifier.Notification{
+				{Subject: "fxupdate is failing", Message: "no successful update for 1m20s. Last error: error"},
+				{Subject: "fxupdate is failing", Message: "no successful update for 3m20s. Last error: error"},
+				{Subject: "fxupdate recovered", Message: "Rates are updated again after 4 failed updates.", Recovery: true},
+			},
+		},
+		{
+			name: "staleness-since-start",
+			monitor: func() *notifier.ThresholdMonitor {
+				return &notifier.ThresholdMonitor{MaxStaleness: time.Minute}
+			},
+			updates: []update{
+				{offset: 0, err: testErr},
+				{offset: time.Minute, err: testErr},
+			},
+			expected: []notifier.Notification{
+				{Subject: "fxupdate is failing", Message: "no successful update for 1m0s. Last error: error"},
+			},
+		},
+		{
+			// successes of other groups do not hide a group always failing
+			name: "consecutive-failures-by-group",
+			monitor: func() *notifier.ThresholdMonitor {
+				return &notifier.ThresholdMonitor{MaxConsecutiveFailures: 2}
+			},
+			updates: []update{
+				{offset: 0, group: "exotics", err: testErr},
+				{offset: 20 * time.Second},
+				{offset: 40 * time.Second, group: "exotics", err: testErr},
+				{offset: 60 * time.Second},
+				{offset: 80 * time.Second, group: "exotics"},
+			},
+			expected: []notifier.Notification{
+				{Subject: "fxupdate is failing", Message: "2 consecutive updates of exotics failed. Last error: error"},
+				{Subject: "fxupdate recovered", Message: "Rates are updated again after 2 failed updates.", Recovery: true},
+			},
+		},
+		{
+			name: "error-redacted",
+			monitor: func() *notifier.ThresholdMonitor {
+				return &notifier.ThresholdMonitor{MaxConsecutiveFailures: 1}
+			},
+			updates: []update{
+				{offset: 0, err: errors.New(`Get "https://api.fastforex.io/fetch-all?from=USD&api_key=secret": EOF`)},
+			},
+			expected: []notifier.Notification{
+				{
+					Subject: "fxupdate is failing",
+					Message: `1 consecutive updates of majors failed. Last error: Get "https://api.fastforex.io/fetch-all?REDACTED": EOF`,
+				},
+			},
+		},
+		{
+			name: "notify-error-not-blocking",
+			monitor: func() *notifier.ThresholdMonitor {
+				return &notifier.ThresholdMonitor{MaxConsecutiveFailures: 1}
+			},
+			updates: []update{
+				{offset: 0, err: testErr},
+				{offset: 20 * time.Second},
+			},
+			mock: func(n *mocknotifier.Notifier) {
+				n.EXPECT().Notify(mock.Anything, mock.Anything).Return(testErr).Twice()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			n := mocknotifier.NewNotifier(t)
+			c := mockclock.NewClock(t)
+
+			var sent []notifier.Notification
+
+			if tc.mock != nil {
+				tc.mock(n)
+			} else {
+				n.EXPECT().Notify(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, notification notifier.Notification) error {
+						sent = append(sent, notification)
+
+						return nil
+					},
+				).Maybe()
+			}
+
+			m := tc.monitor()
+			m.Notifier = n
+			m.Clock = c
+
+			for _, u := range tc.updates {
+				c.EXPECT().Now().Return(start.Add(u.offset)).Once()
+
+				group := u.group
+				if group == "" {
+					group = "majors"
+				}
+
+				if u.err != nil {
+					m.Failed(context.Background(), group, u.err)
+				} else {
+					m.Succeeded(context.Background(), group)
+				}
+			}
+
+			assert.Equal(t, tc.expected, sent)
+		})
+	}
+}
+
+func TestMulti_Notify(t *testing.T) {
+	testErr := errors.New("error")
+	notification := notifier.Notification{Subject: "subject", Message: "message"}
+
+	failing := mocknotifier.NewNotifier(t)
+	failing.EXPECT().Notify(mock.Anything, notification).Return(testErr).Once()
+
+	succeeding := mocknotifier.NewNotifier(t)
+	succeeding.EXPECT().Notify(mock.Anything, notification).Return(nil).Once()
+
+	err := notifier.Multi{failing, succeeding}.Notify(context.Background(), notification)
+
+	assert.ErrorIs(t, err, testErr)
+}
+
+func TestThresholdMonitor_Run(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	start := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		active   bool
+		expected []notifier.Notification
+	}{
+		{
+			// the updates stopped without being reported as failed
+			name:   "stale",
+			active: true,
+			expected: []notifier.Notification{
+				{Subject: "fxupdate is failing", Message: "no successful update for 10m0s"},
+			},
+		},
+		{
+			// followers do not update the rates
+			name: "inactive",
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			n := mocknotifier.NewNotifier(t)
+			c := mockclock.NewClock(t)
+
+			sent := make(chan notifier.Notification, 1)
+
+			n.EXPECT().Notify(mock.Anything, mock.Anything).RunAndReturn(
+				func(ctx context.Context, notification notifier.Notification) error {
+					sent <- notification
+
+					return nil
+				},
+			).Maybe()
+
+			m := &notifier.ThresholdMonitor{
+				Notifier:      n,
+				Clock:         c,
+				MaxStaleness:  5 * time.Minute,
+				Active:        func() bool { return tc.active },
+				CheckInterval: time.Millisecond,
+			}
+
+			c.EXPECT().Now().Return(start).Once()
+			m.Succeeded(context.Background(), "majors")
+
+			checked := make(chan struct{})
+
+			c.EXPECT().Now().RunAndReturn(func() time.Time {
+				select {
+				case checked <- struct{}{}:
+				default:
+				}
+
+				return start.Add(10 * time.Minute)
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+
+			go func() {
+				m.Run(ctx)
+				close(done)
+			}()
+
+			<-checked
+
+			if len(tc.expected) > 0 {
+				assert.Equal(t, tc.expected[0], <-sent)
+			}
+
+			cancel()
+			<-done
+
+			if len(tc.expected) == 0 {
+				assert.Empty(t, sent)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Package notifier alerts operators when fxupdate fails to keep the rates up to date.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/client/httpclient"
+)
+
+const (
+	// SendTimeout : maximum duration of a single notification
+	SendTimeout = 10 * time.Second
+)
+
+type Notification struct {
+	Subject string
+	Message string
+	// Recovery : the notification closes a previous alert
+	Recovery bool
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// Webhook : posts notifications as Slack-compatible JSON ({"text": "..."}), which most chat tools accept
+type Webhook struct {
+	URL        string
+	HTTPClient httpclient.Client
+}
+
+type webhookMessage struct {
+	Text string `json:"text"`
+}
+
+func (w *Webhook) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(webhookMessage{Text: fmt.Sprintf("*%s*\n%s", n.Subject, n.Message)})
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal notification")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, SendTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot create request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot post notification")
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SMTP : sends notifications by email. Authentication is skipped if Username is empty.
+type SMTP struct {
+	Addr     string // host:port
+	Username string
+	Password string
+	From     string
+	To       []string
+
+	// sendMail : replaceable for testing, defaults to sendMail
+	sendMail func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func (s *SMTP) Notify(ctx context.Context, n Notification) error {
+	var auth smtp.Auth
+
+	if s.Username != "" {
+		host := s.Addr
+		if idx := strings.LastIndex(host, ":"); idx >= 0 {
+			host = host[:idx]
+		}
+
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+
+	send := s.sendMail
+	if send == nil {
+		send = sendMail
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, SendTimeout)
+	defer cancel()
+
+	if err := send(ctx, s.Addr, auth, s.From, s.To, s.message(n)); err != nil {
+		return errors.Wrap(err, "cannot send email")
+	}
+
+	return nil
+}
+
+// sendMail : smtp.SendMail bounded by the context, so that an unresponsive server cannot block the update loop
+func sendMail(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	// the connection is closed if the context is cancelled before its deadline
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	host, _, _ := net.SplitHostPort(addr)
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if a != nil {
+		if err = client.Auth(a); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(from); err != nil {
+		return err
+	}
+
+	for _, rcpt := range to {
+		if err = client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (s *SMTP) message(n Notification) []byte {
+	var msg bytes.Buffer
+
+	// header values must not contain line breaks, or they could inject other headers
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(n.Subject)
+
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(n.Message, "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	return msg.Bytes()
+}
+
+// Multi : sends notifications to all the notifiers, even if some of them fail
+type Multi []Notifier
+
+func (m Multi) Notify(ctx context.Context, n Notification) error {
+	errs := make([]error, 0, len(m))
+
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Wrap(joinErrors(errs), "cannot notify")
+}
+
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		messages := make([]string, 0, len(errs))
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+
+		return errors.New(strings.Join(messages, "; "))
+	}
+}
@@ -0,0 +1,200 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockhttpclient "github.com/lruggieri/fxnow/common/mock/client/httpclient"
+)
+
+func TestWebhook_Notify(t *testing.T) {
+	testErr := errors.New("error")
+
+	response := func(status int) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(""))}
+	}
+
+	tests := []struct {
+		name      string
+		mock      func(c *mockhttpclient.Client)
+		assertion func(t *testing.T, err error)
+	}{
+		{
+			name: "error-do",
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "error-status-code",
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).Return(response(http.StatusBadRequest), nil).Once()
+			},
+			assertion: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "unexpected status code 400")
+			},
+		},
+		{
+			name: "happy-path",
+			mock: func(c *mockhttpclient.Client) {
+				c.EXPECT().Do(mock.Anything).RunAndReturn(func(req *http.Request) (*http.Response, error) {
+					body, err := io.ReadAll(req.Body)
+					assert.Nil(t, err)
+
+					assert.Equal(t, "https://hooks.example.com/alerts", req.URL.String())
+					assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+					assert.JSONEq(t, `{"text": "*fxupdate is failing*\n3 consecutive updates failed"}`, string(body))
+
+					return response(http.StatusOK), nil
+				}).Once()
+			},
+			assertion: func(t *testing.T, err error) {
+				assert.Nil(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			c := mockhttpclient.NewClient(t)
+
+			w := Webhook{
+				URL:        "https://hooks.example.com/alerts",
+				HTTPClient: c,
+			}
+
+			tc.mock(c)
+
+			err := w.Notify(context.Background(), Notification{
+				Subject: "fxupdate is failing",
+				Message: "3 consecutive updates failed",
+			})
+
+			tc.assertion(t, err)
+		})
+	}
+}
+
+func TestSMTP_Notify(t *testing.T) {
+	testErr := errors.New("error")
+
+	tests := []struct {
+		name      string
+		username  string
+		sendErr   error
+		assertion func(t *testing.T, auth smtp.Auth, msg string, err error)
+	}{
+		{
+			name:    "error-send",
+			sendErr: testErr,
+			assertion: func(t *testing.T, auth smtp.Auth, msg string, err error) {
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path-no-auth",
+			assertion: func(t *testing.T, auth smtp.Auth, msg string, err error) {
+				assert.Nil(t, err)
+				assert.Nil(t, auth)
+				assert.Equal(t, "From: alerts@fx-now.com\r\n"+
+					"To: ops@fx-now.com, oncall@fx-now.com\r\n"+
+					"Subject: fxupdate is failing  now\r\n"+
+					"MIME-Version: 1.0\r\n"+
+					"Content-Type: text/plain; charset=UTF-8\r\n"+
+					"\r\n"+
+					"3 consecutive updates failed\r\nLast error: timeout\r\n", msg)
+			},
+		},
+		{
+			name:     "happy-path-auth",
+			username: "user",
+			assertion: func(t *testing.T, auth smtp.Auth, msg string, err error) {
+				assert.Nil(t, err)
+				assert.NotNil(t, auth)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				sentAuth smtp.Auth
+				sentMsg  string
+			)
+
+			s := SMTP{
+				Addr:     "smtp.example.com:587",
+				Username: tc.username,
+				Password: "password",
+				From:     "alerts@fx-now.com",
+				To:       []string{"ops@fx-now.com", "oncall@fx-now.com"},
+				sendMail: func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+					_, ok := ctx.Deadline()
+					assert.True(t, ok)
+
+					assert.Equal(t, "smtp.example.com:587", addr)
+					assert.Equal(t, "alerts@fx-now.com", from)
+					assert.Equal(t, []string{"ops@fx-now.com", "oncall@fx-now.com"}, to)
+
+					sentAuth = a
+					sentMsg = string(msg)
+
+					return tc.sendErr
+				},
+			}
+
+			err := s.Notify(context.Background(), Notification{
+				Subject: "fxupdate is failing\r\nnow",
+				Message: "3 consecutive updates failed\nLast error: timeout",
+			})
+
+			tc.assertion(t, sentAuth, sentMsg, err)
+		})
+	}
+}
+
+func TestSMTP_Notify_unresponsive(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	defer listener.Close()
+
+	// the server accepts connections but never greets
+	go func() {
+		conn, acceptErr := listener.Accept()
+		if acceptErr == nil {
+			defer conn.Close()
+
+			_, _ = io.Copy(io.Discard, conn)
+		}
+	}()
+
+	s := SMTP{
+		Addr: listener.Addr().String(),
+		From: "alerts@fx-now.com",
+		To:   []string{"ops@fx-now.com"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	assert.Error(t, s.Notify(ctx, Notification{Subject: "subject", Message: "message"}))
+	assert.Less(t, time.Since(start), SendTimeout)
+}
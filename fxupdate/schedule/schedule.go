@@ -0,0 +1,264 @@
+// Package schedule defines which base currencies fxupdate refreshes, and how often.
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/util"
+)
+
+const (
+	// MinInterval : shortest refresh interval of a group, to protect the fastforex API budget
+	MinInterval = time.Second
+	// DefaultInterval : refresh interval of DefaultConfig
+	DefaultInterval = 20 * time.Second
+)
+
+// Group : base currencies refreshed together
+type Group struct {
+	Name       string
+	Currencies []string
+	Interval   time.Duration
+	// Jitter : maximum random delay added to each interval, so that groups do not hit the source at the same time
+	Jitter time.Duration
+}
+
+type Config struct {
+	Groups []Group
+}
+
+// DefaultConfig : used when no configuration is provided
+func DefaultConfig() *Config {
+	return &Config{
+		Groups: []Group{
+			{
+				Name:       "majors",
+				Currencies: []string{"USD", "GBP", "EUR", "JPY", "CHF", "CAD", "AUD"},
+				Interval:   DefaultInterval,
+			},
+		},
+	}
+}
+
+func (c *Config) Validate() error {
+	if len(c.Groups) == 0 {
+		return errors.New("no currency groups")
+	}
+
+	names := make(map[string]struct{}, len(c.Groups))
+
+	for _, g := range c.Groups {
+		if g.Name == "" {
+			return errors.New("group without name")
+		}
+
+		if _, ok := names[g.Name]; ok {
+			return errors.Errorf("duplicated group %s", g.Name)
+		}
+
+		names[g.Name] = struct{}{}
+
+		if len(g.Currencies) == 0 {
+			return errors.Errorf("group %s has no currencies", g.Name)
+		}
+
+		for _, currency := range g.Currencies {
+			if !util.IsValidCurrency(currency) {
+				return errors.Errorf("invalid currency %s in group %s", currency, g.Name)
+			}
+		}
+
+		if g.Interval < MinInterval {
+			return errors.Errorf("interval of group %s must be at least %s", g.Name, MinInterval)
+		}
+
+		if g.Jitter < 0 || g.Jitter >= g.Interval {
+			return errors.Errorf("jitter of group %s must not be negative and must be shorter than its interval", g.Name)
+		}
+	}
+
+	return nil
+}
+
+// Source : provides the configuration, which can change while fxupdate is running
+type Source interface {
+	Load(ctx context.Context) (*Config, error)
+}
+
+// Static : configuration that never changes
+type Static struct {
+	Config *Config
+}
+
+func (s *Static) Load(_ context.Context) (*Config, error) {
+	return s.Config, nil
+}
+
+// File : JSON configuration file, read again at every load. For example:
+//
+//	{"groups": [{"name": "majors", "currencies": ["USD", "EUR"], "interval": "10s", "jitter": "2s"}]}
+type File struct {
+	Path string
+}
+
+type fileConfig struct {
+	Groups []fileGroup `json:"groups"`
+}
+
+type fileGroup struct {
+	Name       string   `json:"name"`
+	Currencies []string `json:"currencies"`
+	Interval   string   `json:"interval"`
+	Jitter     string   `json:"jitter"`
+}
+
+func (f *File) Load(_ context.Context) (*Config, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read schedule configuration")
+	}
+
+	return Parse(data)
+}
+
+// Parse : parses and validates a JSON configuration
+func Parse(data []byte) (*Config, error) {
+	var fc fileConfig
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return nil, errors.Wrap(err, "invalid schedule configuration")
+	}
+
+	config := &Config{Groups: make([]Group, 0, len(fc.Groups))}
+
+	for _, fg := range fc.Groups {
+		g := Group{
+			Name:       fg.Name,
+			Currencies: make([]string, 0, len(fg.Currencies)),
+		}
+
+		for _, currency := range fg.Currencies {
+			g.Currencies = append(g.Currencies, strings.ToUpper(strings.TrimSpace(currency)))
+		}
+
+		var err error
+
+		if g.Interval, err = time.ParseDuration(fg.Interval); err != nil {
+			return nil, errors.Wrapf(err, "invalid interval of group %s", fg.Name)
+		}
+
+		if fg.Jitter != "" {
+			if g.Jitter, err = time.ParseDuration(fg.Jitter); err != nil {
+				return nil, errors.Wrapf(err, "invalid jitter of group %s", fg.Name)
+			}
+		}
+
+		config.Groups = append(config.Groups, g)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid schedule configuration")
+	}
+
+	return config, nil
+}
+
+// Scheduler : decides which groups are due for a refresh. It is not safe for concurrent use.
+type Scheduler struct {
+	// Jitter : optional, returns a random duration in [0, maxJitter). Defaults to math/rand.
+	Jitter func(maxJitter time.Duration) time.Duration
+	// Throttle : optional, multiplier of the interval of the group with the given priority (its position in the
+	// configuration, 0 being the highest). Groups throttled to 0 are skipped.
+	Throttle func(priority int) float64
+
+	groups     map[string]Group
+	priorities map[string]int
+	nextRun    map[string]time.Time
+}
+
+// Apply : replaces the groups with the configured ones. New groups are due immediately, and groups whose interval
+// was shortened are not delayed beyond their new interval.
+func (s *Scheduler) Apply(config *Config, now time.Time) {
+	groups := make(map[string]Group, len(config.Groups))
+	priorities := make(map[string]int, len(config.Groups))
+	nextRun := make(map[string]time.Time, len(config.Groups))
+
+	for priority, g := range config.Groups {
+		groups[g.Name] = g
+		priorities[g.Name] = priority
+
+		next, ok := s.nextRun[g.Name]
+		if !ok {
+			next = now
+		}
+
+		if latest := now.Add(g.Interval + g.Jitter); next.After(latest) {
+			next = latest
+		}
+
+		nextRun[g.Name] = next
+	}
+
+	s.groups = groups
+	s.priorities = priorities
+	s.nextRun = nextRun
+}
+
+// Due : groups to refresh now, sorted by name. They are rescheduled after their (throttled) interval and a random
+// jitter.
+func (s *Scheduler) Due(now time.Time) []Group {
+	due := make([]Group, 0)
+
+	for name, next := range s.nextRun {
+		if next.After(now) {
+			continue
+		}
+
+		g := s.groups[name]
+
+		factor := s.throttle(s.priorities[name])
+		if factor <= 0 {
+			// skipped, the throttling is checked again after the interval
+			s.nextRun[name] = now.Add(g.Interval)
+
+			continue
+		}
+
+		due = append(due, g)
+		s.nextRun[name] = now.Add(time.Duration(float64(g.Interval)*factor) + s.jitter(g.Jitter))
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].Name < due[j].Name
+	})
+
+	return due
+}
+
+func (s *Scheduler) throttle(priority int) float64 {
+	if s.Throttle == nil {
+		return 1
+	}
+
+	return s.Throttle(priority)
+}
+
+func (s *Scheduler) jitter(maxJitter time.Duration) time.Duration {
+	if maxJitter <= 0 {
+		return 0
+	}
+
+	if s.Jitter != nil {
+		return s.Jitter(maxJitter)
+	}
+
+	//nolint:gosec // jitter does not need a secure source
+	return time.Duration(rand.Int63n(int64(maxJitter)))
+}
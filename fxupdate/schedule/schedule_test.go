@@ -0,0 +1,181 @@
+package schedule
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		assertion func(t *testing.T, config *Config, err error)
+	}{
+		{
+			name: "error-json",
+			data: `{"groups": [`,
+			assertion: func(t *testing.T, config *Config, err error) {
+				assert.Nil(t, config)
+				assert.ErrorContains(t, err, "invalid schedule configuration")
+			},
+		},
+		{
+			name: "error-no-groups",
+			data: `{"groups": []}`,
+			assertion: func(t *testing.T, config *Config, err error) {
+				assert.Nil(t, config)
+				assert.ErrorContains(t, err, "no currency groups")
+			},
+		},
+		{
+			name: "error-invalid-interval",
+			data: `{"groups": [{"name": "majors", "currencies": ["USD"], "interval": "10"}]}`,
+			assertion: func(t *testing.T, config *Config, err error) {
+				assert.Nil(t, config)
+				assert.ErrorContains(t, err, "invalid interval of group majors")
+			},
+		},
+		{
+			name: "error-interval-too-short",
+			data: `{"groups": [{"name": "majors", "currencies": ["USD"], "interval": "100ms"}]}`,
+			assertion: func(t *testing.T, config *Config, err error) {
+				assert.Nil(t, config)
+				assert.ErrorContains(t, err, "interval of group majors must be at least 1s")
+			},
+		},
+		{
+			name: "error-jitter-too-long",
+			data: `{"groups": [{"name": "majors", "currencies": ["USD"], "interval": "10s", "jitter": "10s"}]}`,
+			assertion: func(t *testing.T, config *Config, err error) {
+				assert.Nil(t, config)
+				assert.ErrorContains(t, err, "jitter of group majors")
+			},
+		},
+		{
+			name: "error-invalid-currency",
+			data: `{"groups": [{"name": "majors", "currencies": ["USDX"], "interval": "10s"}]}`,
+			assertion: func(t *testing.T, config *Config, err error) {
+				assert.Nil(t, config)
+				assert.ErrorContains(t, err, "invalid currency USDX in group majors")
+			},
+		},
+		{
+			name: "error-duplicated-group",
+			data: `{"groups": [
+				{"name": "majors", "currencies": ["USD"], "interval": "10s"},
+				{"name": "majors", "currencies": ["EUR"], "interval": "10s"}
+			]}`,
+			assertion: func(t *testing.T, config *Config, err error) {
+				assert.Nil(t, config)
+				assert.ErrorContains(t, err, "duplicated group majors")
+			},
+		},
+		{
+			name: "happy-path",
+			data: `{"groups": [
+				{"name": "majors", "currencies": ["usd", " EUR"], "interval": "10s", "jitter": "2s"},
+				{"name": "exotics", "currencies": ["TRY"], "interval": "5m"}
+			]}`,
+			assertion: func(t *testing.T, config *Config, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &Config{
+					Groups: []Group{
+						{Name: "majors", Currencies: []string{"USD", "EUR"}, Interval: 10 * time.Second, Jitter: 2 * time.Second},
+						{Name: "exotics", Currencies: []string{"TRY"}, Interval: 5 * time.Minute},
+					},
+				}, config)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := Parse([]byte(tc.data))
+
+			tc.assertion(t, config, err)
+		})
+	}
+}
+
+func TestFile_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	f := File{Path: path}
+
+	_, err := f.Load(context.Background())
+	assert.ErrorContains(t, err, "cannot read schedule configuration")
+
+	assert.Nil(t, os.WriteFile(path, []byte(`{"groups": [{"name": "majors", "currencies": ["USD"], "interval": "10s"}]}`),
+		0o600))
+
+	config, err := f.Load(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "majors", config.Groups[0].Name)
+}
+
+func TestScheduler(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	majors := Group{Name: "majors", Currencies: []string{"USD"}, Interval: 10 * time.Second, Jitter: 2 * time.Second}
+	exotics := Group{Name: "exotics", Currencies: []string{"TRY"}, Interval: 5 * time.Minute}
+
+	s := Scheduler{
+		Jitter: func(maxJitter time.Duration) time.Duration {
+			return maxJitter / 2
+		},
+	}
+
+	s.Apply(&Config{Groups: []Group{majors, exotics}}, now)
+
+	// new groups are due immediately, sorted by name
+	assert.Equal(t, []Group{exotics, majors}, s.Due(now))
+	assert.Empty(t, s.Due(now.Add(10*time.Second)))
+	assert.Equal(t, []Group{majors}, s.Due(now.Add(11*time.Second)))
+
+	// exotics are now refreshed every minute: the next refresh is brought forward
+	exotics.Interval = time.Minute
+	s.Apply(&Config{Groups: []Group{majors, exotics}}, now.Add(20*time.Second))
+
+	assert.Equal(t, []Group{majors}, s.Due(now.Add(22*time.Second)))
+	assert.Equal(t, []Group{exotics, majors}, s.Due(now.Add(80*time.Second)))
+
+	// removed groups are not refreshed anymore
+	s.Apply(&Config{Groups: []Group{exotics}}, now.Add(90*time.Second))
+
+	assert.Empty(t, s.Due(now.Add(120*time.Second)))
+	assert.Equal(t, []Group{exotics}, s.Due(now.Add(140*time.Second)))
+}
+
+func TestScheduler_Throttle(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	majors := Group{Name: "majors", Currencies: []string{"USD"}, Interval: 10 * time.Second}
+	exotics := Group{Name: "exotics", Currencies: []string{"TRY"}, Interval: time.Minute}
+
+	// majors are slowed down, exotics are skipped
+	s := Scheduler{
+		Throttle: func(priority int) float64 {
+			if priority == 0 {
+				return 2
+			}
+
+			return 0
+		},
+	}
+
+	s.Apply(&Config{Groups: []Group{majors, exotics}}, now)
+
+	assert.Equal(t, []Group{majors}, s.Due(now))
+	assert.Empty(t, s.Due(now.Add(19*time.Second)))
+	assert.Equal(t, []Group{majors}, s.Due(now.Add(20*time.Second)))
+
+	// exotics are refreshed again once the throttling is lifted
+	s.Throttle = nil
+
+	assert.Equal(t, []Group{exotics, majors}, s.Due(now.Add(time.Minute)))
+}
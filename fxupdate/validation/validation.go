@@ -0,0 +1,242 @@
+// Package validation keeps the anomalous rates returned by FX sources (zero, negative, not finite, inconsistent with
+// their inverse, or jumping far from the previous value) out of the cache.
+package validation
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/lruggieri/fxnow/common/currency"
+	"github.com/lruggieri/fxnow/common/fxsource"
+	"github.com/lruggieri/fxnow/common/util"
+)
+
+const (
+	ReasonUndefined Reason = iota
+	ReasonNonPositive
+	ReasonNonFinite
+	ReasonInverse
+	ReasonJump
+)
+
+const (
+	// DefaultInverseTolerance : how far from 1 the product of a rate and its inverse can be
+	DefaultInverseTolerance = 0.01
+	// DefaultMaxJump : largest change in percent from the previous rate of a fiat pair
+	DefaultMaxJump = 10
+	// DefaultCryptoMaxJump : largest change in percent from the previous rate of a pair with a cryptocurrency leg
+	DefaultCryptoMaxJump = 25
+	// DefaultConfirmations : consecutive jumps of a pair after which its new level is accepted, so that genuine
+	// moves (e.g. a devaluation) are not rejected forever
+	DefaultConfirmations = 3
+)
+
+type Reason uint8
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonNonPositive:
+		return "non_positive"
+	case ReasonNonFinite:
+		return "non_finite"
+	case ReasonInverse:
+		return "inverse"
+	case ReasonJump:
+		return "jump"
+	default:
+		return "undefined"
+	}
+}
+
+type Rejection struct {
+	Rate   fxsource.Rate
+	Reason Reason
+	Detail string
+}
+
+type Validator interface {
+	// Validate : the rates that can be cached, and the rejected ones, whose previous value must be kept
+	Validate(rates []fxsource.Rate) ([]fxsource.Rate, []Rejection)
+	// Rejections : rates rejected since the validator was created, by reason
+	Rejections() map[string]int64
+	// Known : whether a previous rate of the pair has been accepted or seeded
+	Known(from, to string) bool
+	// Seed : sets the previous rate of a pair which is not known yet (e.g. from the cache after a restart), so that
+	// the first rates fetched are checked too
+	Seed(from, to string, rate float64)
+}
+
+type Impl struct {
+	// InverseTolerance : optional, defaults to DefaultInverseTolerance
+	InverseTolerance float64
+	// MaxJump : optional, largest change in percent of fiat pairs. Defaults to DefaultMaxJump.
+	MaxJump float64
+	// MaxJumps : optional, largest change in percent by currency (e.g. {"ARS": 30}). The most lenient threshold of
+	// the two legs of a pair applies.
+	MaxJumps map[string]float64
+	// Confirmations : optional, defaults to DefaultConfirmations
+	Confirmations int
+
+	mu sync.Mutex
+	// accepted : last accepted rate by pair
+	accepted map[string]float64
+	// jumps : consecutive jumps by pair
+	jumps      map[string]int
+	rejections map[Reason]int64
+}
+
+func (i *Impl) Validate(rates []fxsource.Rate) ([]fxsource.Rate, []Rejection) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.init()
+
+	byPair := make(map[string]float64, len(rates))
+	for _, rate := range rates {
+		byPair[util.PairFromCurrencies(rate.From, rate.To)] = rate.Rate
+	}
+
+	valid := make([]fxsource.Rate, 0, len(rates))
+
+	var rejections []Rejection
+
+	for _, rate := range rates {
+		reason, detail := i.check(rate, byPair)
+		if reason != ReasonUndefined {
+			i.rejections[reason]++
+			rejections = append(rejections, Rejection{Rate: rate, Reason: reason, Detail: detail})
+
+			continue
+		}
+
+		i.accepted[util.PairFromCurrencies(rate.From, rate.To)] = rate.Rate
+		valid = append(valid, rate)
+	}
+
+	return valid, rejections
+}
+
+func (i *Impl) Known(from, to string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	_, ok := i.accepted[util.PairFromCurrencies(from, to)]
+
+	return ok
+}
+
+func (i *Impl) Seed(from, to string, rate float64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.init()
+
+	pair := util.PairFromCurrencies(from, to)
+	if _, ok := i.accepted[pair]; ok || rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return
+	}
+
+	i.accepted[pair] = rate
+}
+
+func (i *Impl) init() {
+	if i.accepted == nil {
+		i.accepted = make(map[string]float64)
+		i.jumps = make(map[string]int)
+		i.rejections = make(map[Reason]int64)
+	}
+}
+
+func (i *Impl) Rejections() map[string]int64 {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	res := make(map[string]int64, len(i.rejections))
+	for reason, count := range i.rejections {
+		res[reason.String()] = count
+	}
+
+	return res
+}
+
+// check : ReasonUndefined if the rate is valid. Rates are checked against their inverse within the same batch, as
+// the inverse may have moved since it was fetched.
+func (i *Impl) check(rate fxsource.Rate, byPair map[string]float64) (Reason, string) {
+	switch {
+	case math.IsNaN(rate.Rate) || math.IsInf(rate.Rate, 0):
+		return ReasonNonFinite, fmt.Sprintf("rate is %v", rate.Rate)
+	case rate.Rate <= 0:
+		return ReasonNonPositive, fmt.Sprintf("rate is %v", rate.Rate)
+	}
+
+	inverse, ok := byPair[util.PairFromCurrencies(rate.To, rate.From)]
+	if ok && inverse > 0 && !math.IsInf(inverse, 0) {
+		if product := rate.Rate * inverse; math.Abs(product-1) > i.inverseTolerance() {
+			return ReasonInverse, fmt.Sprintf("product with the inverse is %v", product)
+		}
+	}
+
+	pair := util.PairFromCurrencies(rate.From, rate.To)
+
+	previous, ok := i.accepted[pair]
+	if !ok {
+		return ReasonUndefined, ""
+	}
+
+	change := math.Abs(rate.Rate-previous) / previous * 100
+	if change <= i.maxJump(rate.From, rate.To) {
+		i.jumps[pair] = 0
+
+		return ReasonUndefined, ""
+	}
+
+	i.jumps[pair]++
+
+	if i.jumps[pair] >= i.confirmations() {
+		i.jumps[pair] = 0
+
+		return ReasonUndefined, ""
+	}
+
+	return ReasonJump, fmt.Sprintf("%.2f%% change from %v", change, previous)
+}
+
+func (i *Impl) inverseTolerance() float64 {
+	if i.InverseTolerance > 0 {
+		return i.InverseTolerance
+	}
+
+	return DefaultInverseTolerance
+}
+
+func (i *Impl) confirmations() int {
+	if i.Confirmations > 0 {
+		return i.Confirmations
+	}
+
+	return DefaultConfirmations
+}
+
+// maxJump : the most lenient threshold of the two currencies
+func (i *Impl) maxJump(from, to string) float64 {
+	maxJump := 0.0
+
+	for _, c := range []string{from, to} {
+		threshold, ok := i.MaxJumps[c]
+
+		switch {
+		case ok:
+		case currency.IsCrypto(c):
+			threshold = DefaultCryptoMaxJump
+		case i.MaxJump > 0:
+			threshold = i.MaxJump
+		default:
+			threshold = DefaultMaxJump
+		}
+
+		maxJump = math.Max(maxJump, threshold)
+	}
+
+	return maxJump
+}
@@ -0,0 +1,137 @@
+package validation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lruggieri/fxnow/common/fxsource"
+)
+
+func TestImpl_Validate(t *testing.T) {
+	tests := []struct {
+		name               string
+		validator          func() *Impl
+		previous           []fxsource.Rate
+		rates              []fxsource.Rate
+		expectedValid      []fxsource.Rate
+		expectedRejections []Reason
+	}{
+		{
+			name:      "non-positive-and-non-finite",
+			validator: func() *Impl { return &Impl{} },
+			rates: []fxsource.Rate{
+				{From: "USD", To: "JPY", Rate: 0},
+				{From: "USD", To: "EUR", Rate: -0.9},
+				{From: "USD", To: "GBP", Rate: math.NaN()},
+				{From: "USD", To: "CHF", Rate: math.Inf(1)},
+				{From: "USD", To: "CAD", Rate: 1.37},
+			},
+			expectedValid:      []fxsource.Rate{{From: "USD", To: "CAD", Rate: 1.37}},
+			expectedRejections: []Reason{ReasonNonPositive, ReasonNonPositive, ReasonNonFinite, ReasonNonFinite},
+		},
+		{
+			name:      "inverse-inconsistent",
+			validator: func() *Impl { return &Impl{} },
+			rates: []fxsource.Rate{
+				{From: "EUR", To: "USD", Rate: 1.06},
+				{From: "USD", To: "EUR", Rate: 0.5},
+				{From: "USD", To: "JPY", Rate: 149.8},
+				{From: "JPY", To: "USD", Rate: 1 / 149.7},
+			},
+			expectedValid: []fxsource.Rate{
+				{From: "USD", To: "JPY", Rate: 149.8},
+				{From: "JPY", To: "USD", Rate: 1 / 149.7},
+			},
+			expectedRejections: []Reason{ReasonInverse, ReasonInverse},
+		},
+		{
+			name:      "jumps",
+			validator: func() *Impl { return &Impl{MaxJumps: map[string]float64{"ARS": 50}} },
+			previous: []fxsource.Rate{
+				{From: "USD", To: "JPY", Rate: 149.8},
+				{From: "USD", To: "ARS", Rate: 350},
+				{From: "BTC", To: "USD", Rate: 30000},
+				{From: "USD", To: "EUR", Rate: 0.94},
+			},
+			rates: []fxsource.Rate{
+				{From: "USD", To: "JPY", Rate: 14980},
+				{From: "USD", To: "ARS", Rate: 500},
+				{From: "BTC", To: "USD", Rate: 36000},
+				{From: "USD", To: "EUR", Rate: 0.95},
+			},
+			expectedValid: []fxsource.Rate{
+				{From: "USD", To: "ARS", Rate: 500},
+				{From: "BTC", To: "USD", Rate: 36000},
+				{From: "USD", To: "EUR", Rate: 0.95},
+			},
+			expectedRejections: []Reason{ReasonJump},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			v := tc.validator()
+
+			_, rejections := v.Validate(tc.previous)
+			assert.Empty(t, rejections)
+
+			valid, rejections := v.Validate(tc.rates)
+			assert.Equal(t, tc.expectedValid, valid)
+
+			reasons := make([]Reason, 0, len(rejections))
+			for _, rejection := range rejections {
+				reasons = append(reasons, rejection.Reason)
+			}
+
+			assert.Equal(t, tc.expectedRejections, reasons)
+		})
+	}
+}
+
+func TestImpl_Validate_confirmations(t *testing.T) {
+	v := &Impl{Confirmations: 2}
+
+	valid, _ := v.Validate([]fxsource.Rate{{From: "USD", To: "TRY", Rate: 20}})
+	assert.Len(t, valid, 1)
+
+	// a spike is rejected, and the previous value is the reference of the next rates
+	valid, _ = v.Validate([]fxsource.Rate{{From: "USD", To: "TRY", Rate: 2000}})
+	assert.Empty(t, valid)
+
+	valid, _ = v.Validate([]fxsource.Rate{{From: "USD", To: "TRY", Rate: 21}})
+	assert.Len(t, valid, 1)
+
+	// a new level is accepted once confirmed
+	valid, _ = v.Validate([]fxsource.Rate{{From: "USD", To: "TRY", Rate: 28}})
+	assert.Empty(t, valid)
+
+	valid, _ = v.Validate([]fxsource.Rate{{From: "USD", To: "TRY", Rate: 28.1}})
+	assert.Len(t, valid, 1)
+
+	assert.Equal(t, map[string]int64{"jump": 2}, v.Rejections())
+}
+
+func TestImpl_Seed(t *testing.T) {
+	v := &Impl{}
+
+	assert.False(t, v.Known("USD", "JPY"))
+
+	v.Seed("USD", "JPY", 149.8)
+	v.Seed("USD", "EUR", 0)
+	assert.True(t, v.Known("USD", "JPY"))
+	assert.False(t, v.Known("USD", "EUR"))
+
+	// the seeded rate is the reference of the first rates fetched
+	valid, rejections := v.Validate([]fxsource.Rate{{From: "USD", To: "JPY", Rate: 14980}})
+	assert.Empty(t, valid)
+	assert.Len(t, rejections, 1)
+
+	// accepted rates are not overwritten
+	v.Seed("USD", "JPY", 14980)
+
+	valid, _ = v.Validate([]fxsource.Rate{{From: "USD", To: "JPY", Rate: 150}})
+	assert.Len(t, valid, 1)
+}
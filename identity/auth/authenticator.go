@@ -11,27 +11,81 @@ const (
 )
 
 type Authenticator interface {
-	GetOIDCConsentURL(redirectURL string) string
-	AuthenticateOIDC(code string) (string, error)
+	// BeginOIDC : starts the login flow with the given provider (an empty provider means the default one), returning
+	// the URL of the provider consent page and a signed state to be kept by the client until the callback, usually
+	// in a short-lived cookie. redirectURL is where to send the user after login, and must be either a relative
+	// path or an URL towards an allowed host.
+	BeginOIDC(provider, redirectURL string) (consentURL, signedState string, err error)
+	// CompleteOIDC : checks the signed state against the one returned by the provider and exchanges the
+	// authorization code, returning a token that can be later validated through IsJWTValid and GetUserInfo. The
+	// user information of the returned token is available right away, without verifying it again.
+	CompleteOIDC(ctx context.Context, signedState, returnedState, code string) (*OIDCResult, error)
 
-	IsJWTValid(token string) bool
-	GetUserInfo(token string) *UserInfo
+	IsJWTValid(ctx context.Context, token string) bool
+	GetUserInfo(ctx context.Context, token string) *UserInfo
 }
 
 type Config struct {
-	OIDC OIDCConfig
+	Providers []ProviderConfig
+	// DefaultProvider : Optional. Name of the provider to use when none is specified. Defaults to the first one.
+	DefaultProvider string
+
+	// StateKey : key used to sign login states. If empty a random one is generated, meaning that login flows
+	// won't survive restarts and can't be completed on a different replica.
+	StateKey []byte
+	// AllowedRedirectHosts : hosts users can be redirected to after login, on top of relative paths
+	AllowedRedirectHosts []string
+}
+
+type OIDCResult struct {
+	Token string
+	// RedirectURL : where to send the user after login, if any
+	RedirectURL string
 }
 
-type OIDCConfig struct {
+// ProviderConfig : configuration of a single identity provider.
+//
+// When Issuer is set, the provider is treated as an OIDC provider: its endpoints are discovered from the issuer and
+// ID tokens are verified against its keys (e.g. Google, Microsoft Entra, GitLab, Keycloak). Discovery is skipped when
+// AuthURL, TokenURL and either JWKSURL or JWKSFile are set.
+// Otherwise, it is treated as a generic OAuth2 provider (e.g. GitHub) and AuthURL, TokenURL and UserInfoURL are
+// required.
+type ProviderConfig struct {
+	Name         string
+	Issuer       string
 	ClientID     string
 	ClientSecret string
 	RedirectURL  string
+	Scopes       []string
+
+	AuthURL  string
+	TokenURL string
+
+	// OIDC providers only
+	JWKSURL string
+	// JWKSFile : keys are loaded from this file and never refreshed, for environments without network access
+	JWKSFile string
+
+	// generic OAuth2 providers only
+	UserInfoURL string
+	// EmailsURL : Optional. Endpoint listing the user emails along with their verification status (GitHub style).
+	EmailsURL string
+
+	// TrustEmail : treat the email returned by the provider as verified, for providers not exposing the
+	// verification status (e.g. Microsoft Entra)
+	TrustEmail bool
+	// DisablePKCE : for providers not supporting PKCE
+	DisablePKCE bool
 }
 
 type UserInfo struct {
-	Email      string `json:"email"`
-	GivenName  string `json:"given_name"`
-	FamilyName string `json:"family_name"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+
+	// Provider : name of the provider that authenticated the user
+	Provider string `json:"-"`
 }
 
 func GetUserInfoFromContext(ctx context.Context) *UserInfo {
@@ -3,94 +3,158 @@ package auth
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/coreos/go-oidc/v3/oidc"
-	"golang.org/x/oauth2"
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/clock"
+	cError "github.com/lruggieri/fxnow/common/error"
+)
+
+const (
+	// ExchangeTimeout : how long the provider has to exchange an authorization code
+	ExchangeTimeout = 2 * time.Second
+
+	// tokenProviderSeparator : tokens are prefixed with the name of the provider that issued them
+	tokenProviderSeparator = ":"
 )
 
 type BasicAuthenticator struct {
-	ctx context.Context
+	providers       map[string]Provider
+	defaultProvider string
 
-	// OIDC
-	oauthConfig *oauth2.Config
-	provider    *oidc.Provider
-	verifier    *oidc.IDTokenVerifier
+	states *stateManager
+	claims *claimsCache
 }
 
-func (b *BasicAuthenticator) GetOIDCConsentURL(redirectURL string) string {
-	return b.oauthConfig.AuthCodeURL(redirectURL)
-}
+func (b *BasicAuthenticator) BeginOIDC(provider, redirectURL string) (consentURL, signedState string, err error) {
+	p, err := b.getProvider(provider)
+	if err != nil {
+		return "", "", err
+	}
 
-func (b *BasicAuthenticator) AuthenticateOIDC(code string) (string, error) {
-	// TODO make constant
-	ctx, cancel := context.WithTimeout(b.ctx, 2*time.Second)
-	defer cancel()
+	st, signedState, err := b.states.issue(p.Name(), redirectURL)
+	if err != nil {
+		return "", "", err
+	}
 
-	oauth2Token, err := b.oauthConfig.Exchange(ctx, code)
+	return p.ConsentURL(st.Nonce, codeChallenge(st.CodeVerifier)), signedState, nil
+}
+
+func (b *BasicAuthenticator) CompleteOIDC(
+	ctx context.Context, signedState, returnedState, code string,
+) (*OIDCResult, error) {
+	st, err := b.states.consume(signedState, returnedState)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Extract the ID token from the OAuth2 token
-	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
-	if !ok {
-		return "", fmt.Errorf("id_token not found")
+	p, err := b.getProvider(st.Provider)
+	if err != nil {
+		return nil, err
 	}
 
-	// Verify the ID token
-	_, err = b.verifier.Verify(ctx, rawIDToken)
+	ctx, cancel := context.WithTimeout(ctx, ExchangeTimeout)
+	defer cancel()
+
+	token, verified, err := p.Exchange(ctx, code, st.CodeVerifier)
 	if err != nil {
-		return "", fmt.Errorf("failed to verify ID token")
+		return nil, err
 	}
 
-	return rawIDToken, nil
+	token = p.Name() + tokenProviderSeparator + token
+
+	// the claims have just been verified, GetUserInfo doesn't need to verify the token again
+	b.claims.set(token, verified)
+
+	return &OIDCResult{
+		Token:       token,
+		RedirectURL: st.RedirectURL,
+	}, nil
 }
 
-func (b *BasicAuthenticator) IsJWTValid(token string) bool {
-	idToken, err := b.verifier.Verify(b.ctx, token)
-	if err != nil || idToken.Expiry.Before(time.Now()) {
-		return false
+func (b *BasicAuthenticator) IsJWTValid(ctx context.Context, token string) bool {
+	return b.GetUserInfo(ctx, token) != nil
+}
+
+func (b *BasicAuthenticator) GetUserInfo(ctx context.Context, token string) *UserInfo {
+	if userInfo := b.claims.get(token); userInfo != nil {
+		return userInfo
 	}
 
-	return true
-}
+	provider, rawToken, found := strings.Cut(token, tokenProviderSeparator)
+	if !found {
+		// tokens issued before multiple providers were supported
+		provider, rawToken = b.defaultProvider, token
+	}
 
-func (b *BasicAuthenticator) GetUserInfo(token string) *UserInfo {
-	idToken, err := b.verifier.Verify(b.ctx, token)
-	if err != nil || idToken.Expiry.Before(time.Now()) {
+	p, err := b.getProvider(provider)
+	if err != nil {
 		return nil
 	}
 
-	var userInfo UserInfo
-
-	if err = idToken.Claims(&userInfo); err != nil {
+	verified, err := p.Verify(ctx, rawToken)
+	if err != nil {
 		return nil
 	}
 
-	return &userInfo
+	b.claims.set(token, verified)
+
+	return verified.UserInfo
+}
+
+func (b *BasicAuthenticator) getProvider(name string) (Provider, error) {
+	if name == "" {
+		name = b.defaultProvider
+	}
+
+	p, ok := b.providers[name]
+	if !ok {
+		return nil, errors.Wrap(cError.ErrInvalidParameter, fmt.Sprintf("unknown provider '%s'", name))
+	}
+
+	return p, nil
 }
 
 func NewBasic(ctx context.Context, config Config) (Authenticator, error) {
-	provider, err := oidc.NewProvider(ctx, "https://accounts.google.com")
-	if err != nil {
-		return nil, err
+	if len(config.Providers) == 0 {
+		return nil, errors.New("at least one provider is required")
+	}
+
+	providers := make(map[string]Provider, len(config.Providers))
+
+	for _, providerConfig := range config.Providers {
+		if _, ok := providers[providerConfig.Name]; ok {
+			return nil, fmt.Errorf("duplicated provider '%s'", providerConfig.Name)
+		}
+
+		p, err := NewProvider(ctx, providerConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		providers[providerConfig.Name] = p
+	}
+
+	defaultProvider := config.DefaultProvider
+	if defaultProvider == "" {
+		defaultProvider = config.Providers[0].Name
 	}
 
-	verifier := provider.Verifier(&oidc.Config{ClientID: config.OIDC.ClientID})
+	if _, ok := providers[defaultProvider]; !ok {
+		return nil, fmt.Errorf("unknown default provider '%s'", defaultProvider)
+	}
 
-	oauthConfig := &oauth2.Config{
-		ClientID:     config.OIDC.ClientID,
-		ClientSecret: config.OIDC.ClientSecret,
-		RedirectURL:  config.OIDC.RedirectURL,
-		Endpoint:     provider.Endpoint(),
-		Scopes:       []string{oidc.ScopeOpenID, "profile", "email"},
+	states, err := newStateManager(config.StateKey, config.AllowedRedirectHosts)
+	if err != nil {
+		return nil, err
 	}
 
 	return &BasicAuthenticator{
-		ctx:         ctx,
-		oauthConfig: oauthConfig,
-		provider:    provider,
-		verifier:    verifier,
+		providers:       providers,
+		defaultProvider: defaultProvider,
+		states:          states,
+		claims:          newClaimsCache(clock.Default{}),
 	}, nil
 }
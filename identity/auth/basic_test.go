@@ -0,0 +1,543 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lruggieri/fxnow/common/clock"
+	cError "github.com/lruggieri/fxnow/common/error"
+)
+
+const (
+	testClientID = "client-id"
+	testCode     = "code"
+)
+
+// testIssuer : local stand-in for an OIDC issuer, returning ID tokens with the configured claims
+type testIssuer struct {
+	*httptest.Server
+
+	key    *rsa.PrivateKey
+	claims map[string]interface{}
+	// codeChallenge : if set, the PKCE code verifier is checked against it
+	codeChallenge string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ti := &testIssuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"issuer":                                ti.URL,
+			"authorization_endpoint":                ti.URL + "/auth",
+			"token_endpoint":                        ti.URL + "/token",
+			"jwks_uri":                              ti.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key:       &ti.key.PublicKey,
+			KeyID:     "test",
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != testCode ||
+			(ti.codeChallenge != "" && codeChallenge(r.FormValue("code_verifier")) != ti.codeChallenge) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		writeJSON(w, map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"id_token":     ti.sign(t, ti.claims),
+		})
+	})
+
+	ti.Server = httptest.NewServer(mux)
+	t.Cleanup(ti.Close)
+
+	ti.claims = ti.defaultClaims()
+
+	return ti
+}
+
+func (ti *testIssuer) defaultClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":            ti.URL,
+		"aud":            testClientID,
+		"sub":            "subject",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"email":          "user@domain.com",
+		"email_verified": true,
+		"given_name":     "name",
+		"family_name":    "surname",
+	}
+}
+
+func (ti *testIssuer) sign(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: ti.key},
+		(&jose.SignerOptions{}).WithHeader("kid", "test"),
+	)
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+
+	token, err := jws.CompactSerialize()
+	require.NoError(t, err)
+
+	return token
+}
+
+// newTestOAuth2Server : local stand-in for a GitHub-like OAuth2 provider
+func newTestOAuth2Server(t *testing.T, emailVerified bool) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != testCode {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		writeJSON(w, map[string]interface{}{"access_token": "gh-token", "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		writeJSON(w, map[string]interface{}{"login": "user", "name": "name surname"})
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{
+			{"email": "other@domain.com", "primary": false, "verified": true},
+			{"email": "user@domain.com", "primary": true, "verified": emailVerified},
+		})
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestAuthenticator(t *testing.T, issuer *testIssuer, oauth2Server *httptest.Server) Authenticator {
+	t.Helper()
+
+	a, err := NewBasic(context.Background(), Config{
+		Providers: []ProviderConfig{
+			{
+				Name:        "oidc",
+				Issuer:      issuer.URL,
+				ClientID:    testClientID,
+				RedirectURL: "https://fx-now.com/identity/callback/oidc",
+			},
+			{
+				Name:        "oauth2",
+				ClientID:    testClientID,
+				RedirectURL: "https://fx-now.com/identity/callback/oauth2",
+				AuthURL:     oauth2Server.URL + "/auth",
+				TokenURL:    oauth2Server.URL + "/token",
+				UserInfoURL: oauth2Server.URL + "/user",
+				EmailsURL:   oauth2Server.URL + "/user/emails",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	return a
+}
+
+func TestNewBasic(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	t.Run("error-no-providers", func(t *testing.T) {
+		_, err := NewBasic(context.Background(), Config{})
+		assert.Error(t, err)
+	})
+
+	t.Run("error-duplicated-provider", func(t *testing.T) {
+		_, err := NewBasic(context.Background(), Config{Providers: []ProviderConfig{
+			{Name: "oidc", Issuer: issuer.URL},
+			{Name: "oidc", Issuer: issuer.URL},
+		}})
+		assert.Error(t, err)
+	})
+
+	t.Run("error-unreachable-issuer", func(t *testing.T) {
+		_, err := NewBasic(context.Background(), Config{Providers: []ProviderConfig{
+			{Name: "oidc", Issuer: "http://127.0.0.1:1"},
+		}})
+		assert.Error(t, err)
+	})
+
+	t.Run("error-oauth2-missing-urls", func(t *testing.T) {
+		_, err := NewBasic(context.Background(), Config{Providers: []ProviderConfig{
+			{Name: "oauth2", AuthURL: "http://127.0.0.1/auth"},
+		}})
+		assert.Error(t, err)
+	})
+
+	t.Run("error-missing-jwks-file", func(t *testing.T) {
+		_, err := NewBasic(context.Background(), Config{Providers: []ProviderConfig{{
+			Name:     "oidc",
+			Issuer:   "http://127.0.0.1:1",
+			AuthURL:  "http://127.0.0.1:1/auth",
+			TokenURL: "http://127.0.0.1:1/token",
+			JWKSFile: "missing.json",
+		}}})
+		assert.Error(t, err)
+	})
+
+	t.Run("error-unknown-default-provider", func(t *testing.T) {
+		_, err := NewBasic(context.Background(), Config{
+			Providers:       []ProviderConfig{{Name: "oidc", Issuer: issuer.URL}},
+			DefaultProvider: "other",
+		})
+		assert.Error(t, err)
+	})
+}
+
+// login : goes through the whole login flow, as the provider would after the user consent
+func login(t *testing.T, a Authenticator, provider, code string) (*OIDCResult, error) {
+	t.Helper()
+
+	consentURL, signedState, err := a.BeginOIDC(provider, "/identity/v1/api-keys")
+	require.NoError(t, err)
+
+	u, err := url.Parse(consentURL)
+	require.NoError(t, err)
+
+	return a.CompleteOIDC(context.Background(), signedState, u.Query().Get("state"), code)
+}
+
+func TestBasicAuthenticator_BeginOIDC(t *testing.T) {
+	issuer := newTestIssuer(t)
+	a := newTestAuthenticator(t, issuer, newTestOAuth2Server(t, true))
+
+	t.Run("default-provider", func(t *testing.T) {
+		consentURL, signedState, err := a.BeginOIDC("", "/identity/v1/api-keys")
+		require.NoError(t, err)
+		assert.NotEmpty(t, signedState)
+
+		u, err := url.Parse(consentURL)
+		require.NoError(t, err)
+		assert.Equal(t, issuer.URL+"/auth", u.Scheme+"://"+u.Host+u.Path)
+		assert.Equal(t, testClientID, u.Query().Get("client_id"))
+		assert.Equal(t, "https://fx-now.com/identity/callback/oidc", u.Query().Get("redirect_uri"))
+		assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+		assert.NotEmpty(t, u.Query().Get("code_challenge"))
+
+		// only the nonce is sent to the provider, never the redirect URL
+		assert.NotEmpty(t, u.Query().Get("state"))
+		assert.NotContains(t, u.Query().Get("state"), "api-keys")
+	})
+
+	t.Run("named-provider", func(t *testing.T) {
+		consentURL, _, err := a.BeginOIDC("oauth2", "")
+		require.NoError(t, err)
+
+		u, err := url.Parse(consentURL)
+		require.NoError(t, err)
+		assert.Equal(t, "https://fx-now.com/identity/callback/oauth2", u.Query().Get("redirect_uri"))
+	})
+
+	t.Run("unknown-provider", func(t *testing.T) {
+		_, _, err := a.BeginOIDC("unknown", "")
+		assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+	})
+
+	t.Run("redirect-host-not-allowed", func(t *testing.T) {
+		_, _, err := a.BeginOIDC("", "https://evil.com/phishing")
+		assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+	})
+}
+
+func TestBasicAuthenticator_CompleteOIDC(t *testing.T) {
+	issuer := newTestIssuer(t)
+	a := newTestAuthenticator(t, issuer, newTestOAuth2Server(t, true))
+
+	t.Run("error-state-mismatch", func(t *testing.T) {
+		_, signedState, err := a.BeginOIDC("", "")
+		require.NoError(t, err)
+
+		_, err = a.CompleteOIDC(context.Background(), signedState, "forged", testCode)
+		assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+	})
+
+	t.Run("error-missing-state-cookie", func(t *testing.T) {
+		consentURL, _, err := a.BeginOIDC("", "")
+		require.NoError(t, err)
+
+		u, err := url.Parse(consentURL)
+		require.NoError(t, err)
+
+		_, err = a.CompleteOIDC(context.Background(), "", u.Query().Get("state"), testCode)
+		assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+	})
+
+	t.Run("error-state-reused", func(t *testing.T) {
+		consentURL, signedState, err := a.BeginOIDC("", "")
+		require.NoError(t, err)
+
+		u, err := url.Parse(consentURL)
+		require.NoError(t, err)
+
+		_, err = a.CompleteOIDC(context.Background(), signedState, u.Query().Get("state"), testCode)
+		require.NoError(t, err)
+
+		_, err = a.CompleteOIDC(context.Background(), signedState, u.Query().Get("state"), testCode)
+		assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+	})
+
+	t.Run("error-wrong-code-verifier", func(t *testing.T) {
+		issuer.codeChallenge = codeChallenge("other-verifier")
+		defer func() { issuer.codeChallenge = "" }()
+
+		_, err := login(t, a, "oidc", testCode)
+		assert.Error(t, err)
+	})
+
+	t.Run("happy-path-pkce", func(t *testing.T) {
+		consentURL, signedState, err := a.BeginOIDC("oidc", "")
+		require.NoError(t, err)
+
+		u, err := url.Parse(consentURL)
+		require.NoError(t, err)
+
+		issuer.codeChallenge = u.Query().Get("code_challenge")
+		defer func() { issuer.codeChallenge = "" }()
+
+		_, err = a.CompleteOIDC(context.Background(), signedState, u.Query().Get("state"), testCode)
+		assert.NoError(t, err)
+	})
+}
+
+func TestBasicAuthenticator_OIDC(t *testing.T) {
+	issuer := newTestIssuer(t)
+	a := newTestAuthenticator(t, issuer, newTestOAuth2Server(t, true))
+
+	t.Run("error-wrong-code", func(t *testing.T) {
+		_, err := login(t, a, "oidc", "wrong")
+		assert.Error(t, err)
+	})
+
+	t.Run("happy-path", func(t *testing.T) {
+		res, err := login(t, a, "oidc", testCode)
+		require.NoError(t, err)
+		assert.Equal(t, "/identity/v1/api-keys", res.RedirectURL)
+
+		assert.True(t, a.IsJWTValid(context.Background(), res.Token))
+		assert.Equal(t, &UserInfo{
+			Email:         "user@domain.com",
+			EmailVerified: true,
+			GivenName:     "name",
+			FamilyName:    "surname",
+			Provider:      "oidc",
+		}, a.GetUserInfo(context.Background(), res.Token))
+	})
+
+	t.Run("legacy-token-without-provider", func(t *testing.T) {
+		token := issuer.sign(t, issuer.defaultClaims())
+
+		assert.True(t, a.IsJWTValid(context.Background(), token))
+	})
+
+	t.Run("invalid-token", func(t *testing.T) {
+		assert.False(t, a.IsJWTValid(context.Background(), "oidc:invalid"))
+		assert.Nil(t, a.GetUserInfo(context.Background(), "oidc:invalid"))
+		assert.Nil(t, a.GetUserInfo(context.Background(), "unknown:"+issuer.sign(t, issuer.defaultClaims())))
+	})
+
+	t.Run("expired-token", func(t *testing.T) {
+		claims := issuer.defaultClaims()
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+
+		assert.False(t, a.IsJWTValid(context.Background(), "oidc:"+issuer.sign(t, claims)))
+	})
+
+	t.Run("wrong-audience", func(t *testing.T) {
+		claims := issuer.defaultClaims()
+		claims["aud"] = "other-client"
+
+		assert.False(t, a.IsJWTValid(context.Background(), "oidc:"+issuer.sign(t, claims)))
+	})
+
+	t.Run("unverified-email", func(t *testing.T) {
+		claims := issuer.defaultClaims()
+		claims["email_verified"] = false
+
+		assert.Nil(t, a.GetUserInfo(context.Background(), "oidc:"+issuer.sign(t, claims)))
+	})
+}
+
+func TestBasicAuthenticator_Offline(t *testing.T) {
+	issuer := newTestIssuer(t)
+	claims := issuer.defaultClaims()
+	token := issuer.sign(t, claims)
+	jwksFile := writeJWKSFile(t, jose.JSONWebKey{
+		Key:       &issuer.key.PublicKey,
+		KeyID:     "test",
+		Algorithm: string(jose.RS256),
+		Use:       "sig",
+	})
+
+	// from now on the provider is unreachable
+	issuer.Close()
+
+	a, err := NewBasic(context.Background(), Config{Providers: []ProviderConfig{{
+		Name:     "oidc",
+		Issuer:   issuer.URL,
+		ClientID: testClientID,
+		AuthURL:  issuer.URL + "/auth",
+		TokenURL: issuer.URL + "/token",
+		JWKSFile: jwksFile,
+	}}})
+	require.NoError(t, err)
+
+	assert.Equal(t, &UserInfo{
+		Email:         "user@domain.com",
+		EmailVerified: true,
+		GivenName:     "name",
+		FamilyName:    "surname",
+		Provider:      "oidc",
+	}, a.GetUserInfo(context.Background(), "oidc:"+token))
+
+	claims["iss"] = "https://other-issuer.com"
+	assert.False(t, a.IsJWTValid(context.Background(), "oidc:"+issuer.sign(t, claims)))
+}
+
+func TestBasicAuthenticator_Cache(t *testing.T) {
+	var requests int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"access_token": "gh-token", "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("Authorization") != "Bearer gh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		writeJSON(w, map[string]interface{}{"email": "user@domain.com", "email_verified": true})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	a, err := NewBasic(context.Background(), Config{Providers: []ProviderConfig{{
+		Name:        "oauth2",
+		AuthURL:     server.URL + "/auth",
+		TokenURL:    server.URL + "/token",
+		UserInfoURL: server.URL + "/user",
+	}}})
+	require.NoError(t, err)
+
+	// the token is verified once, then served from the cache
+	assert.True(t, a.IsJWTValid(context.Background(), "oauth2:gh-token"))
+	assert.NotNil(t, a.GetUserInfo(context.Background(), "oauth2:gh-token"))
+	assert.Equal(t, 1, requests)
+
+	// invalid tokens are not cached
+	assert.False(t, a.IsJWTValid(context.Background(), "oauth2:wrong"))
+	assert.False(t, a.IsJWTValid(context.Background(), "oauth2:wrong"))
+	assert.Equal(t, 3, requests)
+
+	// the claims verified at login are cached too
+	a.(*BasicAuthenticator).claims = newClaimsCache(clock.Default{})
+
+	res, err := login(t, a, "oauth2", testCode)
+	require.NoError(t, err)
+	assert.NotNil(t, a.GetUserInfo(context.Background(), res.Token))
+	assert.Equal(t, 4, requests)
+}
+
+func TestBasicAuthenticator_OAuth2(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	t.Run("happy-path", func(t *testing.T) {
+		a := newTestAuthenticator(t, issuer, newTestOAuth2Server(t, true))
+
+		res, err := login(t, a, "oauth2", testCode)
+		require.NoError(t, err)
+		assert.Equal(t, "oauth2:gh-token", res.Token)
+
+		assert.Equal(t, &UserInfo{
+			Email:         "user@domain.com",
+			EmailVerified: true,
+			GivenName:     "name",
+			FamilyName:    "surname",
+			Provider:      "oauth2",
+		}, a.GetUserInfo(context.Background(), res.Token))
+	})
+
+	t.Run("invalid-token", func(t *testing.T) {
+		a := newTestAuthenticator(t, issuer, newTestOAuth2Server(t, true))
+
+		assert.False(t, a.IsJWTValid(context.Background(), "oauth2:wrong"))
+	})
+
+	t.Run("unverified-email", func(t *testing.T) {
+		a := newTestAuthenticator(t, issuer, newTestOAuth2Server(t, false))
+
+		_, err := login(t, a, "oauth2", testCode)
+		assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+	})
+}
+
+func TestPresetProviderConfig(t *testing.T) {
+	assert.Equal(t, "https://accounts.google.com", PresetProviderConfig(ProviderGoogle).Issuer)
+	assert.Equal(t, "https://gitlab.com", PresetProviderConfig(ProviderGitLab).Issuer)
+	assert.Empty(t, PresetProviderConfig(ProviderGitHub).Issuer)
+	assert.NotEmpty(t, PresetProviderConfig(ProviderGitHub).UserInfoURL)
+	assert.Equal(t, ProviderConfig{Name: "keycloak"}, PresetProviderConfig("keycloak"))
+}
+
+func TestDiscoveryURL(t *testing.T) {
+	assert.Equal(t, "https://gitlab.com/.well-known/openid-configuration",
+		DiscoveryURL(PresetProviderConfig(ProviderGitLab)))
+	assert.Equal(t, "https://keycloak.example.com/realms/fxnow/.well-known/openid-configuration",
+		DiscoveryURL(ProviderConfig{Name: "keycloak", Issuer: "https://keycloak.example.com/realms/fxnow/"}))
+
+	// endpoints are not discovered
+	assert.Empty(t, DiscoveryURL(PresetProviderConfig(ProviderGoogle)))
+	assert.Empty(t, DiscoveryURL(PresetProviderConfig(ProviderGitHub)))
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/lruggieri/fxnow/common/clock"
+)
+
+const (
+	// MaxCachedTokens : beyond this, expired entries are purged, and the whole cache is dropped if still full
+	MaxCachedTokens = 10000
+	// UnboundTokenCacheTTL : how long tokens without a known expiration (e.g. OAuth2 access tokens) are cached
+	UnboundTokenCacheTTL = time.Minute
+)
+
+// claimsCache : verified tokens are cached until they expire, to verify each token once
+type claimsCache struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*VerifiedToken
+}
+
+func (c *claimsCache) get(token string) *UserInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := sha256.Sum256([]byte(token))
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	if !c.clock.Now().Before(entry.Expiration) {
+		delete(c.entries, key)
+		return nil
+	}
+
+	// callers must not be able to alter the cached claims
+	userInfo := *entry.UserInfo
+
+	return &userInfo
+}
+
+func (c *claimsCache) set(token string, verified *VerifiedToken) {
+	now := c.clock.Now()
+
+	expiration := verified.Expiration
+	if expiration.IsZero() {
+		expiration = now.Add(UnboundTokenCacheTTL)
+	}
+
+	if !now.Before(expiration) {
+		return
+	}
+
+	userInfo := *verified.UserInfo
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= MaxCachedTokens {
+		c.purge(now)
+	}
+
+	c.entries[sha256.Sum256([]byte(token))] = &VerifiedToken{
+		UserInfo:   &userInfo,
+		Expiration: expiration,
+	}
+}
+
+// purge : removes the expired entries, or everything if none is expired. Must be called holding the lock.
+func (c *claimsCache) purge(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.Expiration) {
+			delete(c.entries, key)
+		}
+	}
+
+	if len(c.entries) >= MaxCachedTokens {
+		c.entries = make(map[[sha256.Size]byte]*VerifiedToken)
+	}
+}
+
+func newClaimsCache(clk clock.Clock) *claimsCache {
+	return &claimsCache{
+		clock:   clk,
+		entries: make(map[[sha256.Size]byte]*VerifiedToken),
+	}
+}
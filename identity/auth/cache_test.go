@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+)
+
+func TestClaimsCache(t *testing.T) {
+	now := time.Now()
+
+	newCache := func(t *testing.T, at *time.Time) *claimsCache {
+		c := mockclock.NewClock(t)
+		c.EXPECT().Now().RunAndReturn(func() time.Time { return *at }).Maybe()
+
+		return newClaimsCache(c)
+	}
+
+	t.Run("miss", func(t *testing.T) {
+		at := now
+		c := newCache(t, &at)
+
+		assert.Nil(t, c.get("token"))
+	})
+
+	t.Run("hit-until-expiration", func(t *testing.T) {
+		at := now
+		c := newCache(t, &at)
+
+		c.set("token", &VerifiedToken{UserInfo: &UserInfo{Email: "user@domain.com"}, Expiration: now.Add(time.Hour)})
+		assert.Equal(t, &UserInfo{Email: "user@domain.com"}, c.get("token"))
+		assert.Nil(t, c.get("other-token"))
+
+		at = now.Add(time.Hour)
+		assert.Nil(t, c.get("token"))
+	})
+
+	t.Run("unbound-token", func(t *testing.T) {
+		at := now
+		c := newCache(t, &at)
+
+		c.set("token", &VerifiedToken{UserInfo: &UserInfo{Email: "user@domain.com"}})
+		assert.NotNil(t, c.get("token"))
+
+		at = now.Add(UnboundTokenCacheTTL)
+		assert.Nil(t, c.get("token"))
+	})
+
+	t.Run("expired-token-not-cached", func(t *testing.T) {
+		at := now
+		c := newCache(t, &at)
+
+		c.set("token", &VerifiedToken{UserInfo: &UserInfo{Email: "user@domain.com"}, Expiration: now})
+		assert.Nil(t, c.get("token"))
+	})
+
+	t.Run("cached-claims-cannot-be-altered", func(t *testing.T) {
+		at := now
+		c := newCache(t, &at)
+
+		userInfo := &UserInfo{Email: "user@domain.com"}
+		c.set("token", &VerifiedToken{UserInfo: userInfo, Expiration: now.Add(time.Hour)})
+
+		userInfo.Email = "other@domain.com"
+		c.get("token").Email = "other@domain.com"
+
+		assert.Equal(t, "user@domain.com", c.get("token").Email)
+	})
+
+	t.Run("purge-when-full", func(t *testing.T) {
+		at := now
+		c := newCache(t, &at)
+
+		// the i-th token expires after i+1 seconds
+		for i := 0; i < MaxCachedTokens; i++ {
+			c.set(strconv.Itoa(i), &VerifiedToken{UserInfo: &UserInfo{}, Expiration: now.Add(time.Duration(i+1) * time.Second)})
+		}
+
+		at = now.Add(time.Duration(MaxCachedTokens/2) * time.Second)
+		c.set("token", &VerifiedToken{UserInfo: &UserInfo{}, Expiration: at.Add(time.Hour)})
+
+		assert.Equal(t, MaxCachedTokens/2+1, len(c.entries))
+		assert.NotNil(t, c.get("token"))
+	})
+}
@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/pkg/errors"
+)
+
+const (
+	// JWKSRefreshInterval : how often remote key sets are refreshed in the background
+	JWKSRefreshInterval = time.Hour
+	// jwksMinRefreshInterval : minimum delay between refreshes triggered by tokens signed with unknown keys
+	jwksMinRefreshInterval = time.Minute
+	jwksFetchTimeout       = 5 * time.Second
+)
+
+// keySet : provider signing keys, implementing oidc.KeySet. Remote key sets are fetched once and kept up to date
+// in the background, so token verification never waits for the network. Static key sets never change.
+type keySet struct {
+	url string
+
+	mu      sync.RWMutex
+	keys    []jose.JSONWebKey
+	lastErr error
+
+	refresh chan struct{}
+}
+
+func (k *keySet) VerifySignature(_ context.Context, jwt string) ([]byte, error) {
+	jws, err := jose.ParseSigned(jwt)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed jwt")
+	}
+
+	var keyID string
+	if len(jws.Signatures) > 0 {
+		keyID = jws.Signatures[0].Header.KeyID
+	}
+
+	k.mu.RLock()
+	keys, lastErr := k.keys, k.lastErr
+	k.mu.RUnlock()
+
+	for i := range keys {
+		if keyID != "" && keys[i].KeyID != keyID {
+			continue
+		}
+
+		if payload, verifyErr := jws.Verify(&keys[i]); verifyErr == nil {
+			return payload, nil
+		}
+	}
+
+	// the provider might have rotated its keys
+	k.requestRefresh()
+
+	if lastErr != nil {
+		return nil, errors.Wrap(lastErr, "failed to verify signature, keys might be stale")
+	}
+
+	return nil, errors.New("failed to verify signature")
+}
+
+func (k *keySet) requestRefresh() {
+	if k.refresh == nil {
+		return
+	}
+
+	select {
+	case k.refresh <- struct{}{}:
+	default:
+		// a refresh is already pending
+	}
+}
+
+// run : keeps the key set up to date until the context is done
+func (k *keySet) run(ctx context.Context) {
+	ticker := time.NewTicker(JWKSRefreshInterval)
+	defer ticker.Stop()
+
+	var lastRequested time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			k.fetch(ctx)
+		case <-k.refresh:
+			// anyone can send tokens with made up key IDs, do not let them hammer the provider
+			if time.Since(lastRequested) >= jwksMinRefreshInterval {
+				lastRequested = time.Now()
+
+				k.fetch(ctx)
+			}
+		}
+	}
+}
+
+// fetch : replaces the keys with the ones currently published by the provider. On failure, the previous keys are
+// kept.
+func (k *keySet) fetch(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	keys, err := fetchJWKS(ctx, k.url)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.lastErr = err
+
+	if err == nil {
+		k.keys = keys
+	}
+}
+
+func fetchJWKS(ctx context.Context, url string) ([]jose.JSONWebKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot fetch jwks")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks endpoint returned status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read jwks")
+	}
+
+	return parseJWKS(body)
+}
+
+func parseJWKS(data []byte) ([]jose.JSONWebKey, error) {
+	var jwks jose.JSONWebKeySet
+
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, errors.Wrap(err, "invalid jwks")
+	}
+
+	if len(jwks.Keys) == 0 {
+		return nil, errors.New("empty jwks")
+	}
+
+	return jwks.Keys, nil
+}
+
+// newRemoteKeySet : fetches the keys once, then refreshes them in the background until the context is done.
+// A failing first fetch does not prevent startup, it is retried in the background.
+func newRemoteKeySet(ctx context.Context, url string) *keySet {
+	k := &keySet{
+		url:     url,
+		refresh: make(chan struct{}, 1),
+	}
+
+	k.fetch(ctx)
+
+	go k.run(ctx)
+
+	return k
+}
+
+// newStaticKeySet : key set loaded from a JWKS file, for environments without access to the provider
+func newStaticKeySet(file string) (*keySet, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read jwks file")
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keySet{keys: keys}, nil
+}
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKey(t *testing.T, keyID string) (*rsa.PrivateKey, jose.JSONWebKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return key, jose.JSONWebKey{Key: &key.PublicKey, KeyID: keyID, Algorithm: string(jose.RS256), Use: "sig"}
+}
+
+func signPayload(t *testing.T, key *rsa.PrivateKey, keyID, payload string) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithHeader("kid", keyID),
+	)
+	require.NoError(t, err)
+
+	jws, err := signer.Sign([]byte(payload))
+	require.NoError(t, err)
+
+	token, err := jws.CompactSerialize()
+	require.NoError(t, err)
+
+	return token
+}
+
+// writeJWKSFile : writes the public keys to a JWKS file, returning its path
+func writeJWKSFile(t *testing.T, keys ...jose.JSONWebKey) string {
+	t.Helper()
+
+	data, err := json.Marshal(jose.JSONWebKeySet{Keys: keys})
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+
+	return file
+}
+
+func TestStaticKeySet(t *testing.T) {
+	key, jwk := newTestKey(t, "key")
+	otherKey, _ := newTestKey(t, "other")
+
+	t.Run("error-missing-file", func(t *testing.T) {
+		_, err := newStaticKeySet(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+
+	t.Run("error-empty-jwks", func(t *testing.T) {
+		_, err := newStaticKeySet(writeJWKSFile(t))
+		assert.Error(t, err)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		ks, err := newStaticKeySet(writeJWKSFile(t, jwk))
+		require.NoError(t, err)
+
+		payload, err := ks.VerifySignature(context.Background(), signPayload(t, key, "key", "payload"))
+		require.NoError(t, err)
+		assert.Equal(t, "payload", string(payload))
+
+		_, err = ks.VerifySignature(context.Background(), signPayload(t, otherKey, "key", "payload"))
+		assert.Error(t, err)
+
+		_, err = ks.VerifySignature(context.Background(), signPayload(t, otherKey, "other", "payload"))
+		assert.Error(t, err)
+
+		_, err = ks.VerifySignature(context.Background(), "malformed")
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoteKeySet(t *testing.T) {
+	key, jwk := newTestKey(t, "key")
+	rotatedKey, rotatedJWK := newTestKey(t, "rotated")
+
+	var (
+		mu       sync.Mutex
+		keys     = []jose.JSONWebKey{jwk}
+		requests int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests++
+
+		writeJSON(w, jose.JSONWebKeySet{Keys: keys})
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ks := newRemoteKeySet(ctx, server.URL)
+
+	// keys are fetched once, not at every verification
+	for i := 0; i < 3; i++ {
+		_, err := ks.VerifySignature(ctx, signPayload(t, key, "key", "payload"))
+		require.NoError(t, err)
+	}
+
+	mu.Lock()
+	assert.Equal(t, 1, requests)
+	keys = []jose.JSONWebKey{jwk, rotatedJWK}
+	mu.Unlock()
+
+	// a token signed with an unknown key triggers a background refresh
+	token := signPayload(t, rotatedKey, "rotated", "payload")
+
+	_, err := ks.VerifySignature(ctx, token)
+	assert.Error(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, err = ks.VerifySignature(ctx, token)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRemoteKeySet_Unreachable(t *testing.T) {
+	key, _ := newTestKey(t, "key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// startup does not depend on the provider availability
+	ks := newRemoteKeySet(ctx, "http://127.0.0.1:1/keys")
+
+	_, err := ks.VerifySignature(ctx, signPayload(t, key, "key", "payload"))
+	assert.ErrorContains(t, err, "keys might be stale")
+}
@@ -0,0 +1,416 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+)
+
+const (
+	ProviderGoogle = "google"
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// Provider : identity provider able to authenticate users through the authorization code flow
+type Provider interface {
+	Name() string
+	// ConsentURL : URL of the consent page. codeChallenge is the PKCE S256 challenge, ignored if PKCE is disabled.
+	ConsentURL(state, codeChallenge string) string
+	// Exchange : exchanges the authorization code for a token that can be validated through Verify, returned along
+	// with its claims, already verified. codeVerifier is the PKCE verifier, ignored if PKCE is disabled.
+	Exchange(ctx context.Context, code, codeVerifier string) (string, *VerifiedToken, error)
+	// Verify : validates the token, returning the information of the user it belongs to
+	Verify(ctx context.Context, token string) (*VerifiedToken, error)
+}
+
+// VerifiedToken : claims of a valid token
+type VerifiedToken struct {
+	UserInfo *UserInfo
+	// Expiration : zero if the provider does not expose it
+	Expiration time.Time
+}
+
+// PresetProviderConfig : returns the well-known endpoints of common providers, to be completed with client
+// credentials. Returns a config with only the name set for unknown providers.
+func PresetProviderConfig(name string) ProviderConfig {
+	switch name {
+	case ProviderGoogle:
+		return ProviderConfig{
+			Name:     name,
+			Issuer:   "https://accounts.google.com",
+			AuthURL:  "https://accounts.google.com/o/oauth2/v2/auth",
+			TokenURL: "https://oauth2.googleapis.com/token",
+			JWKSURL:  "https://www.googleapis.com/oauth2/v3/certs",
+		}
+	case ProviderGitLab:
+		return ProviderConfig{
+			Name:   name,
+			Issuer: "https://gitlab.com",
+		}
+	case ProviderGitHub:
+		return ProviderConfig{
+			Name:        name,
+			AuthURL:     "https://github.com/login/oauth/authorize",
+			TokenURL:    "https://github.com/login/oauth/access_token",
+			UserInfoURL: "https://api.github.com/user",
+			EmailsURL:   "https://api.github.com/user/emails",
+			Scopes:      []string{"read:user", "user:email"},
+		}
+	default:
+		return ProviderConfig{Name: name}
+	}
+}
+
+// NewProvider : creates an OIDC provider if the issuer is set, a generic OAuth2 one otherwise
+func NewProvider(ctx context.Context, config ProviderConfig) (Provider, error) {
+	if config.Name == "" {
+		return nil, errors.New("provider name is required")
+	}
+
+	if config.Issuer != "" {
+		return NewOIDCProvider(ctx, config)
+	}
+
+	return NewOAuth2Provider(config)
+}
+
+type OIDCProvider struct {
+	name        string
+	trustEmail  bool
+	pkce        pkce
+	oauthConfig *oauth2.Config
+	verifier    *oidc.IDTokenVerifier
+}
+
+func (p *OIDCProvider) Name() string {
+	return p.name
+}
+
+func (p *OIDCProvider) ConsentURL(state, codeChallenge string) string {
+	return p.oauthConfig.AuthCodeURL(state, p.pkce.challengeOptions(codeChallenge)...)
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier string) (string, *VerifiedToken, error) {
+	oauth2Token, err := p.oauthConfig.Exchange(ctx, code, p.pkce.verifierOptions(codeVerifier)...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Extract the ID token from the OAuth2 token
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok {
+		return "", nil, fmt.Errorf("id_token not found")
+	}
+
+	// Verify the ID token
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to verify ID token")
+	}
+
+	verified, err := p.claims(idToken)
+	if err != nil {
+		return "", nil, errors.Wrap(cError.ErrNotAuthenticated, err.Error())
+	}
+
+	return rawIDToken, verified, nil
+}
+
+func (p *OIDCProvider) Verify(ctx context.Context, token string) (*VerifiedToken, error) {
+	idToken, err := p.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.claims(idToken)
+}
+
+// claims : information of the user a verified ID token belongs to
+func (p *OIDCProvider) claims(idToken *oidc.IDToken) (*VerifiedToken, error) {
+	var userInfo UserInfo
+
+	if err := idToken.Claims(&userInfo); err != nil {
+		return nil, err
+	}
+
+	if _, err := finalizeUserInfo(&userInfo, p.name, p.trustEmail); err != nil {
+		return nil, err
+	}
+
+	return &VerifiedToken{
+		UserInfo:   &userInfo,
+		Expiration: idToken.Expiry,
+	}, nil
+}
+
+// NewOIDCProvider : the provider endpoints and keys are discovered from the issuer, unless AuthURL, TokenURL and
+// either JWKSURL or JWKSFile are set. With JWKSFile, no network access is needed until users log in.
+func NewOIDCProvider(ctx context.Context, config ProviderConfig) (*OIDCProvider, error) {
+	endpoint, keys, algs, err := oidcKeys(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "profile", "email"}
+	}
+
+	return &OIDCProvider{
+		name:       config.Name,
+		trustEmail: config.TrustEmail,
+		pkce:       pkce(!config.DisablePKCE),
+		oauthConfig: &oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Endpoint:     endpoint,
+			Scopes:       scopes,
+		},
+		verifier: oidc.NewVerifier(config.Issuer, keys, &oidc.Config{
+			ClientID:             config.ClientID,
+			SupportedSigningAlgs: algs,
+		}),
+	}, nil
+}
+
+// oidcKeys : resolves the endpoints, signing keys and signing algorithms of an OIDC provider
+func oidcKeys(ctx context.Context, config ProviderConfig) (oauth2.Endpoint, *keySet, []string, error) {
+	if staticEndpoints(config) {
+		endpoint := oauth2.Endpoint{AuthURL: config.AuthURL, TokenURL: config.TokenURL}
+
+		if config.JWKSFile != "" {
+			keys, err := newStaticKeySet(config.JWKSFile)
+			if err != nil {
+				return oauth2.Endpoint{}, nil, nil, errors.Wrapf(err, "cannot load keys of provider '%s'", config.Name)
+			}
+
+			return endpoint, keys, nil, nil
+		}
+
+		return endpoint, newRemoteKeySet(ctx, config.JWKSURL), nil, nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, config.Issuer)
+	if err != nil {
+		return oauth2.Endpoint{}, nil, nil, errors.Wrapf(err, "cannot discover provider '%s'", config.Name)
+	}
+
+	var discovery struct {
+		JWKSURL string   `json:"jwks_uri"`
+		Algs    []string `json:"id_token_signing_alg_values_supported"`
+	}
+
+	if err = provider.Claims(&discovery); err != nil {
+		return oauth2.Endpoint{}, nil, nil, errors.Wrapf(err, "invalid discovery document of provider '%s'", config.Name)
+	}
+
+	return provider.Endpoint(), newRemoteKeySet(ctx, discovery.JWKSURL), discovery.Algs, nil
+}
+
+// DiscoveryURL : URL of the discovery document of the provider, empty if its endpoints are not discovered
+func DiscoveryURL(config ProviderConfig) string {
+	if config.Issuer == "" || staticEndpoints(config) {
+		return ""
+	}
+
+	return strings.TrimSuffix(config.Issuer, "/") + "/.well-known/openid-configuration"
+}
+
+// staticEndpoints : whether the endpoints and keys of an OIDC provider are configured, skipping the discovery
+func staticEndpoints(config ProviderConfig) bool {
+	return config.AuthURL != "" && config.TokenURL != "" && (config.JWKSURL != "" || config.JWKSFile != "")
+}
+
+// OAuth2Provider : adapter for providers not supporting OIDC. The access token is used as the user token, and user
+// information are fetched from the provider at every validation not served by the authenticator cache.
+type OAuth2Provider struct {
+	name        string
+	trustEmail  bool
+	pkce        pkce
+	oauthConfig *oauth2.Config
+	userInfoURL string
+	emailsURL   string
+}
+
+func (p *OAuth2Provider) Name() string {
+	return p.name
+}
+
+func (p *OAuth2Provider) ConsentURL(state, codeChallenge string) string {
+	return p.oauthConfig.AuthCodeURL(state, p.pkce.challengeOptions(codeChallenge)...)
+}
+
+func (p *OAuth2Provider) Exchange(ctx context.Context, code, codeVerifier string) (string, *VerifiedToken, error) {
+	oauth2Token, err := p.oauthConfig.Exchange(ctx, code, p.pkce.verifierOptions(codeVerifier)...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if oauth2Token.AccessToken == "" {
+		return "", nil, fmt.Errorf("access_token not found")
+	}
+
+	verified, err := p.Verify(ctx, oauth2Token.AccessToken)
+	if err != nil {
+		return "", nil, errors.Wrap(cError.ErrNotAuthenticated, err.Error())
+	}
+
+	return oauth2Token.AccessToken, verified, nil
+}
+
+func (p *OAuth2Provider) Verify(ctx context.Context, token string) (*VerifiedToken, error) {
+	var profile struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		GivenName     string `json:"given_name"`
+		FamilyName    string `json:"family_name"`
+		Name          string `json:"name"`
+	}
+
+	if err := p.get(ctx, p.userInfoURL, token, &profile); err != nil {
+		return nil, err
+	}
+
+	userInfo := &UserInfo{
+		Email:         profile.Email,
+		EmailVerified: profile.EmailVerified,
+		GivenName:     profile.GivenName,
+		FamilyName:    profile.FamilyName,
+	}
+
+	// some providers only expose the full name
+	if userInfo.GivenName == "" && userInfo.FamilyName == "" {
+		userInfo.GivenName, userInfo.FamilyName, _ = strings.Cut(strings.TrimSpace(profile.Name), " ")
+	}
+
+	if p.emailsURL != "" {
+		var emails []struct {
+			Email    string `json:"email"`
+			Primary  bool   `json:"primary"`
+			Verified bool   `json:"verified"`
+		}
+
+		if err := p.get(ctx, p.emailsURL, token, &emails); err != nil {
+			return nil, err
+		}
+
+		for _, e := range emails {
+			if e.Primary {
+				userInfo.Email = e.Email
+				userInfo.EmailVerified = e.Verified
+			}
+		}
+	}
+
+	if _, err := finalizeUserInfo(userInfo, p.name, p.trustEmail); err != nil {
+		return nil, err
+	}
+
+	// access tokens are opaque, their expiration is unknown
+	return &VerifiedToken{UserInfo: userInfo}, nil
+}
+
+func (p *OAuth2Provider) get(ctx context.Context, url, token string, resp interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Add("accept", "application/json")
+	httpReq.Header.Add("authorization", "Bearer "+token)
+
+	httpResp, err := oauth2.NewClient(ctx, nil).Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("provider '%s' returned status code %d", p.name, httpResp.StatusCode)
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Wrap(err, "cannot read response body")
+	}
+
+	return errors.Wrap(json.Unmarshal(body, resp), "invalid provider response")
+}
+
+func NewOAuth2Provider(config ProviderConfig) (*OAuth2Provider, error) {
+	if config.AuthURL == "" || config.TokenURL == "" || config.UserInfoURL == "" {
+		return nil, fmt.Errorf("provider '%s' requires either an issuer or auth, token and user info URLs", config.Name)
+	}
+
+	return &OAuth2Provider{
+		name:       config.Name,
+		trustEmail: config.TrustEmail,
+		pkce:       pkce(!config.DisablePKCE),
+		oauthConfig: &oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  config.AuthURL,
+				TokenURL: config.TokenURL,
+			},
+			Scopes: config.Scopes,
+		},
+		userInfoURL: config.UserInfoURL,
+		emailsURL:   config.EmailsURL,
+	}, nil
+}
+
+// pkce : whether PKCE (RFC 7636) is enabled for the provider
+type pkce bool
+
+func (p pkce) challengeOptions(codeChallenge string) []oauth2.AuthCodeOption {
+	if !p || codeChallenge == "" {
+		return nil
+	}
+
+	return []oauth2.AuthCodeOption{
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	}
+}
+
+func (p pkce) verifierOptions(codeVerifier string) []oauth2.AuthCodeOption {
+	if !p || codeVerifier == "" {
+		return nil
+	}
+
+	return []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("code_verifier", codeVerifier)}
+}
+
+// finalizeUserInfo : users are linked across providers by email, therefore only verified emails are accepted
+func finalizeUserInfo(userInfo *UserInfo, provider string, trustEmail bool) (*UserInfo, error) {
+	if userInfo.Email == "" {
+		return nil, fmt.Errorf("provider '%s' did not return an email", provider)
+	}
+
+	if trustEmail {
+		userInfo.EmailVerified = true
+	}
+
+	if !userInfo.EmailVerified {
+		return nil, fmt.Errorf("email not verified by provider '%s'", provider)
+	}
+
+	userInfo.Provider = provider
+
+	return userInfo, nil
+}
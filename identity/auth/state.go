@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/clock"
+	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/util"
+)
+
+const (
+	// StateLifetime : maximum time between the start of the login flow and the provider callback
+	StateLifetime = 10 * time.Minute
+
+	stateKeyLength   = 32
+	stateNonceLength = 32
+	stateSeparator   = "."
+)
+
+var errInvalidState = errors.Wrap(cError.ErrNotAuthorized, "invalid oauth state")
+
+// oauthState : state of a login flow, handed to the client in signed form (e.g. through a short-lived cookie) and
+// checked against the state returned by the provider in the callback.
+// Only the nonce is sent to the provider.
+type oauthState struct {
+	Nonce        string `json:"n"`
+	Provider     string `json:"p"`
+	RedirectURL  string `json:"r,omitempty"`
+	CodeVerifier string `json:"v,omitempty"`
+	Expiration   int64  `json:"e"` // unix (s)
+}
+
+// stateManager : signs and verifies login states. States are single-use: consumed nonces are remembered until
+// their expiration, and the provider authorization code they are paired with is single-use too.
+type stateManager struct {
+	key          []byte
+	allowedHosts map[string]struct{}
+	clock        clock.Clock
+
+	mu   sync.Mutex
+	used map[string]int64 // nonce -> expiration
+}
+
+func (s *stateManager) issue(provider, redirectURL string) (*oauthState, string, error) {
+	if err := s.validateRedirectURL(redirectURL); err != nil {
+		return nil, "", err
+	}
+
+	nonce, err := randomString(stateNonceLength)
+	if err != nil {
+		return nil, "", err
+	}
+
+	codeVerifier, err := randomString(stateNonceLength)
+	if err != nil {
+		return nil, "", err
+	}
+
+	st := &oauthState{
+		Nonce:        nonce,
+		Provider:     provider,
+		RedirectURL:  redirectURL,
+		CodeVerifier: codeVerifier,
+		Expiration:   s.clock.Now().Add(StateLifetime).Unix(),
+	}
+
+	payload, err := json.Marshal(st)
+	if err != nil {
+		return nil, "", err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+
+	return st, encodedPayload + stateSeparator + s.sign(encodedPayload), nil
+}
+
+// consume : verifies the signed state against the state returned by the provider, marking it as used
+func (s *stateManager) consume(signedState, returnedState string) (*oauthState, error) {
+	encodedPayload, signature, found := strings.Cut(signedState, stateSeparator)
+	if !found || !hmac.Equal([]byte(signature), []byte(s.sign(encodedPayload))) {
+		return nil, errInvalidState
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, errInvalidState
+	}
+
+	var st oauthState
+	if err = json.Unmarshal(payload, &st); err != nil {
+		return nil, errInvalidState
+	}
+
+	now := s.clock.Now().Unix()
+
+	if st.Expiration < now || subtle.ConstantTimeCompare([]byte(st.Nonce), []byte(returnedState)) != 1 {
+		return nil, errInvalidState
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for nonce, expiration := range s.used {
+		if expiration < now {
+			delete(s.used, nonce)
+		}
+	}
+
+	if _, ok := s.used[st.Nonce]; ok {
+		return nil, errors.Wrap(errInvalidState, "state already used")
+	}
+
+	s.used[st.Nonce] = st.Expiration
+
+	return &st, nil
+}
+
+func (s *stateManager) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// validateRedirectURL : only relative paths and absolute URLs towards allowed hosts are accepted, preventing open
+// redirects after login
+func (s *stateManager) validateRedirectURL(redirectURL string) error {
+	if redirectURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(redirectURL)
+	if err != nil {
+		return errors.Wrap(cError.ErrInvalidParameter, "invalid redirect URL")
+	}
+
+	// relative path, excluding protocol-relative URLs like "//host" and "/\host"
+	if u.Scheme == "" && u.Host == "" && strings.HasPrefix(redirectURL, "/") &&
+		!strings.HasPrefix(redirectURL, "//") && !strings.HasPrefix(redirectURL, "/\\") {
+		return nil
+	}
+
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return errors.Wrap(cError.ErrInvalidParameter, "invalid redirect URL")
+	}
+
+	if _, ok := s.allowedHosts[strings.ToLower(u.Hostname())]; !ok {
+		return errors.Wrap(cError.ErrInvalidParameter, "redirect host not allowed")
+	}
+
+	return nil
+}
+
+func newStateManager(key []byte, allowedHosts []string) (*stateManager, error) {
+	if len(key) == 0 {
+		// states won't survive restarts, nor be valid across replicas
+		randomKey := make([]byte, stateKeyLength)
+		if _, err := rand.Read(randomKey); err != nil {
+			return nil, err
+		}
+
+		key = randomKey
+	}
+
+	return &stateManager{
+		key:          key,
+		allowedHosts: util.SliceToMap(util.Map(allowedHosts, strings.ToLower)),
+		clock:        clock.Default{},
+		used:         make(map[string]int64),
+	}, nil
+}
+
+func randomString(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallenge : PKCE S256 code challenge of the verifier
+func codeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
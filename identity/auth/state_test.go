@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+)
+
+func TestStateManager(t *testing.T) {
+	now := time.Now()
+
+	newManager := func(t *testing.T) *stateManager {
+		sm, err := newStateManager([]byte("key"), []string{"fx-now.com"})
+		require.NoError(t, err)
+
+		c := mockclock.NewClock(t)
+		c.EXPECT().Now().Return(now).Maybe()
+		sm.clock = c
+
+		return sm
+	}
+
+	t.Run("happy-path", func(t *testing.T) {
+		sm := newManager(t)
+
+		st, signedState, err := sm.issue("google", "https://fx-now.com/path")
+		require.NoError(t, err)
+
+		res, err := sm.consume(signedState, st.Nonce)
+		require.NoError(t, err)
+		assert.Equal(t, st, res)
+		assert.Equal(t, "google", res.Provider)
+		assert.Equal(t, "https://fx-now.com/path", res.RedirectURL)
+		assert.NotEmpty(t, res.CodeVerifier)
+	})
+
+	t.Run("error-tampered-state", func(t *testing.T) {
+		sm := newManager(t)
+
+		st, signedState, err := sm.issue("google", "/path")
+		require.NoError(t, err)
+
+		_, err = sm.consume(signedState+"x", st.Nonce)
+		assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+
+		_, err = sm.consume("x"+signedState, st.Nonce)
+		assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+	})
+
+	t.Run("error-different-key", func(t *testing.T) {
+		sm := newManager(t)
+		other, err := newStateManager([]byte("other-key"), nil)
+		require.NoError(t, err)
+
+		st, signedState, err := other.issue("google", "/path")
+		require.NoError(t, err)
+
+		_, err = sm.consume(signedState, st.Nonce)
+		assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+	})
+
+	t.Run("error-expired", func(t *testing.T) {
+		sm := newManager(t)
+
+		st, signedState, err := sm.issue("google", "/path")
+		require.NoError(t, err)
+
+		c := mockclock.NewClock(t)
+		c.EXPECT().Now().Return(now.Add(StateLifetime + time.Second)).Once()
+		sm.clock = c
+
+		_, err = sm.consume(signedState, st.Nonce)
+		assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+	})
+
+	t.Run("error-reused", func(t *testing.T) {
+		sm := newManager(t)
+
+		st, signedState, err := sm.issue("google", "/path")
+		require.NoError(t, err)
+
+		_, err = sm.consume(signedState, st.Nonce)
+		require.NoError(t, err)
+
+		_, err = sm.consume(signedState, st.Nonce)
+		assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+	})
+
+	t.Run("redirect-urls", func(t *testing.T) {
+		sm := newManager(t)
+
+		for _, redirectURL := range []string{
+			"",
+			"/identity/v1/api-keys",
+			"/identity/access?provider=github",
+			"https://fx-now.com/path",
+			"http://FX-NOW.com/path",
+		} {
+			_, _, err := sm.issue("google", redirectURL)
+			assert.NoError(t, err, redirectURL)
+		}
+
+		for _, redirectURL := range []string{
+			"https://evil.com",
+			"https://fx-now.com.evil.com",
+			"//evil.com/path",
+			"/\\evil.com/path",
+			"javascript:alert(1)",
+			"evil.com",
+		} {
+			_, _, err := sm.issue("google", redirectURL)
+			assert.ErrorIs(t, err, cError.ErrInvalidParameter, redirectURL)
+		}
+	})
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636, appendix B
+	assert.Equal(t,
+		"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		codeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"),
+	)
+}
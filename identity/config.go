@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lruggieri/fxnow/common/util"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+// providersFromEnv : OIDC_PROVIDERS is a comma separated list of provider names (e.g. "google,github,keycloak"),
+// each configured through OIDC_<NAME>_* variables on top of the presets of well-known providers.
+// When OIDC_PROVIDERS is not set, only Google is configured through OIDC_CLIENT_ID, OIDC_CLIENT_SECRET and
+// OIDC_REDIRECT_URL.
+func providersFromEnv() []auth.ProviderConfig {
+	names := util.PruneSlice(util.Map(strings.Split(os.Getenv("OIDC_PROVIDERS"), ","), strings.TrimSpace))
+
+	if len(names) == 0 {
+		c := auth.PresetProviderConfig(auth.ProviderGoogle)
+		c.ClientID = os.Getenv("OIDC_CLIENT_ID")
+		c.ClientSecret = os.Getenv("OIDC_CLIENT_SECRET")
+		c.RedirectURL = os.Getenv("OIDC_REDIRECT_URL")
+
+		return []auth.ProviderConfig{c}
+	}
+
+	return util.Map(names, providerFromEnv)
+}
+
+func providerFromEnv(name string) auth.ProviderConfig {
+	c := auth.PresetProviderConfig(name)
+
+	env := func(key string) string {
+		return os.Getenv(fmt.Sprintf("OIDC_%s_%s", strings.ToUpper(name), key))
+	}
+
+	overrides := []struct {
+		key   string
+		value *string
+	}{
+		{"ISSUER", &c.Issuer},
+		{"CLIENT_ID", &c.ClientID},
+		{"CLIENT_SECRET", &c.ClientSecret},
+		{"REDIRECT_URL", &c.RedirectURL},
+		{"AUTH_URL", &c.AuthURL},
+		{"TOKEN_URL", &c.TokenURL},
+		{"USERINFO_URL", &c.UserInfoURL},
+		{"EMAILS_URL", &c.EmailsURL},
+		{"JWKS_URL", &c.JWKSURL},
+		{"JWKS_FILE", &c.JWKSFile},
+	}
+
+	for _, o := range overrides {
+		if v := env(o.key); v != "" {
+			*o.value = v
+		}
+	}
+
+	if scopes := env("SCOPES"); scopes != "" {
+		c.Scopes = util.Map(strings.Split(scopes, ","), strings.TrimSpace)
+	}
+
+	if trustEmail, err := strconv.ParseBool(env("TRUST_EMAIL")); err == nil {
+		c.TrustEmail = trustEmail
+	}
+
+	if disablePKCE, err := strconv.ParseBool(env("DISABLE_PKCE")); err == nil {
+		c.DisablePKCE = disablePKCE
+	}
+
+	return c
+}
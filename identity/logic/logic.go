@@ -2,27 +2,104 @@ package logic
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/lruggieri/fxnow/common/clock"
 	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/logger"
 	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/oauth"
 	"github.com/lruggieri/fxnow/common/store"
+	"github.com/lruggieri/fxnow/common/util"
 
 	"github.com/lruggieri/fxnow/identity/auth"
 )
 
+const (
+	DefaultAuditLogsLimit = 50
+	MaxAuditLogsLimit     = 200
+)
+
 type Logic interface {
+	Login(context.Context, LoginRequest) (*LoginResponse, error)
+
+	Authenticate(context.Context, AuthenticateRequest) (*AuthenticateResponse, error)
+	RefreshSession(context.Context, RefreshSessionRequest) (*RefreshSessionResponse, error)
+	Logout(context.Context, LogoutRequest) (*LogoutResponse, error)
+	ListSessions(context.Context, ListSessionsRequest) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, RevokeSessionRequest) (*RevokeSessionResponse, error)
+
 	ListAPIKeys(context.Context, ListAPIKeysRequest) (*ListAPIKeysResponse, error)
 	CreateAPIKey(context.Context, CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
 	DeleteAPIKey(context.Context, DeleteAPIKeyRequest) (*DeleteAPIKeyResponse, error)
+	RotateAPIKey(context.Context, RotateAPIKeyRequest) (*RotateAPIKeyResponse, error)
+	ChangeAPIKeyPlan(context.Context, ChangeAPIKeyPlanRequest) (*ChangeAPIKeyPlanResponse, error)
+
+	ListPlans(context.Context, ListPlansRequest) (*ListPlansResponse, error)
+
+	ListAuditLogs(context.Context, ListAuditLogsRequest) (*ListAuditLogsResponse, error)
+
+	CreateOAuthClient(context.Context, CreateOAuthClientRequest) (*CreateOAuthClientResponse, error)
+	ListOAuthClients(context.Context, ListOAuthClientsRequest) (*ListOAuthClientsResponse, error)
+	DeleteOAuthClient(context.Context, DeleteOAuthClientRequest) (*DeleteOAuthClientResponse, error)
+	IssueClientToken(context.Context, IssueClientTokenRequest) (*IssueClientTokenResponse, error)
+
+	CreateOrganization(context.Context, CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	ListOrganizations(context.Context, ListOrganizationsRequest) (*ListOrganizationsResponse, error)
+	ListOrganizationMembers(context.Context, ListOrganizationMembersRequest) (*ListOrganizationMembersResponse, error)
+	InviteOrganizationMember(context.Context, InviteOrganizationMemberRequest) (*InviteOrganizationMemberResponse, error)
+	RemoveOrganizationMember(context.Context, RemoveOrganizationMemberRequest) (*RemoveOrganizationMemberResponse, error)
+
+	CreateWebhook(context.Context, CreateWebhookRequest) (*CreateWebhookResponse, error)
+	ListWebhooks(context.Context, ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListWebhookDeadLetters(context.Context, ListWebhookDeadLettersRequest) (*ListWebhookDeadLettersResponse, error)
 }
 
 type Impl struct {
 	Store store.Store
+	Clock clock.Clock
+	// Tokens : signer of client credentials access tokens. If nil, the client credentials grant is disabled.
+	Tokens *oauth.Signer
+}
+
+func (i *Impl) Login(ctx context.Context, _ LoginRequest) (*LoginResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	// create user if it doesn't exist
+	uRes, err := i.createUser(ctx, CreateUserRequest{
+		FirstName: uInfo.GivenName,
+		LastName:  uInfo.FamilyName,
+		Email:     uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  uRes.UserID,
+		Action:       model.AuditActionLogin.Uint8(),
+		TargetUserID: uRes.UserID,
+		TargetID:     uRes.UserID,
+	}); err != nil {
+		return nil, err
+	}
+
+	tokens, err := i.createSession(ctx, uRes.UserID, uInfo.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{UserID: uRes.UserID, Tokens: *tokens}, nil
 }
 
-func (i *Impl) ListAPIKeys(ctx context.Context, _ ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+func (i *Impl) ListAPIKeys(ctx context.Context, req ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
 	uInfo := auth.GetUserInfoFromContext(ctx)
 	if uInfo == nil {
 		return nil, cError.ErrNotAuthenticated
@@ -38,7 +115,18 @@ func (i *Impl) ListAPIKeys(ctx context.Context, _ ListAPIKeysRequest) (*ListAPIK
 		return nil, err
 	}
 
-	apiKeys, err := i.Store.ListAPIKeys(ctx, store.ListAPIKeysRequest{UserID: uRes.UserID})
+	filter := store.ListAPIKeysRequest{UserID: uRes.UserID}
+
+	// keys of an organization are visible to all its members
+	if req.OrganizationID != "" {
+		if _, err = i.organizationMember(ctx, req.OrganizationID, uRes.UserID); err != nil {
+			return nil, err
+		}
+
+		filter = store.ListAPIKeysRequest{OrganizationID: req.OrganizationID}
+	}
+
+	apiKeys, err := i.Store.ListAPIKeys(ctx, filter)
 	if err != nil && !errors.Is(err, cError.ErrNotFound) {
 		return nil, err
 	}
@@ -48,7 +136,7 @@ func (i *Impl) ListAPIKeys(ctx context.Context, _ ListAPIKeysRequest) (*ListAPIK
 	}, nil
 }
 
-func (i *Impl) CreateAPIKey(ctx context.Context, _ CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+func (i *Impl) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
 	uInfo := auth.GetUserInfoFromContext(ctx)
 	if uInfo == nil {
 		return nil, cError.ErrNotAuthenticated
@@ -64,6 +152,10 @@ func (i *Impl) CreateAPIKey(ctx context.Context, _ CreateAPIKeyRequest) (*Create
 		return nil, err
 	}
 
+	if req.OrganizationID != "" {
+		return i.createOrganizationAPIKey(ctx, uRes.UserID, req.OrganizationID)
+	}
+
 	apiKey, err := i.Store.GetAPIKey(ctx, store.GetAPIKeyRequest{UserID: uRes.UserID})
 	if err != nil && !errors.Is(err, cError.ErrNotFound) {
 		return nil, err
@@ -78,12 +170,21 @@ func (i *Impl) CreateAPIKey(ctx context.Context, _ CreateAPIKeyRequest) (*Create
 	// create API key
 	akRes, err := i.Store.CreateAPIKey(ctx, store.CreateAPIKeyRequest{
 		UserID: uRes.UserID,
-		Type:   model.APIKeyTypeLimited.Uint8(),
+		PlanID: model.PlanFree,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  uRes.UserID,
+		Action:       model.AuditActionAPIKeyCreated.Uint8(),
+		TargetUserID: uRes.UserID,
+		TargetID:     akRes.APIKeyID,
+	}); err != nil {
+		return nil, err
+	}
+
 	return &CreateAPIKeyResponse{
 		APIKeyID: akRes.APIKeyID,
 	}, nil
@@ -102,26 +203,216 @@ func (i *Impl) DeleteAPIKey(ctx context.Context, req DeleteAPIKeyRequest) (*Dele
 		return nil, err
 	}
 
-	apiKey, err := i.Store.GetAPIKey(ctx, store.GetAPIKeyRequest{UserID: dbUInfo.User.UserID})
+	apiKey, err := i.Store.GetAPIKey(ctx, store.GetAPIKeyRequest{APIKeyID: req.APIKeyID})
 	if err != nil {
 		return nil, err
 	}
 
-	// only the API Key owners can delete their own key
-	if apiKey.APIKey.UserID != dbUInfo.User.UserID {
-		return nil, errors.Wrap(cError.ErrNotAuthorized, "only API Key owners can delete their own key")
+	if err = i.checkAPIKeyAccess(ctx, dbUInfo.User, apiKey.APIKey, "delete"); err != nil {
+		return nil, err
 	}
 
 	_, err = i.Store.DeleteAPIKey(ctx, store.DeleteAPIKeyRequest{
-		APIKeyID: req.APIKeyID,
+		APIKeyID: apiKey.APIKey.APIKeyID,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// the key is revoked anyway, a retry would not find it
+	i.auditPerformed(ctx, store.CreateAuditLogRequest{
+		ActorUserID:          dbUInfo.User.UserID,
+		Action:               model.AuditActionAPIKeyRevoked.Uint8(),
+		TargetUserID:         apiKey.APIKey.UserID,
+		TargetID:             apiKey.APIKey.APIKeyID,
+		TargetOrganizationID: apiKey.APIKey.OrganizationID,
+	})
+
 	return &DeleteAPIKeyResponse{}, nil
 }
 
+// RotateAPIKey : replaces a key with a new one, with the same owner and plan. The new key is created before the
+// previous one is revoked, and removed if the previous one cannot be revoked, so that a failure leaves the owner with
+// the previous key only.
+func (i *Impl) RotateAPIKey(ctx context.Context, req RotateAPIKeyRequest) (*RotateAPIKeyResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := i.Store.GetAPIKey(ctx, store.GetAPIKeyRequest{APIKeyID: req.APIKeyID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.checkAPIKeyAccess(ctx, dbUInfo.User, apiKey.APIKey, "rotate"); err != nil {
+		return nil, err
+	}
+
+	akRes, err := i.Store.CreateAPIKey(ctx, store.CreateAPIKeyRequest{
+		UserID:         apiKey.APIKey.UserID,
+		OrganizationID: apiKey.APIKey.OrganizationID,
+		PlanID:         apiKey.APIKey.PlanID,
+		Expiration:     apiKey.APIKey.Expiration,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = i.Store.DeleteAPIKey(ctx, store.DeleteAPIKeyRequest{
+		APIKeyID: apiKey.APIKey.APIKeyID,
+	}); err != nil {
+		if _, rollbackErr := i.Store.DeleteAPIKey(ctx, store.DeleteAPIKeyRequest{
+			APIKeyID: akRes.APIKeyID,
+		}); rollbackErr != nil {
+			logger.WithError(rollbackErr).Error("cannot remove API key %s, created to rotate %s",
+				akRes.APIKeyID, apiKey.APIKey.APIKeyID)
+		}
+
+		return nil, err
+	}
+
+	// the rotated key is the target, the new one is recorded as created. The new key must be returned even if these
+	// cannot be written, as the owner would not be able to identify it otherwise.
+	for _, entry := range []struct {
+		action   model.AuditAction
+		targetID string
+	}{
+		{action: model.AuditActionAPIKeyRotated, targetID: apiKey.APIKey.APIKeyID},
+		{action: model.AuditActionAPIKeyCreated, targetID: akRes.APIKeyID},
+	} {
+		i.auditPerformed(ctx, store.CreateAuditLogRequest{
+			ActorUserID:          dbUInfo.User.UserID,
+			Action:               entry.action.Uint8(),
+			TargetUserID:         apiKey.APIKey.UserID,
+			TargetID:             entry.targetID,
+			TargetOrganizationID: apiKey.APIKey.OrganizationID,
+		})
+	}
+
+	return &RotateAPIKeyResponse{
+		APIKeyID: akRes.APIKeyID,
+	}, nil
+}
+
+// checkAPIKeyAccess : only the API Key owners can manage their own key, unless the user is an admin. Keys of an
+// organization can be managed by the organization admins.
+func (i *Impl) checkAPIKeyAccess(ctx context.Context, user *model.User, apiKey *model.APIKey, action string) error {
+	if user.IsAdmin() {
+		return nil
+	}
+
+	if apiKey.OrganizationID != "" {
+		member, err := i.organizationMember(ctx, apiKey.OrganizationID, user.UserID)
+		if err != nil {
+			return err
+		}
+
+		if !member.CanManage() {
+			return errors.Wrap(cError.ErrNotAuthorized, fmt.Sprintf("only organization admins can %s its keys", action))
+		}
+	} else if apiKey.UserID != user.UserID {
+		return errors.Wrap(cError.ErrNotAuthorized, fmt.Sprintf("only API Key owners can %s their own key", action))
+	}
+
+	return nil
+}
+
+// createOrganizationAPIKey : keys owned by an organization are not bound to the member creating them, and there is no
+// limit to how many an organization can have, as they all share the same quota
+func (i *Impl) createOrganizationAPIKey(
+	ctx context.Context, userID, organizationID string,
+) (*CreateAPIKeyResponse, error) {
+	member, err := i.organizationMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.CanManage() {
+		return nil, errors.Wrap(cError.ErrNotAuthorized, "only organization admins can create its keys")
+	}
+
+	akRes, err := i.Store.CreateAPIKey(ctx, store.CreateAPIKeyRequest{
+		OrganizationID: organizationID,
+		PlanID:         model.PlanFree,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:          userID,
+		Action:               model.AuditActionAPIKeyCreated.Uint8(),
+		TargetID:             akRes.APIKeyID,
+		TargetOrganizationID: organizationID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &CreateAPIKeyResponse{
+		APIKeyID: akRes.APIKeyID,
+	}, nil
+}
+
+func (i *Impl) ListAuditLogs(ctx context.Context, req ListAuditLogsRequest) (*ListAuditLogsResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userID := req.UserID
+
+	// non-admin users can only see their own records
+	if !dbUInfo.User.IsAdmin() {
+		if userID != "" && userID != dbUInfo.User.UserID {
+			return nil, errors.Wrap(cError.ErrNotAuthorized, "users can only see their own audit records")
+		}
+
+		userID = dbUInfo.User.UserID
+	}
+
+	if req.Offset < 0 || req.Limit < 0 || req.Limit > MaxAuditLogsLimit {
+		return nil, errors.Wrap(cError.ErrInvalidParameter, "invalid pagination parameters")
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultAuditLogsLimit
+	}
+
+	res, err := i.Store.ListAuditLogs(ctx, store.ListAuditLogsRequest{
+		UserID: userID,
+		Actions: util.Map(req.Actions, func(item model.AuditAction) uint8 {
+			return item.Uint8()
+		}),
+		From:   req.From,
+		To:     req.To,
+		Offset: req.Offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListAuditLogsResponse{
+		AuditLogs: res.AuditLogs,
+	}, nil
+}
+
 // createUser : idempotent call, create user if it doesn't already exist
 func (i *Impl) createUser(ctx context.Context, req CreateUserRequest) (*CreateUserResponse, error) {
 	user, err := i.Store.GetUser(ctx, store.GetUserRequest{
@@ -144,6 +435,15 @@ func (i *Impl) createUser(ctx context.Context, req CreateUserRequest) (*CreateUs
 		}
 
 		userID = res.UserID
+
+		if err = i.audit(ctx, store.CreateAuditLogRequest{
+			ActorUserID:  userID,
+			Action:       model.AuditActionUserCreated.Uint8(),
+			TargetUserID: userID,
+			TargetID:     userID,
+		}); err != nil {
+			return nil, err
+		}
 	} else if err != nil {
 		return nil, err
 	} else {
@@ -152,3 +452,38 @@ func (i *Impl) createUser(ctx context.Context, req CreateUserRequest) (*CreateUs
 
 	return &CreateUserResponse{UserID: userID}, nil
 }
+
+func (i *Impl) now() time.Time {
+	if i.Clock == nil {
+		return time.Now()
+	}
+
+	return i.Clock.Now()
+}
+
+// auditPerformed : audits an action which cannot be undone, logging the entries that cannot be written rather than
+// reporting the action as failed
+func (i *Impl) auditPerformed(ctx context.Context, req store.CreateAuditLogRequest) {
+	if err := i.audit(ctx, req); err != nil {
+		logger.WithError(err).WithFields(logger.Fields{
+			"action":      model.AuditAction(req.Action).String(),
+			"actor":       req.ActorUserID,
+			"target_user": req.TargetUserID,
+			"target":      req.TargetID,
+		}).Error("action performed without audit log")
+	}
+}
+
+// audit : append an entry to the audit log, enriched with the client information found in the context
+func (i *Impl) audit(ctx context.Context, req store.CreateAuditLogRequest) error {
+	if cInfo := GetClientInfoFromContext(ctx); cInfo != nil {
+		req.IP = cInfo.IP
+		req.UserAgent = cInfo.UserAgent
+	}
+
+	if _, err := i.Store.CreateAuditLog(ctx, req); err != nil {
+		return errors.Wrap(err, "cannot write audit log")
+	}
+
+	return nil
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/logger"
+	"github.com/lruggieri/fxnow/common/logger/zap"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
 	mockstore "github.com/lruggieri/fxnow/common/mock/store"
 	"github.com/lruggieri/fxnow/common/model"
 	"github.com/lruggieri/fxnow/common/store"
@@ -266,7 +271,7 @@ func TestImpl_CreateAPIKey(t *testing.T) {
 
 				d.store.EXPECT().CreateAPIKey(args.ctx, store.CreateAPIKeyRequest{
 					UserID: "user_id",
-					Type:   model.APIKeyTypeLimited.Uint8(),
+					PlanID: model.PlanFree,
 				}).Return(nil, testErr).Once()
 			},
 			assertion: func(t *testing.T, res *CreateAPIKeyResponse, err error) {
@@ -295,6 +300,41 @@ func TestImpl_CreateAPIKey(t *testing.T) {
 				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
 			},
 		},
+		{
+			name: "error-create-audit-log",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateAPIKeyRequest{},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+				}}, nil).Once()
+
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{UserID: "user_id"}).
+					Return(nil, cError.ErrNotFound).Once()
+
+				d.store.EXPECT().CreateAPIKey(args.ctx, store.CreateAPIKeyRequest{
+					UserID: "user_id",
+					PlanID: model.PlanFree,
+				}).Return(&store.CreateAPIKeyResponse{
+					APIKeyID: "api_key",
+				}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionAPIKeyCreated.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "api_key",
+				}).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *CreateAPIKeyResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
 		{
 			name: "happy-path-user-exists",
 			args: args{
@@ -313,10 +353,17 @@ func TestImpl_CreateAPIKey(t *testing.T) {
 
 				d.store.EXPECT().CreateAPIKey(args.ctx, store.CreateAPIKeyRequest{
 					UserID: "user_id",
-					Type:   model.APIKeyTypeLimited.Uint8(),
+					PlanID: model.PlanFree,
 				}).Return(&store.CreateAPIKeyResponse{
 					APIKeyID: "api_key",
 				}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionAPIKeyCreated.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "api_key",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
 			},
 			assertion: func(t *testing.T, res *CreateAPIKeyResponse, err error) {
 				assert.Nil(t, err)
@@ -342,15 +389,88 @@ func TestImpl_CreateAPIKey(t *testing.T) {
 					UserID: "user_id",
 				}, nil).Once()
 
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionUserCreated.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "user_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+
 				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{UserID: "user_id"}).
 					Return(nil, cError.ErrNotFound).Once()
 
 				d.store.EXPECT().CreateAPIKey(args.ctx, store.CreateAPIKeyRequest{
 					UserID: "user_id",
-					Type:   model.APIKeyTypeLimited.Uint8(),
+					PlanID: model.PlanFree,
+				}).Return(&store.CreateAPIKeyResponse{
+					APIKeyID: "api_key",
+				}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionAPIKeyCreated.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "api_key",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateAPIKeyResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &CreateAPIKeyResponse{APIKeyID: "api_key"}, res)
+			},
+		},
+		{
+			name: "error-organization-member-not-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateAPIKeyRequest{OrganizationID: "organization_id"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+
+				d.store.EXPECT().GetOrganizationMember(args.ctx, store.GetOrganizationMemberRequest{
+					OrganizationID: "organization_id",
+					UserID:         "user_id",
+				}).Return(&store.GetOrganizationMemberResponse{Member: &model.OrganizationMember{
+					Role: model.OrganizationRoleMember,
+				}}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateAPIKeyResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "happy-path-organization",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateAPIKeyRequest{OrganizationID: "organization_id"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+
+				d.store.EXPECT().GetOrganizationMember(args.ctx, store.GetOrganizationMemberRequest{
+					OrganizationID: "organization_id",
+					UserID:         "user_id",
+				}).Return(&store.GetOrganizationMemberResponse{Member: &model.OrganizationMember{
+					Role: model.OrganizationRoleAdmin,
+				}}, nil).Once()
+
+				// the key is not bound to the member creating it
+				d.store.EXPECT().CreateAPIKey(args.ctx, store.CreateAPIKeyRequest{
+					OrganizationID: "organization_id",
+					PlanID:         model.PlanFree,
 				}).Return(&store.CreateAPIKeyResponse{
 					APIKeyID: "api_key",
 				}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:          "user_id",
+					Action:               model.AuditActionAPIKeyCreated.Uint8(),
+					TargetID:             "api_key",
+					TargetOrganizationID: "organization_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
 			},
 			assertion: func(t *testing.T, res *CreateAPIKeyResponse, err error) {
 				assert.Nil(t, err)
@@ -380,6 +500,8 @@ func TestImpl_CreateAPIKey(t *testing.T) {
 }
 
 func TestImpl_DeleteAPIKey(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
 	testErr := errors.New("error")
 
 	type deps struct {
@@ -450,7 +572,7 @@ func TestImpl_DeleteAPIKey(t *testing.T) {
 					UserID: "user_id",
 				}}, nil).Once()
 
-				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{UserID: "user_id"}).
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
 					Return(nil, testErr).Once()
 			},
 			assertion: func(t *testing.T, res *DeleteAPIKeyResponse, err error) {
@@ -458,6 +580,27 @@ func TestImpl_DeleteAPIKey(t *testing.T) {
 				assert.ErrorIs(t, err, testErr)
 			},
 		},
+		{
+			name: "error-api-key-not-found",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+				}}, nil).Once()
+
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteAPIKeyResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotFound)
+			},
+		},
 		{
 			name: "error-delete-api-key",
 			args: args{
@@ -471,7 +614,7 @@ func TestImpl_DeleteAPIKey(t *testing.T) {
 					UserID: "user_id",
 				}}, nil).Once()
 
-				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{UserID: "user_id"}).
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
 					Return(&store.GetAPIKeyResponse{
 						APIKey: &model.APIKey{
 							APIKeyID: "api_key",
@@ -487,6 +630,43 @@ func TestImpl_DeleteAPIKey(t *testing.T) {
 				assert.ErrorIs(t, err, testErr)
 			},
 		},
+		{
+			// the key is revoked anyway, so the call succeeds
+			name: "happy-path-audit-log-error",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+				}}, nil).Once()
+
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.GetAPIKeyResponse{
+						APIKey: &model.APIKey{
+							APIKeyID: "api_key",
+							UserID:   "user_id",
+						},
+					}, nil).Once()
+
+				d.store.EXPECT().DeleteAPIKey(args.ctx, store.DeleteAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.DeleteAPIKeyResponse{}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionAPIKeyRevoked.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "api_key",
+				}).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteAPIKeyResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &DeleteAPIKeyResponse{}, res)
+			},
+		},
 		{
 			// only the API Key owners can delete their own key
 			name: "error-wrong-user",
@@ -499,9 +679,10 @@ func TestImpl_DeleteAPIKey(t *testing.T) {
 					Email: uInfo.Email,
 				}).Return(&store.GetUserResponse{User: &model.User{
 					UserID: "user_id",
+					Role:   model.UserRoleStandard,
 				}}, nil).Once()
 
-				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{UserID: "user_id"}).
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
 					Return(&store.GetAPIKeyResponse{
 						APIKey: &model.APIKey{
 							APIKeyID: "api_key",
@@ -514,6 +695,34 @@ func TestImpl_DeleteAPIKey(t *testing.T) {
 				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
 			},
 		},
+		{
+			// the caller owns a key, but is trying to revoke someone else's
+			name: "error-wrong-user-caller-has-own-key",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteAPIKeyRequest{APIKeyID: "api_key_2"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+					Role:   model.UserRoleStandard,
+				}}, nil).Once()
+
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key_2"}).
+					Return(&store.GetAPIKeyResponse{
+						APIKey: &model.APIKey{
+							APIKeyID: "api_key_2",
+							UserID:   "user_id_2",
+						},
+					}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteAPIKeyResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
 		{
 			name: "happy-path",
 			args: args{
@@ -527,7 +736,7 @@ func TestImpl_DeleteAPIKey(t *testing.T) {
 					UserID: "user_id",
 				}}, nil).Once()
 
-				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{UserID: "user_id"}).
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
 					Return(&store.GetAPIKeyResponse{
 						APIKey: &model.APIKey{
 							APIKeyID: "api_key",
@@ -537,6 +746,123 @@ func TestImpl_DeleteAPIKey(t *testing.T) {
 
 				d.store.EXPECT().DeleteAPIKey(args.ctx, store.DeleteAPIKeyRequest{APIKeyID: "api_key"}).
 					Return(&store.DeleteAPIKeyResponse{}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionAPIKeyRevoked.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "api_key",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteAPIKeyResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &DeleteAPIKeyResponse{}, res)
+			},
+		},
+		{
+			// admins can revoke any key
+			name: "happy-path-admin-other-user",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteAPIKeyRequest{APIKeyID: "api_key_2"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "admin_id",
+					Role:   model.UserRoleAdmin,
+				}}, nil).Once()
+
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key_2"}).
+					Return(&store.GetAPIKeyResponse{
+						APIKey: &model.APIKey{
+							APIKeyID: "api_key_2",
+							UserID:   "user_id_2",
+						},
+					}, nil).Once()
+
+				d.store.EXPECT().DeleteAPIKey(args.ctx, store.DeleteAPIKeyRequest{APIKeyID: "api_key_2"}).
+					Return(&store.DeleteAPIKeyResponse{}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "admin_id",
+					Action:       model.AuditActionAPIKeyRevoked.Uint8(),
+					TargetUserID: "user_id_2",
+					TargetID:     "api_key_2",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteAPIKeyResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &DeleteAPIKeyResponse{}, res)
+			},
+		},
+		{
+			name: "error-organization-key-not-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.GetAPIKeyResponse{
+						APIKey: &model.APIKey{
+							APIKeyID:       "api_key",
+							OrganizationID: "organization_id",
+						},
+					}, nil).Once()
+
+				d.store.EXPECT().GetOrganizationMember(args.ctx, store.GetOrganizationMemberRequest{
+					OrganizationID: "organization_id",
+					UserID:         "user_id",
+				}).Return(&store.GetOrganizationMemberResponse{Member: &model.OrganizationMember{
+					Role: model.OrganizationRoleMember,
+				}}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteAPIKeyResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "happy-path-organization-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+
+				d.store.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.GetAPIKeyResponse{
+						APIKey: &model.APIKey{
+							APIKeyID:       "api_key",
+							OrganizationID: "organization_id",
+						},
+					}, nil).Once()
+
+				d.store.EXPECT().GetOrganizationMember(args.ctx, store.GetOrganizationMemberRequest{
+					OrganizationID: "organization_id",
+					UserID:         "user_id",
+				}).Return(&store.GetOrganizationMemberResponse{Member: &model.OrganizationMember{
+					Role: model.OrganizationRoleAdmin,
+				}}, nil).Once()
+
+				d.store.EXPECT().DeleteAPIKey(args.ctx, store.DeleteAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.DeleteAPIKeyResponse{}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:          "user_id",
+					Action:               model.AuditActionAPIKeyRevoked.Uint8(),
+					TargetID:             "api_key",
+					TargetOrganizationID: "organization_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
 			},
 			assertion: func(t *testing.T, res *DeleteAPIKeyResponse, err error) {
 				assert.Nil(t, err)
@@ -564,3 +890,711 @@ func TestImpl_DeleteAPIKey(t *testing.T) {
 		})
 	}
 }
+
+func TestImpl_RotateAPIKey(t *testing.T) {
+	logger.InitLogger(zap.New(zap.Config{Development: false}))
+
+	testErr := errors.New("error")
+
+	type args struct {
+		ctx context.Context
+		req RotateAPIKeyRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+
+	organizationKey := &model.APIKey{
+		APIKeyID:       "api_key",
+		OrganizationID: "organization_id",
+		PlanID:         model.PlanUnlimited,
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, s *mockstore.Store)
+		assertion func(t *testing.T, res *RotateAPIKeyResponse, err error)
+	}{
+		{
+			name: "error-no-user-info",
+			args: args{
+				ctx: context.Background(),
+				req: RotateAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, s *mockstore.Store) {},
+			assertion: func(t *testing.T, res *RotateAPIKeyResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-wrong-user",
+			args: args{
+				ctx: uInfoCtx,
+				req: RotateAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.GetAPIKeyResponse{
+						APIKey: &model.APIKey{APIKeyID: "api_key", UserID: "user_id_2"},
+					}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RotateAPIKeyResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			// the previous key is kept if the new one cannot be created
+			name: "error-create-api-key",
+			args: args{
+				ctx: uInfoCtx,
+				req: RotateAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.GetAPIKeyResponse{
+						APIKey: &model.APIKey{APIKeyID: "api_key", UserID: "user_id", PlanID: model.PlanFree},
+					}, nil).Once()
+				s.EXPECT().CreateAPIKey(args.ctx, store.CreateAPIKeyRequest{UserID: "user_id", PlanID: model.PlanFree}).
+					Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *RotateAPIKeyResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			// the new key is removed, so that the owner keeps the previous one only
+			name: "error-delete-api-key",
+			args: args{
+				ctx: uInfoCtx,
+				req: RotateAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.GetAPIKeyResponse{
+						APIKey: &model.APIKey{APIKeyID: "api_key", UserID: "user_id", PlanID: model.PlanFree},
+					}, nil).Once()
+				s.EXPECT().CreateAPIKey(args.ctx, store.CreateAPIKeyRequest{UserID: "user_id", PlanID: model.PlanFree}).
+					Return(&store.CreateAPIKeyResponse{APIKeyID: "api_key_2"}, nil).Once()
+				s.EXPECT().DeleteAPIKey(args.ctx, store.DeleteAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(nil, testErr).Once()
+				s.EXPECT().DeleteAPIKey(args.ctx, store.DeleteAPIKeyRequest{APIKeyID: "api_key_2"}).
+					Return(&store.DeleteAPIKeyResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RotateAPIKeyResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			// the previous key is already revoked, the new one must be returned
+			name: "happy-path-audit-log-error",
+			args: args{
+				ctx: uInfoCtx,
+				req: RotateAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.GetAPIKeyResponse{
+						APIKey: &model.APIKey{APIKeyID: "api_key", UserID: "user_id", PlanID: model.PlanFree},
+					}, nil).Once()
+				s.EXPECT().CreateAPIKey(args.ctx, store.CreateAPIKeyRequest{UserID: "user_id", PlanID: model.PlanFree}).
+					Return(&store.CreateAPIKeyResponse{APIKeyID: "api_key_2"}, nil).Once()
+				s.EXPECT().DeleteAPIKey(args.ctx, store.DeleteAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.DeleteAPIKeyResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, mock.AnythingOfType("store.CreateAuditLogRequest")).
+					Return(nil, testErr).Twice()
+			},
+			assertion: func(t *testing.T, res *RotateAPIKeyResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &RotateAPIKeyResponse{APIKeyID: "api_key_2"}, res)
+			},
+		},
+		{
+			name: "happy-path",
+			args: args{
+				ctx: uInfoCtx,
+				req: RotateAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.GetAPIKeyResponse{
+						APIKey: &model.APIKey{APIKeyID: "api_key", UserID: "user_id", PlanID: model.PlanFree},
+					}, nil).Once()
+				s.EXPECT().CreateAPIKey(args.ctx, store.CreateAPIKeyRequest{UserID: "user_id", PlanID: model.PlanFree}).
+					Return(&store.CreateAPIKeyResponse{APIKeyID: "api_key_2"}, nil).Once()
+				s.EXPECT().DeleteAPIKey(args.ctx, store.DeleteAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.DeleteAPIKeyResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionAPIKeyRotated.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "api_key",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionAPIKeyCreated.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "api_key_2",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RotateAPIKeyResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &RotateAPIKeyResponse{APIKeyID: "api_key_2"}, res)
+			},
+		},
+		{
+			name: "happy-path-organization-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: RotateAPIKeyRequest{APIKeyID: "api_key"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.GetAPIKeyResponse{APIKey: organizationKey}, nil).Once()
+				s.EXPECT().GetOrganizationMember(args.ctx, store.GetOrganizationMemberRequest{
+					OrganizationID: "organization_id",
+					UserID:         "user_id",
+				}).Return(&store.GetOrganizationMemberResponse{Member: &model.OrganizationMember{
+					Role: model.OrganizationRoleAdmin,
+				}}, nil).Once()
+				// the new key keeps the owner and the plan of the previous one
+				s.EXPECT().CreateAPIKey(args.ctx, store.CreateAPIKeyRequest{
+					OrganizationID: "organization_id",
+					PlanID:         model.PlanUnlimited,
+				}).Return(&store.CreateAPIKeyResponse{APIKeyID: "api_key_2"}, nil).Once()
+				s.EXPECT().DeleteAPIKey(args.ctx, store.DeleteAPIKeyRequest{APIKeyID: "api_key"}).
+					Return(&store.DeleteAPIKeyResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:          "user_id",
+					Action:               model.AuditActionAPIKeyRotated.Uint8(),
+					TargetID:             "api_key",
+					TargetOrganizationID: "organization_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:          "user_id",
+					Action:               model.AuditActionAPIKeyCreated.Uint8(),
+					TargetID:             "api_key_2",
+					TargetOrganizationID: "organization_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RotateAPIKeyResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &RotateAPIKeyResponse{APIKeyID: "api_key_2"}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockstore.NewStore(t)
+
+			l := Impl{
+				Store: s,
+			}
+
+			tc.mock(tc.args, s)
+
+			res, err := l.RotateAPIKey(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_Login(t *testing.T) {
+	testErr := errors.New("error")
+
+	type deps struct {
+		store *mockstore.Store
+		clock *mockclock.Clock
+	}
+
+	type args struct {
+		ctx context.Context
+		req LoginRequest
+	}
+
+	uInfo := auth.UserInfo{
+		Email:      "user@domain.com",
+		GivenName:  "name",
+		FamilyName: "surname",
+		Provider:   auth.ProviderGoogle,
+	}
+	cInfo := ClientInfo{
+		IP:        "127.0.0.1",
+		UserAgent: "user-agent",
+	}
+	uInfoCtx := context.WithValue(
+		context.WithValue(context.Background(), ContextKeyClientInfo, &cInfo),
+		auth.ContextUserInfoKey, &uInfo,
+	)
+	now := time.Unix(1700000000, 0)
+
+	// the session tokens are random, so only their derived values can be checked
+	sessionRequest := func(req store.CreateSessionRequest) bool {
+		return req.UserID == "user_id" &&
+			len(req.TokenHash) == 64 && len(req.RefreshTokenHash) == 64 && req.TokenHash != req.RefreshTokenHash &&
+			req.Provider == auth.ProviderGoogle &&
+			req.IP == cInfo.IP && req.UserAgent == cInfo.UserAgent &&
+			req.Expiration == now.Add(SessionLifetime).Unix() &&
+			req.RefreshExpiration == now.Add(RefreshLifetime).Unix()
+	}
+	assertTokens := func(t *testing.T, res *LoginResponse) {
+		assert.Equal(t, "user_id", res.UserID)
+		assert.Equal(t, "session_id", res.Tokens.SessionID)
+		assert.NotEmpty(t, res.Tokens.Token)
+		assert.NotEmpty(t, res.Tokens.RefreshToken)
+		assert.NotEqual(t, res.Tokens.Token, res.Tokens.RefreshToken)
+		assert.Equal(t, now.Add(SessionLifetime).Unix(), res.Tokens.Expiration)
+		assert.Equal(t, now.Add(RefreshLifetime).Unix(), res.Tokens.RefreshExpiration)
+	}
+
+	tests := []struct {
+		name      string
+		deps      deps
+		args      args
+		mock      func(args args, d deps)
+		assertion func(
+			t *testing.T,
+			res *LoginResponse,
+			err error,
+		)
+	}{
+		{
+			name: "error-no-user-info",
+			args: args{
+				ctx: context.Background(),
+				req: LoginRequest{},
+			},
+			mock: func(args args, d deps) {},
+			assertion: func(t *testing.T, res *LoginResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-get-user",
+			args: args{
+				ctx: uInfoCtx,
+				req: LoginRequest{},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *LoginResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "error-create-audit-log",
+			args: args{
+				ctx: uInfoCtx,
+				req: LoginRequest{},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+				}}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionLogin.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "user_id",
+					IP:           cInfo.IP,
+					UserAgent:    cInfo.UserAgent,
+				}).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *LoginResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "error-create-session",
+			args: args{
+				ctx: uInfoCtx,
+				req: LoginRequest{},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+				}}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionLogin.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "user_id",
+					IP:           cInfo.IP,
+					UserAgent:    cInfo.UserAgent,
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				d.store.EXPECT().CreateSession(args.ctx, mock.MatchedBy(sessionRequest)).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *LoginResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path-user-exists",
+			args: args{
+				ctx: uInfoCtx,
+				req: LoginRequest{},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+				}}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionLogin.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "user_id",
+					IP:           cInfo.IP,
+					UserAgent:    cInfo.UserAgent,
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				d.store.EXPECT().CreateSession(args.ctx, mock.MatchedBy(sessionRequest)).
+					Return(&store.CreateSessionResponse{SessionID: "session_id"}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *LoginResponse, err error) {
+				assert.Nil(t, err)
+				assertTokens(t, res)
+			},
+		},
+		{
+			name: "happy-path-user-not-exist",
+			args: args{
+				ctx: uInfoCtx,
+				req: LoginRequest{},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(nil, cError.ErrNotFound).Once()
+
+				d.store.EXPECT().CreateUser(args.ctx, store.CreateUserRequest{
+					FirstName: uInfo.GivenName,
+					LastName:  uInfo.FamilyName,
+					Email:     uInfo.Email,
+				}).Return(&store.CreateUserResponse{
+					UserID: "user_id",
+				}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionUserCreated.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "user_id",
+					IP:           cInfo.IP,
+					UserAgent:    cInfo.UserAgent,
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionLogin.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "user_id",
+					IP:           cInfo.IP,
+					UserAgent:    cInfo.UserAgent,
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+
+				d.clock.EXPECT().Now().Return(now).Once()
+
+				d.store.EXPECT().CreateSession(args.ctx, mock.MatchedBy(sessionRequest)).
+					Return(&store.CreateSessionResponse{SessionID: "session_id"}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *LoginResponse, err error) {
+				assert.Nil(t, err)
+				assertTokens(t, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				store: mockstore.NewStore(t),
+				clock: mockclock.NewClock(t),
+			}
+
+			l := Impl{
+				Store: d.store,
+				Clock: d.clock,
+			}
+
+			tc.mock(tc.args, d)
+
+			res, err := l.Login(tc.args.ctx, tt.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_ListAuditLogs(t *testing.T) {
+	testErr := errors.New("error")
+
+	type deps struct {
+		store *mockstore.Store
+	}
+
+	type args struct {
+		ctx context.Context
+		req ListAuditLogsRequest
+	}
+
+	uInfo := auth.UserInfo{
+		Email:      "user@domain.com",
+		GivenName:  "name",
+		FamilyName: "surname",
+	}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+
+	auditLogs := []*model.AuditLog{
+		{ID: 2, ActorUserID: "user_id", Action: model.AuditActionAPIKeyRevoked, TargetID: "api_key"},
+		{ID: 1, ActorUserID: "user_id", Action: model.AuditActionAPIKeyCreated, TargetID: "api_key"},
+	}
+
+	tests := []struct {
+		name      string
+		deps      deps
+		args      args
+		mock      func(args args, d deps)
+		assertion func(
+			t *testing.T,
+			res *ListAuditLogsResponse,
+			err error,
+		)
+	}{
+		{
+			name: "error-no-user-info",
+			args: args{
+				ctx: context.Background(),
+				req: ListAuditLogsRequest{},
+			},
+			mock: func(args args, d deps) {},
+			assertion: func(t *testing.T, res *ListAuditLogsResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-get-user",
+			args: args{
+				ctx: uInfoCtx,
+				req: ListAuditLogsRequest{},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *ListAuditLogsResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			// only admins can see other users' records
+			name: "error-other-user-not-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: ListAuditLogsRequest{UserID: "user_id_2"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+					Role:   model.UserRoleStandard,
+				}}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *ListAuditLogsResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "error-invalid-limit",
+			args: args{
+				ctx: uInfoCtx,
+				req: ListAuditLogsRequest{Limit: MaxAuditLogsLimit + 1},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+				}}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *ListAuditLogsResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-list-audit-logs",
+			args: args{
+				ctx: uInfoCtx,
+				req: ListAuditLogsRequest{},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+				}}, nil).Once()
+
+				d.store.EXPECT().ListAuditLogs(args.ctx, store.ListAuditLogsRequest{
+					UserID:  "user_id",
+					Actions: []uint8{},
+					Limit:   DefaultAuditLogsLimit,
+				}).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *ListAuditLogsResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path-own-records",
+			args: args{
+				ctx: uInfoCtx,
+				req: ListAuditLogsRequest{
+					Actions: []model.AuditAction{model.AuditActionAPIKeyCreated, model.AuditActionAPIKeyRevoked},
+					From:    100,
+					To:      200,
+					Offset:  10,
+					Limit:   5,
+				},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "user_id",
+				}}, nil).Once()
+
+				d.store.EXPECT().ListAuditLogs(args.ctx, store.ListAuditLogsRequest{
+					UserID: "user_id",
+					Actions: []uint8{
+						model.AuditActionAPIKeyCreated.Uint8(),
+						model.AuditActionAPIKeyRevoked.Uint8(),
+					},
+					From:   100,
+					To:     200,
+					Offset: 10,
+					Limit:  5,
+				}).Return(&store.ListAuditLogsResponse{AuditLogs: auditLogs}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *ListAuditLogsResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &ListAuditLogsResponse{AuditLogs: auditLogs}, res)
+			},
+		},
+		{
+			// admins can see all records, unfiltered
+			name: "happy-path-admin-all-records",
+			args: args{
+				ctx: uInfoCtx,
+				req: ListAuditLogsRequest{},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "admin_id",
+					Role:   model.UserRoleAdmin,
+				}}, nil).Once()
+
+				d.store.EXPECT().ListAuditLogs(args.ctx, store.ListAuditLogsRequest{
+					Actions: []uint8{},
+					Limit:   DefaultAuditLogsLimit,
+				}).Return(&store.ListAuditLogsResponse{AuditLogs: auditLogs}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *ListAuditLogsResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &ListAuditLogsResponse{AuditLogs: auditLogs}, res)
+			},
+		},
+		{
+			name: "happy-path-admin-other-user",
+			args: args{
+				ctx: uInfoCtx,
+				req: ListAuditLogsRequest{UserID: "user_id_2"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{
+					Email: uInfo.Email,
+				}).Return(&store.GetUserResponse{User: &model.User{
+					UserID: "admin_id",
+					Role:   model.UserRoleAdmin,
+				}}, nil).Once()
+
+				d.store.EXPECT().ListAuditLogs(args.ctx, store.ListAuditLogsRequest{
+					UserID:  "user_id_2",
+					Actions: []uint8{},
+					Limit:   DefaultAuditLogsLimit,
+				}).Return(&store.ListAuditLogsResponse{AuditLogs: auditLogs}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *ListAuditLogsResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &ListAuditLogsResponse{AuditLogs: auditLogs}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				store: mockstore.NewStore(t),
+			}
+
+			l := Impl{
+				Store: d.store,
+			}
+
+			tc.mock(tc.args, d)
+
+			res, err := l.ListAuditLogs(tc.args.ctx, tt.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
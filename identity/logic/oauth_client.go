@@ -0,0 +1,222 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/oauth"
+	"github.com/lruggieri/fxnow/common/store"
+	"github.com/lruggieri/fxnow/common/util"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+const (
+	MaxOAuthClientNameLength = 128
+	// MaxOAuthClientsPerUser : like API keys, clients are limited so that they cannot multiply the quota of a plan
+	MaxOAuthClientsPerUser = 1
+)
+
+func (i *Impl) CreateOAuthClient(
+	ctx context.Context, req CreateOAuthClientRequest,
+) (*CreateOAuthClientResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > MaxOAuthClientNameLength {
+		return nil, errors.Wrap(cError.ErrInvalidParameter, "invalid client name")
+	}
+
+	scopes := util.PruneSlice(req.Scopes)
+	if len(scopes) == 0 {
+		scopes = oauth.AllScopes
+	}
+
+	if !oauth.ValidScopes(scopes) {
+		return nil, errors.Wrap(cError.ErrInvalidParameter, "invalid scopes")
+	}
+
+	planID := model.PlanFree
+
+	if req.PlanID != "" && req.PlanID != planID {
+		if !dbUInfo.User.IsAdmin() {
+			return nil, errors.Wrap(cError.ErrNotAuthorized, "only admins can choose the client plan")
+		}
+
+		if err = i.checkPlan(ctx, req.PlanID); err != nil {
+			return nil, err
+		}
+
+		planID = req.PlanID
+	}
+
+	existing, err := i.Store.ListOAuthClients(ctx, store.ListOAuthClientsRequest{UserID: dbUInfo.User.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing.OAuthClients) >= MaxOAuthClientsPerUser {
+		return nil, errors.Wrap(cError.ErrInvalidParameter, "too many OAuth clients")
+	}
+
+	secret, err := newSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.Store.CreateOAuthClient(ctx, store.CreateOAuthClientRequest{
+		UserID:     dbUInfo.User.UserID,
+		Name:       name,
+		SecretHash: hashToken(secret),
+		Scopes:     scopes,
+		PlanID:     planID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  dbUInfo.User.UserID,
+		Action:       model.AuditActionOAuthClientCreated.Uint8(),
+		TargetUserID: dbUInfo.User.UserID,
+		TargetID:     res.ClientID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &CreateOAuthClientResponse{
+		ClientID:     res.ClientID,
+		ClientSecret: secret,
+	}, nil
+}
+
+func (i *Impl) ListOAuthClients(ctx context.Context, _ ListOAuthClientsRequest) (*ListOAuthClientsResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.Store.ListOAuthClients(ctx, store.ListOAuthClientsRequest{UserID: dbUInfo.User.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListOAuthClientsResponse{
+		OAuthClients: res.OAuthClients,
+	}, nil
+}
+
+func (i *Impl) DeleteOAuthClient(
+	ctx context.Context, req DeleteOAuthClientRequest,
+) (*DeleteOAuthClientResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := i.Store.GetOAuthClient(ctx, store.GetOAuthClientRequest{ClientID: req.ClientID})
+	if err != nil {
+		return nil, err
+	}
+
+	// only the client owners can delete their own clients, unless the caller is an admin
+	if client.OAuthClient.UserID != dbUInfo.User.UserID && !dbUInfo.User.IsAdmin() {
+		return nil, errors.Wrap(cError.ErrNotAuthorized, "only client owners can delete their own clients")
+	}
+
+	if _, err = i.Store.DeleteOAuthClient(ctx, store.DeleteOAuthClientRequest{
+		ClientID: client.OAuthClient.ClientID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  dbUInfo.User.UserID,
+		Action:       model.AuditActionOAuthClientDeleted.Uint8(),
+		TargetUserID: client.OAuthClient.UserID,
+		TargetID:     client.OAuthClient.ClientID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &DeleteOAuthClientResponse{}, nil
+}
+
+// IssueClientToken : client credentials grant (RFC 6749, section 4.4). Tokens of deleted clients stay valid until
+// they expire.
+func (i *Impl) IssueClientToken(ctx context.Context, req IssueClientTokenRequest) (*IssueClientTokenResponse, error) {
+	if i.Tokens == nil {
+		return nil, errors.Wrap(cError.ErrInvalidParameter, "client credentials grant not enabled")
+	}
+
+	if req.ClientID == "" || req.ClientSecret == "" {
+		return nil, errors.Wrap(cError.ErrNotAuthenticated, "missing client credentials")
+	}
+
+	res, err := i.Store.GetOAuthClient(ctx, store.GetOAuthClientRequest{
+		ClientID:   req.ClientID,
+		SecretHash: hashToken(req.ClientSecret),
+	})
+	if err != nil {
+		if errors.Is(err, cError.ErrNotFound) {
+			return nil, errors.Wrap(cError.ErrNotAuthenticated, "invalid client credentials")
+		}
+
+		return nil, err
+	}
+
+	client := res.OAuthClient
+
+	scopes := client.Scopes
+	if len(req.Scopes) > 0 {
+		if !util.SliceContains(client.Scopes, req.Scopes) {
+			return nil, errors.Wrap(cError.ErrInvalidParameter, "invalid scope")
+		}
+
+		scopes = util.PruneSlice(req.Scopes)
+	}
+
+	token, expiration, err := i.Tokens.Issue(oauth.Claims{
+		ClientID: client.ClientID,
+		UserID:   client.UserID,
+		Scopes:   scopes,
+		PlanID:   client.PlanID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &IssueClientTokenResponse{
+		AccessToken: token,
+		Expiration:  expiration,
+		Scopes:      scopes,
+	}, nil
+}
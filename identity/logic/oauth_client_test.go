@@ -0,0 +1,468 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+	mockstore "github.com/lruggieri/fxnow/common/mock/store"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/oauth"
+	"github.com/lruggieri/fxnow/common/store"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+func TestImpl_CreateOAuthClient(t *testing.T) {
+	testErr := errors.New("error")
+
+	type args struct {
+		ctx context.Context
+		req CreateOAuthClientRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+	user := &model.User{UserID: "user_id"}
+	admin := &model.User{UserID: "user_id", Role: model.UserRoleAdmin}
+
+	clientRequest := func(planID string) func(req store.CreateOAuthClientRequest) bool {
+		return func(req store.CreateOAuthClientRequest) bool {
+			return req.UserID == "user_id" && req.Name == "backend" && len(req.SecretHash) == 64 &&
+				assert.ObjectsAreEqual(oauth.AllScopes, req.Scopes) && req.PlanID == planID
+		}
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, s *mockstore.Store)
+		assertion func(t *testing.T, res *CreateOAuthClientResponse, err error)
+	}{
+		{
+			name: "error-no-user-info",
+			args: args{
+				ctx: context.Background(),
+				req: CreateOAuthClientRequest{Name: "backend"},
+			},
+			mock: func(args args, s *mockstore.Store) {},
+			assertion: func(t *testing.T, res *CreateOAuthClientResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-missing-name",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOAuthClientRequest{Name: " "},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOAuthClientResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-invalid-scope",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOAuthClientRequest{Name: "backend", Scopes: []string{"admin"}},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOAuthClientResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-plan-not-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOAuthClientRequest{Name: "backend", PlanID: model.PlanUnlimited},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOAuthClientResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "error-too-many-clients",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOAuthClientRequest{Name: "backend"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+				s.EXPECT().ListOAuthClients(args.ctx, store.ListOAuthClientsRequest{UserID: "user_id"}).
+					Return(&store.ListOAuthClientsResponse{
+						OAuthClients: []*model.OAuthClient{{ClientID: "client_id"}},
+					}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOAuthClientResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-create-client",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOAuthClientRequest{Name: "backend"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+				s.EXPECT().ListOAuthClients(args.ctx, store.ListOAuthClientsRequest{UserID: "user_id"}).
+					Return(&store.ListOAuthClientsResponse{}, nil).Once()
+				s.EXPECT().CreateOAuthClient(args.ctx, mock.MatchedBy(clientRequest(model.PlanFree))).
+					Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOAuthClientResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOAuthClientRequest{Name: "backend"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+				s.EXPECT().ListOAuthClients(args.ctx, store.ListOAuthClientsRequest{UserID: "user_id"}).
+					Return(&store.ListOAuthClientsResponse{}, nil).Once()
+				s.EXPECT().CreateOAuthClient(args.ctx, mock.MatchedBy(clientRequest(model.PlanFree))).
+					Return(&store.CreateOAuthClientResponse{ClientID: "client_id"}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionOAuthClientCreated.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "client_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOAuthClientResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "client_id", res.ClientID)
+				assert.NotEmpty(t, res.ClientSecret)
+			},
+		},
+		{
+			name: "error-unknown-plan",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOAuthClientRequest{Name: "backend", PlanID: "gold"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: admin}, nil).Once()
+				s.EXPECT().GetPlan(args.ctx, store.GetPlanRequest{PlanID: "gold"}).
+					Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOAuthClientResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "happy-path-admin-plan",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOAuthClientRequest{Name: "backend", PlanID: model.PlanUnlimited},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: admin}, nil).Once()
+				s.EXPECT().GetPlan(args.ctx, store.GetPlanRequest{PlanID: model.PlanUnlimited}).
+					Return(&store.GetPlanResponse{Plan: &model.Plan{PlanID: model.PlanUnlimited}}, nil).Once()
+				s.EXPECT().ListOAuthClients(args.ctx, store.ListOAuthClientsRequest{UserID: "user_id"}).
+					Return(&store.ListOAuthClientsResponse{}, nil).Once()
+				s.EXPECT().CreateOAuthClient(args.ctx, mock.MatchedBy(clientRequest(model.PlanUnlimited))).
+					Return(&store.CreateOAuthClientResponse{ClientID: "client_id"}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, mock.AnythingOfType("store.CreateAuditLogRequest")).
+					Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOAuthClientResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "client_id", res.ClientID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockstore.NewStore(t)
+
+			l := Impl{
+				Store: s,
+			}
+
+			tc.mock(tc.args, s)
+
+			res, err := l.CreateOAuthClient(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_DeleteOAuthClient(t *testing.T) {
+	testErr := errors.New("error")
+
+	type args struct {
+		ctx context.Context
+		req DeleteOAuthClientRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+	otherClient := &model.OAuthClient{ClientID: "client_id", UserID: "other_user_id"}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, s *mockstore.Store)
+		assertion func(t *testing.T, res *DeleteOAuthClientResponse, err error)
+	}{
+		{
+			name: "error-get-client",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteOAuthClientRequest{ClientID: "client_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().GetOAuthClient(args.ctx, store.GetOAuthClientRequest{ClientID: "client_id"}).
+					Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteOAuthClientResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "error-other-user-client",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteOAuthClientRequest{ClientID: "client_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().GetOAuthClient(args.ctx, store.GetOAuthClientRequest{ClientID: "client_id"}).
+					Return(&store.GetOAuthClientResponse{OAuthClient: otherClient}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteOAuthClientResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "happy-path-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteOAuthClientRequest{ClientID: "client_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{
+						UserID: "user_id",
+						Role:   model.UserRoleAdmin,
+					}}, nil).Once()
+				s.EXPECT().GetOAuthClient(args.ctx, store.GetOAuthClientRequest{ClientID: "client_id"}).
+					Return(&store.GetOAuthClientResponse{OAuthClient: otherClient}, nil).Once()
+				s.EXPECT().DeleteOAuthClient(args.ctx, store.DeleteOAuthClientRequest{ClientID: "client_id"}).
+					Return(&store.DeleteOAuthClientResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionOAuthClientDeleted.Uint8(),
+					TargetUserID: "other_user_id",
+					TargetID:     "client_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteOAuthClientResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &DeleteOAuthClientResponse{}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockstore.NewStore(t)
+
+			l := Impl{
+				Store: s,
+			}
+
+			tc.mock(tc.args, s)
+
+			res, err := l.DeleteOAuthClient(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_IssueClientToken(t *testing.T) {
+	testErr := errors.New("error")
+	now := time.Unix(1700000000, 0)
+
+	c := mockclock.NewClock(t)
+	c.EXPECT().Now().Return(now).Maybe()
+
+	signer, err := oauth.NewSigner([]byte("0123456789abcdef0123456789abcdef"), c)
+	require.NoError(t, err)
+
+	type args struct {
+		ctx context.Context
+		req IssueClientTokenRequest
+	}
+
+	credentials := IssueClientTokenRequest{ClientID: "client_id", ClientSecret: "secret"}
+	clientRequest := store.GetOAuthClientRequest{ClientID: "client_id", SecretHash: hashToken("secret")}
+	client := &model.OAuthClient{
+		ClientID: "client_id",
+		UserID:   "user_id",
+		Scopes:   []string{oauth.ScopeRatesRead},
+		PlanID:   model.PlanUnlimited,
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, s *mockstore.Store)
+		assertion func(t *testing.T, res *IssueClientTokenResponse, err error)
+	}{
+		{
+			name: "error-missing-credentials",
+			args: args{
+				ctx: context.Background(),
+				req: IssueClientTokenRequest{ClientID: "client_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {},
+			assertion: func(t *testing.T, res *IssueClientTokenResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-invalid-credentials",
+			args: args{
+				ctx: context.Background(),
+				req: credentials,
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetOAuthClient(args.ctx, clientRequest).Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *IssueClientTokenResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-get-client",
+			args: args{
+				ctx: context.Background(),
+				req: credentials,
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetOAuthClient(args.ctx, clientRequest).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *IssueClientTokenResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "error-scope-not-granted",
+			args: args{
+				ctx: context.Background(),
+				req: IssueClientTokenRequest{ClientID: "client_id", ClientSecret: "secret", Scopes: []string{"admin"}},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetOAuthClient(args.ctx, clientRequest).
+					Return(&store.GetOAuthClientResponse{OAuthClient: client}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *IssueClientTokenResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "happy-path",
+			args: args{
+				ctx: context.Background(),
+				req: credentials,
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetOAuthClient(args.ctx, clientRequest).
+					Return(&store.GetOAuthClientResponse{OAuthClient: client}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *IssueClientTokenResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, []string{oauth.ScopeRatesRead}, res.Scopes)
+				assert.Equal(t, now.Add(oauth.AccessTokenLifetime).Unix(), res.Expiration)
+
+				// the client registration is carried by the token
+				claims, err := signer.Verify(res.AccessToken)
+				require.NoError(t, err)
+				assert.Equal(t, &oauth.Claims{
+					ClientID:   "client_id",
+					UserID:     "user_id",
+					Scopes:     []string{oauth.ScopeRatesRead},
+					PlanID:     model.PlanUnlimited,
+					Expiration: res.Expiration,
+				}, claims)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockstore.NewStore(t)
+
+			l := Impl{
+				Store:  s,
+				Tokens: signer,
+			}
+
+			tc.mock(tc.args, s)
+
+			res, err := l.IssueClientToken(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+
+	t.Run("error-grant-disabled", func(t *testing.T) {
+		l := Impl{Store: mockstore.NewStore(t)}
+
+		_, err := l.IssueClientToken(context.Background(), credentials)
+		assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+	})
+}
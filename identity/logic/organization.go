@@ -0,0 +1,298 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/store"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+const (
+	MaxOrganizationNameLength = 128
+)
+
+func (i *Impl) CreateOrganization(
+	ctx context.Context, req CreateOrganizationRequest,
+) (*CreateOrganizationResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > MaxOrganizationNameLength {
+		return nil, errors.Wrap(cError.ErrInvalidParameter, "invalid organization name")
+	}
+
+	res, err := i.Store.CreateOrganization(ctx, store.CreateOrganizationRequest{
+		Name:        name,
+		OwnerUserID: dbUInfo.User.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  dbUInfo.User.UserID,
+		Action:       model.AuditActionOrganizationCreated.Uint8(),
+		TargetUserID: dbUInfo.User.UserID,
+		TargetID:     res.OrganizationID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &CreateOrganizationResponse{
+		OrganizationID: res.OrganizationID,
+	}, nil
+}
+
+func (i *Impl) ListOrganizations(ctx context.Context, _ ListOrganizationsRequest) (*ListOrganizationsResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.Store.ListOrganizations(ctx, store.ListOrganizationsRequest{UserID: dbUInfo.User.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListOrganizationsResponse{
+		Organizations: res.Organizations,
+	}, nil
+}
+
+func (i *Impl) ListOrganizationMembers(
+	ctx context.Context, req ListOrganizationMembersRequest,
+) (*ListOrganizationMembersResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// members can see each other, admins can see every organization
+	if !dbUInfo.User.IsAdmin() {
+		if _, err = i.organizationMember(ctx, req.OrganizationID, dbUInfo.User.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := i.Store.ListOrganizationMembers(ctx, store.ListOrganizationMembersRequest{
+		OrganizationID: req.OrganizationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListOrganizationMembersResponse{
+		Members: res.Members,
+	}, nil
+}
+
+// InviteOrganizationMember : adds the user with the given email to the organization. Users who never logged in are
+// created, and find themselves in the organization at their first login.
+func (i *Impl) InviteOrganizationMember(
+	ctx context.Context, req InviteOrganizationMemberRequest,
+) (*InviteOrganizationMemberResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := i.organizationMember(ctx, req.OrganizationID, dbUInfo.User.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !caller.CanManage() {
+		return nil, errors.Wrap(cError.ErrNotAuthorized, "only organization admins can invite members")
+	}
+
+	role := req.Role
+	if role == model.OrganizationRoleUndefined {
+		role = model.OrganizationRoleMember
+	}
+
+	if role > caller.Role {
+		return nil, errors.Wrap(cError.ErrNotAuthorized, "cannot grant a role higher than your own")
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return nil, errors.Wrap(cError.ErrInvalidParameter, "missing email")
+	}
+
+	uRes, err := i.createUser(ctx, CreateUserRequest{Email: email})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = i.Store.GetOrganizationMember(ctx, store.GetOrganizationMemberRequest{
+		OrganizationID: req.OrganizationID,
+		UserID:         uRes.UserID,
+	})
+	if err == nil {
+		return nil, errors.Wrap(cError.ErrDuplicated, "user is already a member of the organization")
+	} else if !errors.Is(err, cError.ErrNotFound) {
+		return nil, err
+	}
+
+	if _, err = i.Store.CreateOrganizationMember(ctx, store.CreateOrganizationMemberRequest{
+		OrganizationID: req.OrganizationID,
+		UserID:         uRes.UserID,
+		Role:           role.Uint8(),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  dbUInfo.User.UserID,
+		Action:       model.AuditActionOrganizationMemberAdded.Uint8(),
+		TargetUserID: uRes.UserID,
+		TargetID:     req.OrganizationID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &InviteOrganizationMemberResponse{
+		UserID: uRes.UserID,
+	}, nil
+}
+
+// RemoveOrganizationMember : members can leave, admins can remove members not above their own role. Keys are owned
+// by the organization, so they are not affected.
+func (i *Impl) RemoveOrganizationMember(
+	ctx context.Context, req RemoveOrganizationMemberRequest,
+) (*RemoveOrganizationMemberResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := i.organizationMember(ctx, req.OrganizationID, dbUInfo.User.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := caller
+
+	if req.UserID != caller.UserID {
+		var res *store.GetOrganizationMemberResponse
+
+		res, err = i.Store.GetOrganizationMember(ctx, store.GetOrganizationMemberRequest{
+			OrganizationID: req.OrganizationID,
+			UserID:         req.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		target = res.Member
+
+		if !caller.CanManage() || target.Role > caller.Role {
+			return nil, errors.Wrap(cError.ErrNotAuthorized, "not allowed to remove this member")
+		}
+	}
+
+	// organizations must always have an owner
+	if target.Role == model.OrganizationRoleOwner {
+		if err = i.ensureAnotherOwner(ctx, req.OrganizationID, target.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err = i.Store.DeleteOrganizationMember(ctx, store.DeleteOrganizationMemberRequest{
+		OrganizationID: req.OrganizationID,
+		UserID:         target.UserID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  dbUInfo.User.UserID,
+		Action:       model.AuditActionOrganizationMemberRemoved.Uint8(),
+		TargetUserID: target.UserID,
+		TargetID:     req.OrganizationID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &RemoveOrganizationMemberResponse{}, nil
+}
+
+// organizationMember : returns the membership of the user, ErrNotAuthorized if the user is not a member
+func (i *Impl) organizationMember(
+	ctx context.Context, organizationID, userID string,
+) (*model.OrganizationMember, error) {
+	res, err := i.Store.GetOrganizationMember(ctx, store.GetOrganizationMemberRequest{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, cError.ErrNotFound) {
+			return nil, errors.Wrap(cError.ErrNotAuthorized, "not a member of the organization")
+		}
+
+		return nil, err
+	}
+
+	return res.Member, nil
+}
+
+func (i *Impl) ensureAnotherOwner(ctx context.Context, organizationID, userID string) error {
+	res, err := i.Store.ListOrganizationMembers(ctx, store.ListOrganizationMembersRequest{
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, m := range res.Members {
+		if m.UserID != userID && m.Role == model.OrganizationRoleOwner {
+			return nil
+		}
+	}
+
+	return errors.Wrap(cError.ErrInvalidParameter, "the last owner cannot leave the organization")
+}
@@ -0,0 +1,459 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	mockstore "github.com/lruggieri/fxnow/common/mock/store"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/store"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+func TestImpl_CreateOrganization(t *testing.T) {
+	testErr := errors.New("error")
+
+	type args struct {
+		ctx context.Context
+		req CreateOrganizationRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, s *mockstore.Store)
+		assertion func(t *testing.T, res *CreateOrganizationResponse, err error)
+	}{
+		{
+			name: "error-no-user-info",
+			args: args{
+				ctx: context.Background(),
+				req: CreateOrganizationRequest{Name: "team"},
+			},
+			mock: func(args args, s *mockstore.Store) {},
+			assertion: func(t *testing.T, res *CreateOrganizationResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-missing-name",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOrganizationRequest{Name: " "},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOrganizationResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-create-organization",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOrganizationRequest{Name: "team"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().CreateOrganization(args.ctx, store.CreateOrganizationRequest{
+					Name:        "team",
+					OwnerUserID: "user_id",
+				}).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOrganizationResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path",
+			args: args{
+				ctx: uInfoCtx,
+				req: CreateOrganizationRequest{Name: " team "},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().CreateOrganization(args.ctx, store.CreateOrganizationRequest{
+					Name:        "team",
+					OwnerUserID: "user_id",
+				}).Return(&store.CreateOrganizationResponse{OrganizationID: "organization_id"}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionOrganizationCreated.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "organization_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateOrganizationResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &CreateOrganizationResponse{OrganizationID: "organization_id"}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockstore.NewStore(t)
+
+			l := Impl{
+				Store: s,
+			}
+
+			tc.mock(tc.args, s)
+
+			res, err := l.CreateOrganization(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_InviteOrganizationMember(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req InviteOrganizationMemberRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+
+	callerRequest := store.GetOrganizationMemberRequest{OrganizationID: "organization_id", UserID: "user_id"}
+	inviteeRequest := store.GetOrganizationMemberRequest{OrganizationID: "organization_id", UserID: "invitee_id"}
+
+	callerIs := func(s *mockstore.Store, role model.OrganizationRole) {
+		s.EXPECT().GetUser(uInfoCtx, store.GetUserRequest{Email: uInfo.Email}).
+			Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+		s.EXPECT().GetOrganizationMember(uInfoCtx, callerRequest).
+			Return(&store.GetOrganizationMemberResponse{Member: &model.OrganizationMember{
+				OrganizationID: "organization_id",
+				UserID:         "user_id",
+				Role:           role,
+			}}, nil).Once()
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, s *mockstore.Store)
+		assertion func(t *testing.T, res *InviteOrganizationMemberResponse, err error)
+	}{
+		{
+			name: "error-not-a-member",
+			args: args{
+				ctx: uInfoCtx,
+				req: InviteOrganizationMemberRequest{OrganizationID: "organization_id", Email: "invitee@domain.com"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().GetOrganizationMember(args.ctx, callerRequest).Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *InviteOrganizationMemberResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "error-caller-not-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: InviteOrganizationMemberRequest{OrganizationID: "organization_id", Email: "invitee@domain.com"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				callerIs(s, model.OrganizationRoleMember)
+			},
+			assertion: func(t *testing.T, res *InviteOrganizationMemberResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "error-role-above-caller",
+			args: args{
+				ctx: uInfoCtx,
+				req: InviteOrganizationMemberRequest{
+					OrganizationID: "organization_id",
+					Email:          "invitee@domain.com",
+					Role:           model.OrganizationRoleOwner,
+				},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				callerIs(s, model.OrganizationRoleAdmin)
+			},
+			assertion: func(t *testing.T, res *InviteOrganizationMemberResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "error-already-member",
+			args: args{
+				ctx: uInfoCtx,
+				req: InviteOrganizationMemberRequest{OrganizationID: "organization_id", Email: "invitee@domain.com"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				callerIs(s, model.OrganizationRoleAdmin)
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: "invitee@domain.com"}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "invitee_id"}}, nil).Once()
+				s.EXPECT().GetOrganizationMember(args.ctx, inviteeRequest).
+					Return(&store.GetOrganizationMemberResponse{Member: &model.OrganizationMember{}}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *InviteOrganizationMemberResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrDuplicated)
+			},
+		},
+		{
+			name: "happy-path-new-user",
+			args: args{
+				ctx: uInfoCtx,
+				req: InviteOrganizationMemberRequest{OrganizationID: "organization_id", Email: "invitee@domain.com"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				callerIs(s, model.OrganizationRoleAdmin)
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: "invitee@domain.com"}).
+					Return(nil, cError.ErrNotFound).Once()
+				s.EXPECT().CreateUser(args.ctx, store.CreateUserRequest{Email: "invitee@domain.com"}).
+					Return(&store.CreateUserResponse{UserID: "invitee_id"}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "invitee_id",
+					Action:       model.AuditActionUserCreated.Uint8(),
+					TargetUserID: "invitee_id",
+					TargetID:     "invitee_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+				s.EXPECT().GetOrganizationMember(args.ctx, inviteeRequest).Return(nil, cError.ErrNotFound).Once()
+				s.EXPECT().CreateOrganizationMember(args.ctx, store.CreateOrganizationMemberRequest{
+					OrganizationID: "organization_id",
+					UserID:         "invitee_id",
+					Role:           model.OrganizationRoleMember.Uint8(),
+				}).Return(&store.CreateOrganizationMemberResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionOrganizationMemberAdded.Uint8(),
+					TargetUserID: "invitee_id",
+					TargetID:     "organization_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *InviteOrganizationMemberResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &InviteOrganizationMemberResponse{UserID: "invitee_id"}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockstore.NewStore(t)
+
+			l := Impl{
+				Store: s,
+			}
+
+			tc.mock(tc.args, s)
+
+			res, err := l.InviteOrganizationMember(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_RemoveOrganizationMember(t *testing.T) {
+	testErr := errors.New("error")
+
+	type args struct {
+		ctx context.Context
+		req RemoveOrganizationMemberRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+
+	callerRequest := store.GetOrganizationMemberRequest{OrganizationID: "organization_id", UserID: "user_id"}
+	targetRequest := store.GetOrganizationMemberRequest{OrganizationID: "organization_id", UserID: "target_id"}
+
+	callerIs := func(s *mockstore.Store, role model.OrganizationRole) {
+		s.EXPECT().GetUser(uInfoCtx, store.GetUserRequest{Email: uInfo.Email}).
+			Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+		s.EXPECT().GetOrganizationMember(uInfoCtx, callerRequest).
+			Return(&store.GetOrganizationMemberResponse{Member: &model.OrganizationMember{
+				OrganizationID: "organization_id",
+				UserID:         "user_id",
+				Role:           role,
+			}}, nil).Once()
+	}
+	targetIs := func(s *mockstore.Store, role model.OrganizationRole) {
+		s.EXPECT().GetOrganizationMember(uInfoCtx, targetRequest).
+			Return(&store.GetOrganizationMemberResponse{Member: &model.OrganizationMember{
+				OrganizationID: "organization_id",
+				UserID:         "target_id",
+				Role:           role,
+			}}, nil).Once()
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, s *mockstore.Store)
+		assertion func(t *testing.T, res *RemoveOrganizationMemberResponse, err error)
+	}{
+		{
+			name: "error-member-removing-others",
+			args: args{
+				ctx: uInfoCtx,
+				req: RemoveOrganizationMemberRequest{OrganizationID: "organization_id", UserID: "target_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				callerIs(s, model.OrganizationRoleMember)
+				targetIs(s, model.OrganizationRoleMember)
+			},
+			assertion: func(t *testing.T, res *RemoveOrganizationMemberResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "error-admin-removing-owner",
+			args: args{
+				ctx: uInfoCtx,
+				req: RemoveOrganizationMemberRequest{OrganizationID: "organization_id", UserID: "target_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				callerIs(s, model.OrganizationRoleAdmin)
+				targetIs(s, model.OrganizationRoleOwner)
+			},
+			assertion: func(t *testing.T, res *RemoveOrganizationMemberResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "error-last-owner-leaving",
+			args: args{
+				ctx: uInfoCtx,
+				req: RemoveOrganizationMemberRequest{OrganizationID: "organization_id", UserID: "user_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				callerIs(s, model.OrganizationRoleOwner)
+				s.EXPECT().ListOrganizationMembers(args.ctx, store.ListOrganizationMembersRequest{
+					OrganizationID: "organization_id",
+				}).Return(&store.ListOrganizationMembersResponse{Members: []*model.OrganizationMember{
+					{UserID: "user_id", Role: model.OrganizationRoleOwner},
+					{UserID: "target_id", Role: model.OrganizationRoleAdmin},
+				}}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RemoveOrganizationMemberResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-delete-member",
+			args: args{
+				ctx: uInfoCtx,
+				req: RemoveOrganizationMemberRequest{OrganizationID: "organization_id", UserID: "target_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				callerIs(s, model.OrganizationRoleAdmin)
+				targetIs(s, model.OrganizationRoleMember)
+				s.EXPECT().DeleteOrganizationMember(args.ctx, store.DeleteOrganizationMemberRequest{
+					OrganizationID: "organization_id",
+					UserID:         "target_id",
+				}).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *RemoveOrganizationMemberResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path-leaving",
+			args: args{
+				ctx: uInfoCtx,
+				req: RemoveOrganizationMemberRequest{OrganizationID: "organization_id", UserID: "user_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				// keys of the organization are left untouched
+				callerIs(s, model.OrganizationRoleMember)
+				s.EXPECT().DeleteOrganizationMember(args.ctx, store.DeleteOrganizationMemberRequest{
+					OrganizationID: "organization_id",
+					UserID:         "user_id",
+				}).Return(&store.DeleteOrganizationMemberResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionOrganizationMemberRemoved.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "organization_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RemoveOrganizationMemberResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &RemoveOrganizationMemberResponse{}, res)
+			},
+		},
+		{
+			name: "happy-path-owner-removing-owner",
+			args: args{
+				ctx: uInfoCtx,
+				req: RemoveOrganizationMemberRequest{OrganizationID: "organization_id", UserID: "target_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				callerIs(s, model.OrganizationRoleOwner)
+				targetIs(s, model.OrganizationRoleOwner)
+				s.EXPECT().ListOrganizationMembers(args.ctx, store.ListOrganizationMembersRequest{
+					OrganizationID: "organization_id",
+				}).Return(&store.ListOrganizationMembersResponse{Members: []*model.OrganizationMember{
+					{UserID: "user_id", Role: model.OrganizationRoleOwner},
+					{UserID: "target_id", Role: model.OrganizationRoleOwner},
+				}}, nil).Once()
+				s.EXPECT().DeleteOrganizationMember(args.ctx, store.DeleteOrganizationMemberRequest{
+					OrganizationID: "organization_id",
+					UserID:         "target_id",
+				}).Return(&store.DeleteOrganizationMemberResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionOrganizationMemberRemoved.Uint8(),
+					TargetUserID: "target_id",
+					TargetID:     "organization_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RemoveOrganizationMemberResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &RemoveOrganizationMemberResponse{}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockstore.NewStore(t)
+
+			l := Impl{
+				Store: s,
+			}
+
+			tc.mock(tc.args, s)
+
+			res, err := l.RemoveOrganizationMember(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
@@ -1,25 +1,56 @@
 package logic
 
-import "github.com/lruggieri/fxnow/common/model"
+import (
+	"time"
 
-type ListAPIKeysRequest struct{}
+	"github.com/lruggieri/fxnow/common/model"
+)
+
+type ListAPIKeysRequest struct {
+	// OrganizationID : Optional. List the keys of the organization instead of the personal ones.
+	OrganizationID string
+}
 
 type ListAPIKeysResponse struct {
 	APIKeys []*model.APIKey
 }
 
-type CreateAPIKeyRequest struct{}
+type CreateAPIKeyRequest struct {
+	// OrganizationID : Optional. Create a key owned by the organization instead of a personal one.
+	OrganizationID string
+}
 
 type CreateAPIKeyResponse struct {
 	APIKeyID string
 }
 
+type ChangeAPIKeyPlanRequest struct {
+	APIKeyID string
+	PlanID   string
+}
+
+type ChangeAPIKeyPlanResponse struct{}
+
+type ListPlansRequest struct{}
+
+type ListPlansResponse struct {
+	Plans []*model.Plan
+}
+
 type DeleteAPIKeyRequest struct {
 	APIKeyID string
 }
 
 type DeleteAPIKeyResponse struct{}
 
+type RotateAPIKeyRequest struct {
+	APIKeyID string
+}
+
+type RotateAPIKeyResponse struct {
+	APIKeyID string
+}
+
 type CreateUserRequest struct {
 	FirstName string
 	LastName  string
@@ -29,3 +60,186 @@ type CreateUserRequest struct {
 type CreateUserResponse struct {
 	UserID string
 }
+
+type LoginRequest struct{}
+
+type LoginResponse struct {
+	UserID string
+	Tokens SessionTokens
+}
+
+// SessionTokens : credentials of a first-party session. Only their hashes are persisted.
+type SessionTokens struct {
+	SessionID         string
+	Token             string
+	RefreshToken      string
+	Expiration        int64 // Unix time (seconds)
+	RefreshExpiration int64 // Unix time (seconds)
+}
+
+type AuthenticateRequest struct {
+	SessionToken string
+}
+
+type AuthenticateResponse struct {
+	Session *model.Session
+	User    *model.User
+}
+
+type RefreshSessionRequest struct {
+	RefreshToken string
+}
+
+type RefreshSessionResponse struct {
+	Tokens  SessionTokens
+	Session *model.Session
+	User    *model.User
+}
+
+type LogoutRequest struct {
+	SessionToken string
+}
+
+type LogoutResponse struct{}
+
+type ListSessionsRequest struct{}
+
+type ListSessionsResponse struct {
+	Sessions []*model.Session
+}
+
+type RevokeSessionRequest struct {
+	SessionID string
+}
+
+type RevokeSessionResponse struct{}
+
+type ListAuditLogsRequest struct {
+	// UserID : Optional. Only admins can request entries of users other than themselves.
+	UserID  string
+	Actions []model.AuditAction
+	From    int64 // Unix time (seconds)
+	To      int64 // Unix time (seconds)
+
+	Offset int
+	Limit  int
+}
+
+type ListAuditLogsResponse struct {
+	AuditLogs []*model.AuditLog
+}
+
+type CreateOAuthClientRequest struct {
+	Name string
+	// Scopes : Optional. Defaults to all scopes.
+	Scopes []string
+	// PlanID : Optional. Only admins can choose the plan, other users get free clients.
+	PlanID string
+}
+
+type CreateOAuthClientResponse struct {
+	ClientID string
+	// ClientSecret : only returned at creation, it cannot be retrieved afterwards
+	ClientSecret string
+}
+
+type ListOAuthClientsRequest struct{}
+
+type ListOAuthClientsResponse struct {
+	OAuthClients []*model.OAuthClient
+}
+
+type DeleteOAuthClientRequest struct {
+	ClientID string
+}
+
+type DeleteOAuthClientResponse struct{}
+
+type IssueClientTokenRequest struct {
+	ClientID     string
+	ClientSecret string
+	// Scopes : Optional. Subset of the client scopes to be granted to the token, all of them by default.
+	Scopes []string
+}
+
+type IssueClientTokenResponse struct {
+	AccessToken string
+	Expiration  int64 // Unix time (seconds)
+	Scopes      []string
+}
+
+type CreateOrganizationRequest struct {
+	Name string
+}
+
+type CreateOrganizationResponse struct {
+	OrganizationID string
+}
+
+type ListOrganizationsRequest struct{}
+
+type ListOrganizationsResponse struct {
+	Organizations []*model.Organization
+}
+
+type ListOrganizationMembersRequest struct {
+	OrganizationID string
+}
+
+type ListOrganizationMembersResponse struct {
+	Members []*model.OrganizationMember
+}
+
+type InviteOrganizationMemberRequest struct {
+	OrganizationID string
+	Email          string
+	// Role : Optional. Defaults to member, and cannot be higher than the role of the inviter.
+	Role model.OrganizationRole
+}
+
+type InviteOrganizationMemberResponse struct {
+	UserID string
+}
+
+type RemoveOrganizationMemberRequest struct {
+	OrganizationID string
+	UserID         string
+}
+
+type RemoveOrganizationMemberResponse struct{}
+
+type CreateWebhookRequest struct {
+	URL       string
+	Pair      string
+	Condition model.WebhookCondition
+	// Threshold : rate for crosses conditions, percentage for change ones
+	Threshold float64
+	// Window : change conditions only
+	Window time.Duration
+}
+
+type CreateWebhookResponse struct {
+	WebhookID string
+	// Secret : only returned at creation, it cannot be retrieved afterwards
+	Secret string
+}
+
+type ListWebhooksRequest struct{}
+
+type ListWebhooksResponse struct {
+	Webhooks []*model.Webhook
+}
+
+type DeleteWebhookRequest struct {
+	WebhookID string
+}
+
+type DeleteWebhookResponse struct{}
+
+type ListWebhookDeadLettersRequest struct {
+	WebhookID string
+}
+
+type ListWebhookDeadLettersResponse struct {
+	DeadLetters []*model.WebhookDeadLetter
+}
@@ -0,0 +1,86 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/store"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+func (i *Impl) ListPlans(ctx context.Context, _ ListPlansRequest) (*ListPlansResponse, error) {
+	res, err := i.Store.ListPlans(ctx, store.ListPlansRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListPlansResponse{
+		Plans: res.Plans,
+	}, nil
+}
+
+// ChangeAPIKeyPlan : moves a key to another plan. The key does not change, fxrate picks up the new plan within
+// a minute.
+func (i *Impl) ChangeAPIKeyPlan(ctx context.Context, req ChangeAPIKeyPlanRequest) (*ChangeAPIKeyPlanResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !dbUInfo.User.IsAdmin() {
+		return nil, errors.Wrap(cError.ErrNotAuthorized, "only admins can change plans")
+	}
+
+	if err = i.checkPlan(ctx, req.PlanID); err != nil {
+		return nil, err
+	}
+
+	apiKey, err := i.Store.GetAPIKey(ctx, store.GetAPIKeyRequest{APIKeyID: req.APIKeyID})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = i.Store.UpdateAPIKey(ctx, store.UpdateAPIKeyRequest{
+		APIKeyID: apiKey.APIKey.APIKeyID,
+		PlanID:   req.PlanID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:          dbUInfo.User.UserID,
+		Action:               model.AuditActionAPIKeyPlanChanged.Uint8(),
+		TargetUserID:         apiKey.APIKey.UserID,
+		TargetID:             apiKey.APIKey.APIKeyID,
+		TargetOrganizationID: apiKey.APIKey.OrganizationID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &ChangeAPIKeyPlanResponse{}, nil
+}
+
+// checkPlan : returns ErrInvalidParameter if the plan is not in the catalog
+func (i *Impl) checkPlan(ctx context.Context, planID string) error {
+	if _, err := i.Store.GetPlan(ctx, store.GetPlanRequest{PlanID: planID}); err != nil {
+		if errors.Is(err, cError.ErrNotFound) {
+			return errors.Wrap(cError.ErrInvalidParameter, fmt.Sprintf("unknown plan '%s'", planID))
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	mockstore "github.com/lruggieri/fxnow/common/mock/store"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/store"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+func TestImpl_ChangeAPIKeyPlan(t *testing.T) {
+	testErr := errors.New("error")
+
+	type args struct {
+		ctx context.Context
+		req ChangeAPIKeyPlanRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+	user := &model.User{UserID: "user_id"}
+	admin := &model.User{UserID: "admin_id", Role: model.UserRoleAdmin}
+	apiKey := &model.APIKey{APIKeyID: "api_key_id", UserID: "user_id", PlanID: model.PlanFree}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, s *mockstore.Store)
+		assertion func(t *testing.T, res *ChangeAPIKeyPlanResponse, err error)
+	}{
+		{
+			name: "error-no-user-info",
+			args: args{
+				ctx: context.Background(),
+				req: ChangeAPIKeyPlanRequest{APIKeyID: "api_key_id", PlanID: model.PlanUnlimited},
+			},
+			mock: func(args args, s *mockstore.Store) {},
+			assertion: func(t *testing.T, res *ChangeAPIKeyPlanResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-not-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: ChangeAPIKeyPlanRequest{APIKeyID: "api_key_id", PlanID: model.PlanUnlimited},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *ChangeAPIKeyPlanResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "error-unknown-plan",
+			args: args{
+				ctx: uInfoCtx,
+				req: ChangeAPIKeyPlanRequest{APIKeyID: "api_key_id", PlanID: "gold"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: admin}, nil).Once()
+				s.EXPECT().GetPlan(args.ctx, store.GetPlanRequest{PlanID: "gold"}).
+					Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *ChangeAPIKeyPlanResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-update-api-key",
+			args: args{
+				ctx: uInfoCtx,
+				req: ChangeAPIKeyPlanRequest{APIKeyID: "api_key_id", PlanID: model.PlanUnlimited},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: admin}, nil).Once()
+				s.EXPECT().GetPlan(args.ctx, store.GetPlanRequest{PlanID: model.PlanUnlimited}).
+					Return(&store.GetPlanResponse{Plan: &model.Plan{PlanID: model.PlanUnlimited}}, nil).Once()
+				s.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key_id"}).
+					Return(&store.GetAPIKeyResponse{APIKey: apiKey}, nil).Once()
+				s.EXPECT().UpdateAPIKey(args.ctx, store.UpdateAPIKeyRequest{
+					APIKeyID: "api_key_id",
+					PlanID:   model.PlanUnlimited,
+				}).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *ChangeAPIKeyPlanResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path",
+			args: args{
+				ctx: uInfoCtx,
+				req: ChangeAPIKeyPlanRequest{APIKeyID: "api_key_id", PlanID: model.PlanUnlimited},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: admin}, nil).Once()
+				s.EXPECT().GetPlan(args.ctx, store.GetPlanRequest{PlanID: model.PlanUnlimited}).
+					Return(&store.GetPlanResponse{Plan: &model.Plan{PlanID: model.PlanUnlimited}}, nil).Once()
+				s.EXPECT().GetAPIKey(args.ctx, store.GetAPIKeyRequest{APIKeyID: "api_key_id"}).
+					Return(&store.GetAPIKeyResponse{APIKey: apiKey}, nil).Once()
+				s.EXPECT().UpdateAPIKey(args.ctx, store.UpdateAPIKeyRequest{
+					APIKeyID: "api_key_id",
+					PlanID:   model.PlanUnlimited,
+				}).Return(&store.UpdateAPIKeyResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "admin_id",
+					Action:       model.AuditActionAPIKeyPlanChanged.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "api_key_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *ChangeAPIKeyPlanResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &ChangeAPIKeyPlanResponse{}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockstore.NewStore(t)
+
+			l := Impl{
+				Store: s,
+			}
+
+			tc.mock(tc.args, s)
+
+			res, err := l.ChangeAPIKeyPlan(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
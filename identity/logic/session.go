@@ -0,0 +1,277 @@
+package logic
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/store"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+const (
+	// SessionLifetime : validity of a session token. Expired sessions can be renewed with their refresh token.
+	SessionLifetime = time.Hour
+	// RefreshLifetime : validity of a refresh token. After that, users have to log in again.
+	RefreshLifetime = 30 * 24 * time.Hour
+
+	sessionTokenBytes = 32
+)
+
+func (i *Impl) Authenticate(ctx context.Context, req AuthenticateRequest) (*AuthenticateResponse, error) {
+	if req.SessionToken == "" {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	sRes, err := i.Store.GetSession(ctx, store.GetSessionRequest{TokenHash: hashToken(req.SessionToken)})
+	if err != nil {
+		if errors.Is(err, cError.ErrNotFound) {
+			return nil, errors.Wrap(cError.ErrNotAuthenticated, "invalid session")
+		}
+
+		return nil, err
+	}
+
+	if sRes.Session.Expiration <= i.now().Unix() {
+		return nil, errors.Wrap(cError.ErrNotAuthenticated, "session expired")
+	}
+
+	return i.sessionUser(ctx, sRes.Session)
+}
+
+func (i *Impl) RefreshSession(ctx context.Context, req RefreshSessionRequest) (*RefreshSessionResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	sRes, err := i.Store.GetSession(ctx, store.GetSessionRequest{RefreshTokenHash: hashToken(req.RefreshToken)})
+	if err != nil {
+		if errors.Is(err, cError.ErrNotFound) {
+			return nil, errors.Wrap(cError.ErrNotAuthenticated, "invalid refresh token")
+		}
+
+		return nil, err
+	}
+
+	if sRes.Session.RefreshExpiration <= i.now().Unix() {
+		return nil, errors.Wrap(cError.ErrNotAuthenticated, "refresh token expired")
+	}
+
+	// both tokens are rotated, so that a leaked refresh token can only be used once
+	tokens, err := newSessionTokens(i.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = i.Store.UpdateSession(ctx, store.UpdateSessionRequest{
+		SessionID:         sRes.Session.SessionID,
+		TokenHash:         hashToken(tokens.Token),
+		RefreshTokenHash:  hashToken(tokens.RefreshToken),
+		Expiration:        tokens.Expiration,
+		RefreshExpiration: tokens.RefreshExpiration,
+	}); err != nil {
+		return nil, err
+	}
+
+	sRes.Session.Expiration = tokens.Expiration
+	sRes.Session.RefreshExpiration = tokens.RefreshExpiration
+
+	aRes, err := i.sessionUser(ctx, sRes.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefreshSessionResponse{
+		Tokens:  *tokens,
+		Session: aRes.Session,
+		User:    aRes.User,
+	}, nil
+}
+
+func (i *Impl) Logout(ctx context.Context, req LogoutRequest) (*LogoutResponse, error) {
+	aRes, err := i.Authenticate(ctx, AuthenticateRequest{SessionToken: req.SessionToken})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = i.Store.DeleteSession(ctx, store.DeleteSessionRequest{SessionID: aRes.Session.SessionID}); err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  aRes.User.UserID,
+		Action:       model.AuditActionLogout.Uint8(),
+		TargetUserID: aRes.User.UserID,
+		TargetID:     aRes.Session.SessionID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &LogoutResponse{}, nil
+}
+
+func (i *Impl) ListSessions(ctx context.Context, _ ListSessionsRequest) (*ListSessionsResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.Store.ListSessions(ctx, store.ListSessionsRequest{UserID: dbUInfo.User.UserID})
+	if err != nil && !errors.Is(err, cError.ErrNotFound) {
+		return nil, err
+	}
+
+	sessions := make([]*model.Session, 0)
+
+	if res != nil {
+		now := i.now().Unix()
+
+		// sessions that cannot be refreshed anymore are dead, no need to show them
+		for _, s := range res.Sessions {
+			if s.RefreshExpiration > now {
+				sessions = append(sessions, s)
+			}
+		}
+	}
+
+	return &ListSessionsResponse{
+		Sessions: sessions,
+	}, nil
+}
+
+func (i *Impl) RevokeSession(ctx context.Context, req RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sRes, err := i.Store.GetSession(ctx, store.GetSessionRequest{SessionID: req.SessionID})
+	if err != nil {
+		return nil, err
+	}
+
+	// only the session owners can revoke their own sessions, unless the caller is an admin
+	if sRes.Session.UserID != dbUInfo.User.UserID && !dbUInfo.User.IsAdmin() {
+		return nil, errors.Wrap(cError.ErrNotAuthorized, "only session owners can revoke their own sessions")
+	}
+
+	if _, err = i.Store.DeleteSession(ctx, store.DeleteSessionRequest{SessionID: sRes.Session.SessionID}); err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  dbUInfo.User.UserID,
+		Action:       model.AuditActionSessionRevoked.Uint8(),
+		TargetUserID: sRes.Session.UserID,
+		TargetID:     sRes.Session.SessionID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &RevokeSessionResponse{}, nil
+}
+
+// createSession : issues a new session for the user
+func (i *Impl) createSession(ctx context.Context, userID, provider string) (*SessionTokens, error) {
+	tokens, err := newSessionTokens(i.now())
+	if err != nil {
+		return nil, err
+	}
+
+	req := store.CreateSessionRequest{
+		UserID:            userID,
+		TokenHash:         hashToken(tokens.Token),
+		RefreshTokenHash:  hashToken(tokens.RefreshToken),
+		Provider:          provider,
+		Expiration:        tokens.Expiration,
+		RefreshExpiration: tokens.RefreshExpiration,
+	}
+
+	if cInfo := GetClientInfoFromContext(ctx); cInfo != nil {
+		req.IP = cInfo.IP
+		req.UserAgent = cInfo.UserAgent
+	}
+
+	sRes, err := i.Store.CreateSession(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens.SessionID = sRes.SessionID
+
+	return tokens, nil
+}
+
+// sessionUser : resolves the owner of a valid session
+func (i *Impl) sessionUser(ctx context.Context, session *model.Session) (*AuthenticateResponse, error) {
+	uRes, err := i.Store.GetUser(ctx, store.GetUserRequest{UserID: session.UserID})
+	if err != nil {
+		if errors.Is(err, cError.ErrNotFound) {
+			return nil, errors.Wrap(cError.ErrNotAuthenticated, "session user not found")
+		}
+
+		return nil, err
+	}
+
+	return &AuthenticateResponse{
+		Session: session,
+		User:    uRes.User,
+	}, nil
+}
+
+func newSessionTokens(now time.Time) (*SessionTokens, error) {
+	token, err := newSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := newSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionTokens{
+		Token:             token,
+		RefreshToken:      refreshToken,
+		Expiration:        now.Add(SessionLifetime).Unix(),
+		RefreshExpiration: now.Add(RefreshLifetime).Unix(),
+	}, nil
+}
+
+// newSecureToken : opaque random token. Only its hash is persisted.
+func newSecureToken() (string, error) {
+	b := make([]byte, sessionTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "cannot generate session token")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,680 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	mockclock "github.com/lruggieri/fxnow/common/mock/clock"
+	mockstore "github.com/lruggieri/fxnow/common/mock/store"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/store"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+func TestImpl_Authenticate(t *testing.T) {
+	testErr := errors.New("error")
+
+	type deps struct {
+		store *mockstore.Store
+		clock *mockclock.Clock
+	}
+
+	type args struct {
+		ctx context.Context
+		req AuthenticateRequest
+	}
+
+	now := time.Unix(1700000000, 0)
+	session := &model.Session{
+		SessionID:         "session_id",
+		UserID:            "user_id",
+		Expiration:        now.Add(time.Minute).Unix(),
+		RefreshExpiration: now.Add(time.Hour).Unix(),
+	}
+	user := &model.User{UserID: "user_id", Email: "user@domain.com"}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, d deps)
+		assertion func(t *testing.T, res *AuthenticateResponse, err error)
+	}{
+		{
+			name: "error-no-token",
+			args: args{
+				ctx: context.Background(),
+				req: AuthenticateRequest{},
+			},
+			mock: func(args args, d deps) {},
+			assertion: func(t *testing.T, res *AuthenticateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-session-not-found",
+			args: args{
+				ctx: context.Background(),
+				req: AuthenticateRequest{SessionToken: "token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{TokenHash: hashToken("token")}).
+					Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *AuthenticateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-get-session",
+			args: args{
+				ctx: context.Background(),
+				req: AuthenticateRequest{SessionToken: "token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{TokenHash: hashToken("token")}).
+					Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *AuthenticateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "error-session-expired",
+			args: args{
+				ctx: context.Background(),
+				req: AuthenticateRequest{SessionToken: "token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{TokenHash: hashToken("token")}).
+					Return(&store.GetSessionResponse{Session: session}, nil).Once()
+				d.clock.EXPECT().Now().Return(now.Add(time.Minute)).Once()
+			},
+			assertion: func(t *testing.T, res *AuthenticateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-user-not-found",
+			args: args{
+				ctx: context.Background(),
+				req: AuthenticateRequest{SessionToken: "token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{TokenHash: hashToken("token")}).
+					Return(&store.GetSessionResponse{Session: session}, nil).Once()
+				d.clock.EXPECT().Now().Return(now).Once()
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{UserID: "user_id"}).
+					Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *AuthenticateResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "happy-path",
+			args: args{
+				ctx: context.Background(),
+				req: AuthenticateRequest{SessionToken: "token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{TokenHash: hashToken("token")}).
+					Return(&store.GetSessionResponse{Session: session}, nil).Once()
+				d.clock.EXPECT().Now().Return(now).Once()
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{UserID: "user_id"}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *AuthenticateResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &AuthenticateResponse{Session: session, User: user}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				store: mockstore.NewStore(t),
+				clock: mockclock.NewClock(t),
+			}
+
+			l := Impl{
+				Store: d.store,
+				Clock: d.clock,
+			}
+
+			tc.mock(tc.args, d)
+
+			res, err := l.Authenticate(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_RefreshSession(t *testing.T) {
+	testErr := errors.New("error")
+
+	type deps struct {
+		store *mockstore.Store
+		clock *mockclock.Clock
+	}
+
+	type args struct {
+		ctx context.Context
+		req RefreshSessionRequest
+	}
+
+	now := time.Unix(1700000000, 0)
+	newSession := func() *model.Session {
+		return &model.Session{
+			SessionID:         "session_id",
+			UserID:            "user_id",
+			Expiration:        now.Add(-time.Minute).Unix(),
+			RefreshExpiration: now.Add(time.Hour).Unix(),
+		}
+	}
+	user := &model.User{UserID: "user_id", Email: "user@domain.com"}
+	refreshReq := store.GetSessionRequest{RefreshTokenHash: hashToken("refresh_token")}
+	updateRequest := func(req store.UpdateSessionRequest) bool {
+		return req.SessionID == "session_id" &&
+			len(req.TokenHash) == 64 && len(req.RefreshTokenHash) == 64 &&
+			req.RefreshTokenHash != hashToken("refresh_token") &&
+			req.Expiration == now.Add(SessionLifetime).Unix() &&
+			req.RefreshExpiration == now.Add(RefreshLifetime).Unix()
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, d deps)
+		assertion func(t *testing.T, res *RefreshSessionResponse, err error)
+	}{
+		{
+			name: "error-no-token",
+			args: args{
+				ctx: context.Background(),
+				req: RefreshSessionRequest{},
+			},
+			mock: func(args args, d deps) {},
+			assertion: func(t *testing.T, res *RefreshSessionResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-session-not-found",
+			args: args{
+				ctx: context.Background(),
+				req: RefreshSessionRequest{RefreshToken: "refresh_token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, refreshReq).Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *RefreshSessionResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-refresh-expired",
+			args: args{
+				ctx: context.Background(),
+				req: RefreshSessionRequest{RefreshToken: "refresh_token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, refreshReq).
+					Return(&store.GetSessionResponse{Session: newSession()}, nil).Once()
+				d.clock.EXPECT().Now().Return(now.Add(time.Hour)).Once()
+			},
+			assertion: func(t *testing.T, res *RefreshSessionResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-update-session",
+			args: args{
+				ctx: context.Background(),
+				req: RefreshSessionRequest{RefreshToken: "refresh_token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, refreshReq).
+					Return(&store.GetSessionResponse{Session: newSession()}, nil).Once()
+				d.clock.EXPECT().Now().Return(now).Twice()
+				d.store.EXPECT().UpdateSession(args.ctx, mock.MatchedBy(updateRequest)).Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *RefreshSessionResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path",
+			args: args{
+				ctx: context.Background(),
+				req: RefreshSessionRequest{RefreshToken: "refresh_token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, refreshReq).
+					Return(&store.GetSessionResponse{Session: newSession()}, nil).Once()
+				d.clock.EXPECT().Now().Return(now).Twice()
+				d.store.EXPECT().UpdateSession(args.ctx, mock.MatchedBy(updateRequest)).
+					Return(&store.UpdateSessionResponse{}, nil).Once()
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{UserID: "user_id"}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RefreshSessionResponse, err error) {
+				assert.Nil(t, err)
+				assert.NotEmpty(t, res.Tokens.Token)
+				assert.NotEmpty(t, res.Tokens.RefreshToken)
+				assert.NotEqual(t, "refresh_token", res.Tokens.RefreshToken)
+				assert.Equal(t, now.Add(SessionLifetime).Unix(), res.Session.Expiration)
+				assert.Equal(t, now.Add(RefreshLifetime).Unix(), res.Session.RefreshExpiration)
+				assert.Equal(t, user, res.User)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				store: mockstore.NewStore(t),
+				clock: mockclock.NewClock(t),
+			}
+
+			l := Impl{
+				Store: d.store,
+				Clock: d.clock,
+			}
+
+			tc.mock(tc.args, d)
+
+			res, err := l.RefreshSession(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_Logout(t *testing.T) {
+	testErr := errors.New("error")
+
+	type deps struct {
+		store *mockstore.Store
+		clock *mockclock.Clock
+	}
+
+	type args struct {
+		ctx context.Context
+		req LogoutRequest
+	}
+
+	now := time.Unix(1700000000, 0)
+	session := &model.Session{
+		SessionID:         "session_id",
+		UserID:            "user_id",
+		Expiration:        now.Add(time.Minute).Unix(),
+		RefreshExpiration: now.Add(time.Hour).Unix(),
+	}
+	user := &model.User{UserID: "user_id", Email: "user@domain.com"}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, d deps)
+		assertion func(t *testing.T, res *LogoutResponse, err error)
+	}{
+		{
+			name: "error-invalid-session",
+			args: args{
+				ctx: context.Background(),
+				req: LogoutRequest{SessionToken: "token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{TokenHash: hashToken("token")}).
+					Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *LogoutResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-delete-session",
+			args: args{
+				ctx: context.Background(),
+				req: LogoutRequest{SessionToken: "token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{TokenHash: hashToken("token")}).
+					Return(&store.GetSessionResponse{Session: session}, nil).Once()
+				d.clock.EXPECT().Now().Return(now).Once()
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{UserID: "user_id"}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+				d.store.EXPECT().DeleteSession(args.ctx, store.DeleteSessionRequest{SessionID: "session_id"}).
+					Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *LogoutResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path",
+			args: args{
+				ctx: context.Background(),
+				req: LogoutRequest{SessionToken: "token"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{TokenHash: hashToken("token")}).
+					Return(&store.GetSessionResponse{Session: session}, nil).Once()
+				d.clock.EXPECT().Now().Return(now).Once()
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{UserID: "user_id"}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+				d.store.EXPECT().DeleteSession(args.ctx, store.DeleteSessionRequest{SessionID: "session_id"}).
+					Return(&store.DeleteSessionResponse{}, nil).Once()
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionLogout.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "session_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *LogoutResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &LogoutResponse{}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				store: mockstore.NewStore(t),
+				clock: mockclock.NewClock(t),
+			}
+
+			l := Impl{
+				Store: d.store,
+				Clock: d.clock,
+			}
+
+			tc.mock(tc.args, d)
+
+			res, err := l.Logout(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_ListSessions(t *testing.T) {
+	testErr := errors.New("error")
+
+	type deps struct {
+		store *mockstore.Store
+		clock *mockclock.Clock
+	}
+
+	type args struct {
+		ctx context.Context
+		req ListSessionsRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+	now := time.Unix(1700000000, 0)
+	active := &model.Session{SessionID: "active", UserID: "user_id", RefreshExpiration: now.Add(time.Hour).Unix()}
+	dead := &model.Session{SessionID: "dead", UserID: "user_id", RefreshExpiration: now.Unix()}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, d deps)
+		assertion func(t *testing.T, res *ListSessionsResponse, err error)
+	}{
+		{
+			name: "error-no-user-info",
+			args: args{
+				ctx: context.Background(),
+			},
+			mock: func(args args, d deps) {},
+			assertion: func(t *testing.T, res *ListSessionsResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-list-sessions",
+			args: args{
+				ctx: uInfoCtx,
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				d.store.EXPECT().ListSessions(args.ctx, store.ListSessionsRequest{UserID: "user_id"}).
+					Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *ListSessionsResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path-skip-dead-sessions",
+			args: args{
+				ctx: uInfoCtx,
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				d.store.EXPECT().ListSessions(args.ctx, store.ListSessionsRequest{UserID: "user_id"}).
+					Return(&store.ListSessionsResponse{Sessions: []*model.Session{active, dead}}, nil).Once()
+				d.clock.EXPECT().Now().Return(now).Once()
+			},
+			assertion: func(t *testing.T, res *ListSessionsResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &ListSessionsResponse{Sessions: []*model.Session{active}}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				store: mockstore.NewStore(t),
+				clock: mockclock.NewClock(t),
+			}
+
+			l := Impl{
+				Store: d.store,
+				Clock: d.clock,
+			}
+
+			tc.mock(tc.args, d)
+
+			res, err := l.ListSessions(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_RevokeSession(t *testing.T) {
+	testErr := errors.New("error")
+
+	type deps struct {
+		store *mockstore.Store
+	}
+
+	type args struct {
+		ctx context.Context
+		req RevokeSessionRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+	ownSession := &model.Session{SessionID: "session_id", UserID: "user_id"}
+	otherSession := &model.Session{SessionID: "session_id", UserID: "other_user_id"}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, d deps)
+		assertion func(t *testing.T, res *RevokeSessionResponse, err error)
+	}{
+		{
+			name: "error-no-user-info",
+			args: args{
+				ctx: context.Background(),
+				req: RevokeSessionRequest{SessionID: "session_id"},
+			},
+			mock: func(args args, d deps) {},
+			assertion: func(t *testing.T, res *RevokeSessionResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-get-session",
+			args: args{
+				ctx: uInfoCtx,
+				req: RevokeSessionRequest{SessionID: "session_id"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{SessionID: "session_id"}).
+					Return(nil, cError.ErrNotFound).Once()
+			},
+			assertion: func(t *testing.T, res *RevokeSessionResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotFound)
+			},
+		},
+		{
+			name: "error-other-user-session",
+			args: args{
+				ctx: uInfoCtx,
+				req: RevokeSessionRequest{SessionID: "session_id"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{SessionID: "session_id"}).
+					Return(&store.GetSessionResponse{Session: otherSession}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RevokeSessionResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "error-delete-session",
+			args: args{
+				ctx: uInfoCtx,
+				req: RevokeSessionRequest{SessionID: "session_id"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{SessionID: "session_id"}).
+					Return(&store.GetSessionResponse{Session: ownSession}, nil).Once()
+				d.store.EXPECT().DeleteSession(args.ctx, store.DeleteSessionRequest{SessionID: "session_id"}).
+					Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *RevokeSessionResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path-own-session",
+			args: args{
+				ctx: uInfoCtx,
+				req: RevokeSessionRequest{SessionID: "session_id"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{SessionID: "session_id"}).
+					Return(&store.GetSessionResponse{Session: ownSession}, nil).Once()
+				d.store.EXPECT().DeleteSession(args.ctx, store.DeleteSessionRequest{SessionID: "session_id"}).
+					Return(&store.DeleteSessionResponse{}, nil).Once()
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionSessionRevoked.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "session_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RevokeSessionResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &RevokeSessionResponse{}, res)
+			},
+		},
+		{
+			name: "happy-path-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: RevokeSessionRequest{SessionID: "session_id"},
+			},
+			mock: func(args args, d deps) {
+				d.store.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{
+						UserID: "user_id",
+						Role:   model.UserRoleAdmin,
+					}}, nil).Once()
+				d.store.EXPECT().GetSession(args.ctx, store.GetSessionRequest{SessionID: "session_id"}).
+					Return(&store.GetSessionResponse{Session: otherSession}, nil).Once()
+				d.store.EXPECT().DeleteSession(args.ctx, store.DeleteSessionRequest{SessionID: "session_id"}).
+					Return(&store.DeleteSessionResponse{}, nil).Once()
+				d.store.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionSessionRevoked.Uint8(),
+					TargetUserID: "other_user_id",
+					TargetID:     "session_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *RevokeSessionResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &RevokeSessionResponse{}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				store: mockstore.NewStore(t),
+			}
+
+			l := Impl{
+				Store: d.store,
+			}
+
+			tc.mock(tc.args, d)
+
+			res, err := l.RevokeSession(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/lruggieri/fxnow/common/util"
+)
+
+const (
+	ContextKeyClientInfo util.ContextKey = "client-info"
+)
+
+// ClientInfo : information about the client performing the request, used for auditing
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+func GetClientInfoFromContext(ctx context.Context) *ClientInfo {
+	if ctx == nil {
+		return nil
+	}
+
+	clientInfo, ok := ctx.Value(ContextKeyClientInfo).(*ClientInfo)
+	if !ok {
+		return nil
+	}
+
+	return clientInfo
+}
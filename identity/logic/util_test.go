@@ -0,0 +1,23 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClientInfoFromContext(t *testing.T) {
+	cInfo := &ClientInfo{
+		IP:        "127.0.0.1",
+		UserAgent: "curl/8.0.0",
+	}
+	cInfoCtx := context.WithValue(context.Background(), ContextKeyClientInfo, cInfo)
+	wrongCInfoCtx := context.WithValue(context.Background(), ContextKeyClientInfo, 42)
+
+	//nolint:staticcheck
+	assert.Equal(t, (*ClientInfo)(nil), GetClientInfoFromContext(nil))
+	assert.Equal(t, (*ClientInfo)(nil), GetClientInfoFromContext(context.Background()))
+	assert.Equal(t, (*ClientInfo)(nil), GetClientInfoFromContext(wrongCInfoCtx))
+	assert.Equal(t, cInfo, GetClientInfoFromContext(cInfoCtx))
+}
@@ -0,0 +1,234 @@
+package logic
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lruggieri/fxnow/common/client/httpclient"
+	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/store"
+	"github.com/lruggieri/fxnow/common/util"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+const (
+	MaxWebhooksPerUser   = 20
+	MaxWebhookURLLength  = 2048
+	MinWebhookWindow     = time.Minute
+	maxWebhookPercentage = 100
+)
+
+// CreateWebhook : registers a URL to be notified when the rate of a pair meets the condition. Payloads are signed
+// with the returned secret.
+func (i *Impl) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateWebhook(req); err != nil {
+		return nil, err
+	}
+
+	existing, err := i.Store.ListWebhooks(ctx, store.ListWebhooksRequest{UserID: dbUInfo.User.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing.Webhooks) >= MaxWebhooksPerUser {
+		return nil, errors.Wrap(cError.ErrInvalidParameter, "too many webhooks")
+	}
+
+	secret, err := newSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	window := int64(req.Window.Seconds())
+	if req.Condition != model.WebhookConditionChange {
+		window = 0
+	}
+
+	res, err := i.Store.CreateWebhook(ctx, store.CreateWebhookRequest{
+		UserID:    dbUInfo.User.UserID,
+		URL:       strings.TrimSpace(req.URL),
+		Secret:    secret,
+		Pair:      req.Pair,
+		Condition: req.Condition.Uint8(),
+		Threshold: req.Threshold,
+		Window:    window,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  dbUInfo.User.UserID,
+		Action:       model.AuditActionWebhookCreated.Uint8(),
+		TargetUserID: dbUInfo.User.UserID,
+		TargetID:     res.WebhookID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &CreateWebhookResponse{
+		WebhookID: res.WebhookID,
+		Secret:    secret,
+	}, nil
+}
+
+func (i *Impl) ListWebhooks(ctx context.Context, _ ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.Store.ListWebhooks(ctx, store.ListWebhooksRequest{UserID: dbUInfo.User.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListWebhooksResponse{
+		Webhooks: res.Webhooks,
+	}, nil
+}
+
+func (i *Impl) DeleteWebhook(ctx context.Context, req DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := i.ownedWebhook(ctx, req.WebhookID, dbUInfo.User)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = i.Store.DeleteWebhook(ctx, store.DeleteWebhookRequest{WebhookID: webhook.WebhookID}); err != nil {
+		return nil, err
+	}
+
+	if err = i.audit(ctx, store.CreateAuditLogRequest{
+		ActorUserID:  dbUInfo.User.UserID,
+		Action:       model.AuditActionWebhookDeleted.Uint8(),
+		TargetUserID: webhook.UserID,
+		TargetID:     webhook.WebhookID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &DeleteWebhookResponse{}, nil
+}
+
+// ListWebhookDeadLetters : payloads that could not be delivered to the webhook
+func (i *Impl) ListWebhookDeadLetters(
+	ctx context.Context, req ListWebhookDeadLettersRequest,
+) (*ListWebhookDeadLettersResponse, error) {
+	uInfo := auth.GetUserInfoFromContext(ctx)
+	if uInfo == nil {
+		return nil, cError.ErrNotAuthenticated
+	}
+
+	dbUInfo, err := i.Store.GetUser(ctx, store.GetUserRequest{
+		Email: uInfo.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := i.ownedWebhook(ctx, req.WebhookID, dbUInfo.User)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.Store.ListWebhookDeadLetters(ctx, store.ListWebhookDeadLettersRequest{
+		WebhookID: webhook.WebhookID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListWebhookDeadLettersResponse{
+		DeadLetters: res.DeadLetters,
+	}, nil
+}
+
+// ownedWebhook : only webhook owners can manage their own webhooks, unless the caller is an admin
+func (i *Impl) ownedWebhook(ctx context.Context, webhookID string, user *model.User) (*model.Webhook, error) {
+	res, err := i.Store.GetWebhook(ctx, store.GetWebhookRequest{WebhookID: webhookID})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Webhook.UserID != user.UserID && !user.IsAdmin() {
+		return nil, errors.Wrap(cError.ErrNotAuthorized, "only webhook owners can manage their own webhooks")
+	}
+
+	return res.Webhook, nil
+}
+
+func validateWebhook(req CreateWebhookRequest) error {
+	u, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || u.Scheme != "https" || u.Host == "" || len(req.URL) > MaxWebhookURLLength {
+		return errors.Wrap(cError.ErrInvalidParameter, "webhook URL must be a valid https URL")
+	}
+
+	// early feedback only, the addresses the host resolves to are checked when delivering
+	host := u.Hostname()
+	if ip := net.ParseIP(host); strings.EqualFold(host, "localhost") || (ip != nil && !httpclient.IsPublicIP(ip)) {
+		return errors.Wrap(cError.ErrInvalidParameter, "webhook URL must be a public address")
+	}
+
+	if !util.IsValidPair(req.Pair) {
+		return errors.Wrap(cError.ErrInvalidParameter, "invalid pair")
+	}
+
+	if req.Threshold <= 0 || math.IsInf(req.Threshold, 0) || math.IsNaN(req.Threshold) {
+		return errors.Wrap(cError.ErrInvalidParameter, "threshold must be positive")
+	}
+
+	switch req.Condition {
+	case model.WebhookConditionCrosses:
+	case model.WebhookConditionChange:
+		if req.Threshold > maxWebhookPercentage {
+			return errors.Wrap(cError.ErrInvalidParameter, "change threshold is a percentage")
+		}
+
+		if req.Window < MinWebhookWindow || req.Window > model.MaxWebhookWindow {
+			return errors.Wrap(cError.ErrInvalidParameter, "window must be between 1 minute and 24 hours")
+		}
+	default:
+		return errors.Wrap(cError.ErrInvalidParameter, "invalid condition")
+	}
+
+	return nil
+}
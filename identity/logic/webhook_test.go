@@ -0,0 +1,352 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	cError "github.com/lruggieri/fxnow/common/error"
+	mockstore "github.com/lruggieri/fxnow/common/mock/store"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/store"
+
+	"github.com/lruggieri/fxnow/identity/auth"
+)
+
+func TestImpl_CreateWebhook(t *testing.T) {
+	testErr := errors.New("error")
+
+	type args struct {
+		ctx context.Context
+		req CreateWebhookRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+	user := &model.User{UserID: "user_id"}
+
+	crosses := CreateWebhookRequest{
+		URL:       "https://example.com/hook",
+		Pair:      "EUR_USD",
+		Condition: model.WebhookConditionCrosses,
+		Threshold: 1.1,
+		Window:    time.Hour,
+	}
+	change := CreateWebhookRequest{
+		URL:       "https://example.com/hook",
+		Pair:      "EUR_USD",
+		Condition: model.WebhookConditionChange,
+		Threshold: 0.5,
+		Window:    time.Hour,
+	}
+
+	invalid := func(mutate func(req *CreateWebhookRequest)) CreateWebhookRequest {
+		req := change
+		mutate(&req)
+
+		return req
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, s *mockstore.Store)
+		assertion func(t *testing.T, res *CreateWebhookResponse, err error)
+	}{
+		{
+			name: "error-no-user-info",
+			args: args{
+				ctx: context.Background(),
+				req: crosses,
+			},
+			mock: func(args args, s *mockstore.Store) {},
+			assertion: func(t *testing.T, res *CreateWebhookResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthenticated)
+			},
+		},
+		{
+			name: "error-http-url",
+			args: args{
+				ctx: uInfoCtx,
+				req: invalid(func(req *CreateWebhookRequest) { req.URL = "http://example.com/hook" }),
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateWebhookResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-private-url",
+			args: args{
+				ctx: uInfoCtx,
+				req: invalid(func(req *CreateWebhookRequest) { req.URL = "https://169.254.169.254/latest/meta-data" }),
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateWebhookResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-invalid-pair",
+			args: args{
+				ctx: uInfoCtx,
+				req: invalid(func(req *CreateWebhookRequest) { req.Pair = "EURUSD" }),
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateWebhookResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-invalid-condition",
+			args: args{
+				ctx: uInfoCtx,
+				req: invalid(func(req *CreateWebhookRequest) { req.Condition = model.WebhookConditionUndefined }),
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateWebhookResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-window-too-long",
+			args: args{
+				ctx: uInfoCtx,
+				req: invalid(func(req *CreateWebhookRequest) { req.Window = 48 * time.Hour }),
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateWebhookResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-too-many-webhooks",
+			args: args{
+				ctx: uInfoCtx,
+				req: change,
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+				s.EXPECT().ListWebhooks(args.ctx, store.ListWebhooksRequest{UserID: "user_id"}).
+					Return(&store.ListWebhooksResponse{Webhooks: make([]*model.Webhook, MaxWebhooksPerUser)}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateWebhookResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrInvalidParameter)
+			},
+		},
+		{
+			name: "error-create-webhook",
+			args: args{
+				ctx: uInfoCtx,
+				req: change,
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+				s.EXPECT().ListWebhooks(args.ctx, store.ListWebhooksRequest{UserID: "user_id"}).
+					Return(&store.ListWebhooksResponse{}, nil).Once()
+				s.EXPECT().CreateWebhook(args.ctx, mock.AnythingOfType("store.CreateWebhookRequest")).
+					Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *CreateWebhookResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "happy-path-crosses",
+			args: args{
+				ctx: uInfoCtx,
+				req: crosses,
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+				s.EXPECT().ListWebhooks(args.ctx, store.ListWebhooksRequest{UserID: "user_id"}).
+					Return(&store.ListWebhooksResponse{}, nil).Once()
+				// the window is only relevant to change conditions
+				s.EXPECT().CreateWebhook(args.ctx, mock.MatchedBy(func(req store.CreateWebhookRequest) bool {
+					return req.UserID == "user_id" && req.URL == "https://example.com/hook" && req.Pair == "EUR_USD" &&
+						req.Condition == model.WebhookConditionCrosses.Uint8() && req.Threshold == 1.1 &&
+						req.Window == 0 && req.Secret != ""
+				})).Return(&store.CreateWebhookResponse{WebhookID: "webhook_id"}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "user_id",
+					Action:       model.AuditActionWebhookCreated.Uint8(),
+					TargetUserID: "user_id",
+					TargetID:     "webhook_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateWebhookResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "webhook_id", res.WebhookID)
+				assert.NotEmpty(t, res.Secret)
+			},
+		},
+		{
+			name: "happy-path-change",
+			args: args{
+				ctx: uInfoCtx,
+				req: change,
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: user}, nil).Once()
+				s.EXPECT().ListWebhooks(args.ctx, store.ListWebhooksRequest{UserID: "user_id"}).
+					Return(&store.ListWebhooksResponse{}, nil).Once()
+				s.EXPECT().CreateWebhook(args.ctx, mock.MatchedBy(func(req store.CreateWebhookRequest) bool {
+					return req.Condition == model.WebhookConditionChange.Uint8() && req.Threshold == 0.5 &&
+						req.Window == 3600
+				})).Return(&store.CreateWebhookResponse{WebhookID: "webhook_id"}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, mock.AnythingOfType("store.CreateAuditLogRequest")).
+					Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *CreateWebhookResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "webhook_id", res.WebhookID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockstore.NewStore(t)
+
+			l := Impl{
+				Store: s,
+			}
+
+			tc.mock(tc.args, s)
+
+			res, err := l.CreateWebhook(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
+
+func TestImpl_DeleteWebhook(t *testing.T) {
+	testErr := errors.New("error")
+
+	type args struct {
+		ctx context.Context
+		req DeleteWebhookRequest
+	}
+
+	uInfo := auth.UserInfo{Email: "user@domain.com"}
+	uInfoCtx := context.WithValue(context.Background(), auth.ContextUserInfoKey, &uInfo)
+	webhook := &model.Webhook{WebhookID: "webhook_id", UserID: "owner_id"}
+
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(args args, s *mockstore.Store)
+		assertion func(t *testing.T, res *DeleteWebhookResponse, err error)
+	}{
+		{
+			name: "error-get-webhook",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteWebhookRequest{WebhookID: "webhook_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "owner_id"}}, nil).Once()
+				s.EXPECT().GetWebhook(args.ctx, store.GetWebhookRequest{WebhookID: "webhook_id"}).
+					Return(nil, testErr).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteWebhookResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, testErr)
+			},
+		},
+		{
+			name: "error-other-user-webhook",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteWebhookRequest{WebhookID: "webhook_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "user_id"}}, nil).Once()
+				s.EXPECT().GetWebhook(args.ctx, store.GetWebhookRequest{WebhookID: "webhook_id"}).
+					Return(&store.GetWebhookResponse{Webhook: webhook}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteWebhookResponse, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, cError.ErrNotAuthorized)
+			},
+		},
+		{
+			name: "happy-path-admin",
+			args: args{
+				ctx: uInfoCtx,
+				req: DeleteWebhookRequest{WebhookID: "webhook_id"},
+			},
+			mock: func(args args, s *mockstore.Store) {
+				s.EXPECT().GetUser(args.ctx, store.GetUserRequest{Email: uInfo.Email}).
+					Return(&store.GetUserResponse{User: &model.User{UserID: "admin_id", Role: model.UserRoleAdmin}}, nil).
+					Once()
+				s.EXPECT().GetWebhook(args.ctx, store.GetWebhookRequest{WebhookID: "webhook_id"}).
+					Return(&store.GetWebhookResponse{Webhook: webhook}, nil).Once()
+				s.EXPECT().DeleteWebhook(args.ctx, store.DeleteWebhookRequest{WebhookID: "webhook_id"}).
+					Return(&store.DeleteWebhookResponse{}, nil).Once()
+				s.EXPECT().CreateAuditLog(args.ctx, store.CreateAuditLogRequest{
+					ActorUserID:  "admin_id",
+					Action:       model.AuditActionWebhookDeleted.Uint8(),
+					TargetUserID: "owner_id",
+					TargetID:     "webhook_id",
+				}).Return(&store.CreateAuditLogResponse{}, nil).Once()
+			},
+			assertion: func(t *testing.T, res *DeleteWebhookResponse, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &DeleteWebhookResponse{}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt // avoid loop closure issue
+		t.Run(tc.name, func(t *testing.T) {
+			s := mockstore.NewStore(t)
+
+			l := Impl{
+				Store: s,
+			}
+
+			tc.mock(tc.args, s)
+
+			res, err := l.DeleteWebhook(tc.args.ctx, tc.args.req)
+
+			tc.assertion(t, res, err)
+		})
+	}
+}
@@ -6,35 +6,58 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 
+	"github.com/lruggieri/fxnow/common/app"
+	"github.com/lruggieri/fxnow/common/clock"
+	cError "github.com/lruggieri/fxnow/common/error"
+	"github.com/lruggieri/fxnow/common/health"
 	cHttp "github.com/lruggieri/fxnow/common/http"
 	"github.com/lruggieri/fxnow/common/logger"
 	"github.com/lruggieri/fxnow/common/logger/zap"
+	"github.com/lruggieri/fxnow/common/model"
+	"github.com/lruggieri/fxnow/common/oauth"
 	"github.com/lruggieri/fxnow/common/store"
 	"github.com/lruggieri/fxnow/common/store/mysql"
+	"github.com/lruggieri/fxnow/common/util"
 
 	"github.com/lruggieri/fxnow/identity/auth"
 	"github.com/lruggieri/fxnow/identity/logic"
 )
 
+const (
+	oauthStateCookie     = "oauth_state"
+	oauthStateCookiePath = "/identity/callback"
+
+	sessionCookie      = "session"
+	refreshCookie      = "refresh_token"
+	refreshCookiePath  = "/identity"
+	legacyAccessCookie = "access_token"
+)
+
 var (
 	authenticator auth.Authenticator
 
 	l logic.Logic
 
 	str store.Store
+
+	// insecureCookies : allows session cookies over plain HTTP, for local development only
+	insecureCookies bool
 )
 
 func main() {
-	mainContext := context.Background()
-
 	logger.InitLogger(zap.New(zap.Config{
 		Development: false,
 		Level:       logger.LevelDebug,
 	}))
 
+	application := app.New("identity")
+
 	port := os.Getenv("PORT")
 
 	mysqlPort, err := strconv.Atoi(os.Getenv("MYSQL_PORT"))
@@ -53,37 +76,113 @@ func main() {
 		panic(err)
 	}
 
+	application.OnShutdown("mysql", func(ctx context.Context) error {
+		return str.Close()
+	})
+
+	var tokens *oauth.Signer
+
+	// the same key must be configured on fxrate, for it to accept the access tokens
+	if tokenKey := os.Getenv("OAUTH_TOKEN_KEY"); tokenKey != "" {
+		if tokens, err = oauth.NewSigner([]byte(tokenKey), clock.Default{}); err != nil {
+			panic(err)
+		}
+	}
+
 	l = &logic.Impl{
-		Store: str,
+		Store:  str,
+		Clock:  clock.Default{},
+		Tokens: tokens,
 	}
 
-	authenticator, err = auth.NewBasic(mainContext, auth.Config{
-		OIDC: auth.OIDCConfig{
-			ClientID:     os.Getenv("OIDC_CLIENT_ID"),
-			ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
-			RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
-		},
+	insecureCookies, _ = strconv.ParseBool(os.Getenv("SESSION_COOKIE_INSECURE"))
+
+	providers := providersFromEnv()
+
+	authenticator, err = auth.NewBasic(application.Context(), auth.Config{
+		Providers:            providers,
+		DefaultProvider:      os.Getenv("OIDC_DEFAULT_PROVIDER"),
+		StateKey:             []byte(os.Getenv("OIDC_STATE_KEY")),
+		AllowedRedirectHosts: util.PruneSlice(strings.Split(os.Getenv("OIDC_ALLOWED_REDIRECT_HOSTS"), ",")),
 	})
 
 	if err != nil {
 		panic(err)
 	}
 
+	readiness := &health.Registry{Clock: clock.Default{}}
+	readiness.Register(health.Check{Name: "mysql", Checker: health.CheckerFunc(str.Ping)})
+
+	// logins fail while the providers cannot be discovered
+	for _, provider := range providers {
+		if discoveryURL := auth.DiscoveryURL(provider); discoveryURL != "" {
+			readiness.Register(health.Check{
+				Name:     "oidc-" + provider.Name,
+				Checker:  &health.HTTP{Client: http.DefaultClient, URL: discoveryURL},
+				CacheTTL: time.Minute,
+			})
+		}
+	}
+
 	r := gin.Default()
 	r.Use(commonHeaders())
 	r.GET("/identity/health", HandleHealth)
+	r.GET("/identity/livez", readiness.HandleLive)
+	r.GET("/identity/readyz", readiness.HandleReady)
 
 	// oidc
 	r.GET("/identity/access", HandleAccess)
 	r.GET("/identity/callback", HandleOauthCallback)
+	r.GET("/identity/callback/:provider", HandleOauthCallback)
+
+	// machine-to-machine access
+	r.POST("/identity/oauth/token", HandleOAuthToken)
+
+	// session
+	r.POST("/identity/refresh", HandleRefreshSession)
+	r.POST("/identity/logout", HandleLogout)
 
 	// API key
 	v1 := r.Group("/identity/v1")
 	v1.GET("/api-keys", HandleListAPIKey)
 	v1.POST("/api-key", HandleCreateAPIKey)
 	v1.DELETE("/api-key/:key", HandleRevokeAPIKey)
+	v1.POST("/api-key/:key/rotate", HandleRotateAPIKey)
+	v1.PUT("/api-key/:key/plan", HandleChangeAPIKeyPlan)
+	v1.GET("/plans", HandleListPlans)
+
+	// session
+	v1.GET("/sessions", HandleListSessions)
+	v1.DELETE("/session/:session", HandleRevokeSession)
+
+	// OAuth2 clients
+	v1.GET("/oauth-clients", HandleListOAuthClients)
+	v1.POST("/oauth-client", HandleCreateOAuthClient)
+	v1.DELETE("/oauth-client/:client", HandleDeleteOAuthClient)
+
+	// organizations, owning keys that share the same quota
+	v1.GET("/organizations", HandleListOrganizations)
+	v1.POST("/organization", HandleCreateOrganization)
+	v1.GET("/organization/:organization/members", HandleListOrganizationMembers)
+	v1.POST("/organization/:organization/member", HandleInviteOrganizationMember)
+	v1.DELETE("/organization/:organization/member/:user", HandleRemoveOrganizationMember)
+	v1.GET("/organization/:organization/api-keys", HandleListAPIKey)
+	v1.POST("/organization/:organization/api-key", HandleCreateAPIKey)
 
-	panic(r.Run(fmt.Sprintf(":%s", port)))
+	// webhooks notified on rate thresholds
+	v1.GET("/webhooks", HandleListWebhooks)
+	v1.POST("/webhook", HandleCreateWebhook)
+	v1.DELETE("/webhook/:webhook", HandleDeleteWebhook)
+	v1.GET("/webhook/:webhook/dead-letters", HandleListWebhookDeadLetters)
+
+	// audit
+	v1.GET("/audit", HandleListAuditLogs)
+
+	application.Serve(app.NewServer(fmt.Sprintf(":%s", port), r))
+
+	if err = application.Run(); err != nil {
+		panic(err)
+	}
 }
 
 func commonHeaders() gin.HandlerFunc {
@@ -98,30 +197,49 @@ func HandleHealth(c *gin.Context) {
 }
 
 func HandleAccess(c *gin.Context) {
-	if isAuthenticated(c) {
+	if _, aRes := authenticate(c); aRes != nil {
 		cHttp.HTTPResponse(c, "OK", nil, http.StatusOK)
 		return
 	}
 
 	// invalid token, redirect to OIDC provider
-	c.Redirect(http.StatusFound, authenticator.GetOIDCConsentURL(getFullPath(c)))
+	redirectToConsent(c, c.Query("provider"), c.Query("redirect"))
 }
 
 func HandleOauthCallback(c *gin.Context) {
-	code := c.Query("code")
+	signedState, _ := c.Cookie(oauthStateCookie)
+
+	// the state is single-use
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, "", -1, oauthStateCookiePath, "", isSecureRequest(c), true)
 
-	token, err := authenticator.AuthenticateOIDC(code)
+	res, err := authenticator.CompleteOIDC(c.Request.Context(), signedState, c.Query("state"), c.Query("code"))
 	if err != nil {
-		cHttp.HTTPResponse(c, "", err, http.StatusInternalServerError)
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+		return
+	}
+
+	uInfo := authenticator.GetUserInfo(c.Request.Context(), res.Token)
+	if uInfo == nil {
+		cHttp.HTTPResponse(c, "", cError.ErrNotAuthenticated, http.StatusUnauthorized)
+		return
+	}
+
+	// the provider token is only used to identify the user, from now on the user is tracked by our own session
+	lRes, err := l.Login(
+		context.WithValue(requestContext(c), auth.ContextUserInfoKey, uInfo),
+		logic.LoginRequest{},
+	)
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
 		return
 	}
 
-	c.SetCookie("access_token", token, 0, "/", "", false, false)
+	setSessionCookies(c, lRes.Tokens)
 
 	// redirect the user where it came from before the auth flow started
-	state := c.Query("state")
-	if state != "" {
-		c.Redirect(http.StatusFound, state)
+	if res.RedirectURL != "" {
+		c.Redirect(http.StatusFound, res.RedirectURL)
 		return
 	}
 
@@ -129,14 +247,16 @@ func HandleOauthCallback(c *gin.Context) {
 }
 
 func HandleListAPIKey(c *gin.Context) {
-	if !isAuthenticated(c) {
-		c.Redirect(http.StatusFound, authenticator.GetOIDCConsentURL(getFullPath(c)))
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
 		return
 	}
 
+	// on organization routes, the keys of the organization are listed
 	resp, err := l.ListAPIKeys(
-		context.WithValue(c, auth.ContextUserInfoKey, authenticator.GetUserInfo(getToken(c))),
-		logic.ListAPIKeysRequest{},
+		ctx,
+		logic.ListAPIKeysRequest{OrganizationID: c.Param("organization")},
 	)
 	if err != nil {
 		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
@@ -145,16 +265,20 @@ func HandleListAPIKey(c *gin.Context) {
 	}
 
 	type key struct {
-		APIKeyID   string `json:"api_key"`
-		Expiration int64  `json:"expiration"`
+		APIKeyID       string `json:"api_key"`
+		OrganizationID string `json:"organization_id,omitempty"`
+		PlanID         string `json:"plan"`
+		Expiration     int64  `json:"expiration"`
 	}
 
 	apiKeys := make([]key, 0, len(resp.APIKeys))
 
 	for _, apiKey := range resp.APIKeys {
 		apiKeys = append(apiKeys, key{
-			APIKeyID:   apiKey.APIKeyID,
-			Expiration: apiKey.Expiration,
+			APIKeyID:       apiKey.APIKeyID,
+			OrganizationID: apiKey.OrganizationID,
+			PlanID:         apiKey.PlanID,
+			Expiration:     apiKey.Expiration,
 		})
 	}
 
@@ -164,14 +288,15 @@ func HandleListAPIKey(c *gin.Context) {
 }
 
 func HandleCreateAPIKey(c *gin.Context) {
-	if !isAuthenticated(c) {
-		c.Redirect(http.StatusFound, authenticator.GetOIDCConsentURL(getFullPath(c)))
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
 		return
 	}
 
 	resp, err := l.CreateAPIKey(
-		context.WithValue(c, auth.ContextUserInfoKey, authenticator.GetUserInfo(getToken(c))),
-		logic.CreateAPIKeyRequest{},
+		ctx,
+		logic.CreateAPIKeyRequest{OrganizationID: c.Param("organization")},
 	)
 	if err != nil {
 		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
@@ -187,8 +312,9 @@ func HandleCreateAPIKey(c *gin.Context) {
 }
 
 func HandleRevokeAPIKey(c *gin.Context) {
-	if !isAuthenticated(c) {
-		c.Redirect(http.StatusFound, authenticator.GetOIDCConsentURL(getFullPath(c)))
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
 		return
 	}
 
@@ -200,7 +326,7 @@ func HandleRevokeAPIKey(c *gin.Context) {
 	}
 
 	_, err := l.DeleteAPIKey(
-		context.WithValue(c, auth.ContextUserInfoKey, authenticator.GetUserInfo(getToken(c))),
+		ctx,
 		logic.DeleteAPIKeyRequest{APIKeyID: keyToRevoke},
 	)
 	if err != nil {
@@ -212,35 +338,811 @@ func HandleRevokeAPIKey(c *gin.Context) {
 	cHttp.HTTPResponse(c, nil, nil, http.StatusOK)
 }
 
-func isAuthenticated(c *gin.Context) bool {
-	accessToken := getToken(c)
-	if accessToken != "" && authenticator.IsJWTValid(accessToken) {
-		return true
+func HandleRotateAPIKey(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	resp, err := l.RotateAPIKey(
+		ctx,
+		logic.RotateAPIKeyRequest{APIKeyID: c.Param("key")},
+	)
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		ID string `json:"id"`
+	}{
+		ID: resp.APIKeyID,
+	}, nil, http.StatusOK)
+}
+
+func HandleChangeAPIKeyPlan(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	var body struct {
+		PlanID string `json:"plan"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil || body.PlanID == "" {
+		cHttp.HTTPResponse(c, "", fmt.Errorf("invalid request body"), http.StatusBadRequest)
+
+		return
+	}
+
+	_, err := l.ChangeAPIKeyPlan(ctx, logic.ChangeAPIKeyPlanRequest{
+		APIKeyID: c.Param("key"),
+		PlanID:   body.PlanID,
+	})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, nil, nil, http.StatusOK)
+}
+
+func HandleListPlans(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	resp, err := l.ListPlans(ctx, logic.ListPlansRequest{})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		Plans []*model.Plan `json:"plans"`
+	}{resp.Plans}, nil, http.StatusOK)
+}
+
+func HandleRefreshSession(c *gin.Context) {
+	refreshToken, _ := c.Cookie(refreshCookie)
+
+	res, err := l.RefreshSession(requestContext(c), logic.RefreshSessionRequest{RefreshToken: refreshToken})
+	if err != nil {
+		clearSessionCookies(c)
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	setSessionCookies(c, res.Tokens)
+
+	cHttp.HTTPResponse(c, struct {
+		Expiration int64 `json:"expiration"`
+	}{res.Tokens.Expiration}, nil, http.StatusOK)
+}
+
+func HandleLogout(c *gin.Context) {
+	token, _ := c.Cookie(sessionCookie)
+
+	// cookies are cleared anyway, the session might have already expired or been revoked
+	clearSessionCookies(c)
+
+	if _, err := l.Logout(requestContext(c), logic.LogoutRequest{SessionToken: token}); err != nil &&
+		!errors.Is(err, cError.ErrNotAuthenticated) {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, "OK", nil, http.StatusOK)
+}
+
+func HandleListSessions(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	resp, err := l.ListSessions(ctx, logic.ListSessionsRequest{})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	type session struct {
+		SessionID         string `json:"session_id"`
+		Provider          string `json:"provider"`
+		IP                string `json:"ip"`
+		UserAgent         string `json:"user_agent"`
+		CreatedAt         int64  `json:"created_at"`
+		Expiration        int64  `json:"expiration"`
+		RefreshExpiration int64  `json:"refresh_expiration"`
+		Current           bool   `json:"current"`
+	}
+
+	sessions := make([]session, 0, len(resp.Sessions))
+
+	for _, s := range resp.Sessions {
+		sessions = append(sessions, session{
+			SessionID:         s.SessionID,
+			Provider:          s.Provider,
+			IP:                s.IP,
+			UserAgent:         s.UserAgent,
+			CreatedAt:         s.CreatedAt,
+			Expiration:        s.Expiration,
+			RefreshExpiration: s.RefreshExpiration,
+			Current:           s.SessionID == aRes.Session.SessionID,
+		})
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		Sessions []session `json:"sessions"`
+	}{sessions}, nil, http.StatusOK)
+}
+
+func HandleRevokeSession(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	sessionToRevoke := c.Param("session")
+	if len(sessionToRevoke) == 0 {
+		cHttp.HTTPResponse(c, "", fmt.Errorf("invalid session"), http.StatusBadRequest)
+
+		return
+	}
+
+	if _, err := l.RevokeSession(ctx, logic.RevokeSessionRequest{SessionID: sessionToRevoke}); err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	// revoking the current session is equivalent to a logout
+	if sessionToRevoke == aRes.Session.SessionID {
+		clearSessionCookies(c)
+	}
+
+	cHttp.HTTPResponse(c, nil, nil, http.StatusOK)
+}
+
+// HandleOAuthToken : token endpoint of the client credentials grant. Requests and responses follow RFC 6749, for
+// standard OAuth2 client libraries to work out of the box.
+func HandleOAuthToken(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+
+	if grantType := c.PostForm("grant_type"); grantType != "client_credentials" {
+		oauthError(c, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	// clients can authenticate either through HTTP basic auth or through the request body
+	clientID, clientSecret, ok := c.Request.BasicAuth()
+	if !ok {
+		clientID, clientSecret = c.PostForm("client_id"), c.PostForm("client_secret")
 	}
 
-	return false
+	res, err := l.IssueClientToken(requestContext(c), logic.IssueClientTokenRequest{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       strings.Fields(c.PostForm("scope")),
+	})
+
+	switch {
+	case errors.Is(err, cError.ErrNotAuthenticated):
+		if ok {
+			c.Header("WWW-Authenticate", `Basic realm="fxnow"`)
+		}
+
+		oauthError(c, http.StatusUnauthorized, "invalid_client")
+	case errors.Is(err, cError.ErrInvalidParameter):
+		oauthError(c, http.StatusBadRequest, "invalid_scope")
+	case err != nil:
+		oauthError(c, http.StatusInternalServerError, "server_error")
+	default:
+		c.JSON(http.StatusOK, gin.H{
+			"access_token": res.AccessToken,
+			"token_type":   "Bearer",
+			"expires_in":   int64(oauth.AccessTokenLifetime.Seconds()),
+			"scope":        strings.Join(res.Scopes, " "),
+		})
+	}
+}
+
+func oauthError(c *gin.Context, status int, code string) {
+	c.JSON(status, gin.H{"error": code})
 }
 
-func getToken(c *gin.Context) string {
-	accessToken, err := c.Cookie("access_token")
+func HandleListOAuthClients(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	resp, err := l.ListOAuthClients(ctx, logic.ListOAuthClientsRequest{})
 	if err != nil {
-		return ""
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
 	}
 
-	return accessToken
+	type client struct {
+		ClientID  string   `json:"client_id"`
+		Name      string   `json:"name"`
+		Scopes    []string `json:"scopes"`
+		PlanID    string   `json:"plan"`
+		CreatedAt int64    `json:"created_at"`
+	}
+
+	clients := make([]client, 0, len(resp.OAuthClients))
+
+	for _, oc := range resp.OAuthClients {
+		clients = append(clients, client{
+			ClientID:  oc.ClientID,
+			Name:      oc.Name,
+			Scopes:    oc.Scopes,
+			PlanID:    oc.PlanID,
+			CreatedAt: oc.CreatedAt,
+		})
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		OAuthClients []client `json:"oauth-clients"`
+	}{clients}, nil, http.StatusOK)
 }
 
-func getFullPath(c *gin.Context) string {
-	req := c.Request
+func HandleCreateOAuthClient(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	var body struct {
+		Name   string   `json:"name"`
+		Scopes []string `json:"scopes"`
+		PlanID string   `json:"plan"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		cHttp.HTTPResponse(c, "", fmt.Errorf("invalid request body"), http.StatusBadRequest)
 
-	scheme := "http"
-	if req.TLS != nil {
-		scheme = "https"
+		return
 	}
 
-	host := req.Host
-	path := req.URL.Path
-	fullURL := fmt.Sprintf("%s://%s%s", scheme, host, path)
+	resp, err := l.CreateOAuthClient(ctx, logic.CreateOAuthClientRequest{
+		Name:   body.Name,
+		Scopes: body.Scopes,
+		PlanID: body.PlanID,
+	})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}{
+		ClientID:     resp.ClientID,
+		ClientSecret: resp.ClientSecret,
+	}, nil, http.StatusOK)
+}
+
+func HandleDeleteOAuthClient(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	clientToDelete := c.Param("client")
+	if len(clientToDelete) == 0 {
+		cHttp.HTTPResponse(c, "", fmt.Errorf("invalid client"), http.StatusBadRequest)
+
+		return
+	}
+
+	if _, err := l.DeleteOAuthClient(ctx, logic.DeleteOAuthClientRequest{ClientID: clientToDelete}); err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, nil, nil, http.StatusOK)
+}
+
+func HandleListWebhooks(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	resp, err := l.ListWebhooks(ctx, logic.ListWebhooksRequest{})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	type webhook struct {
+		WebhookID string  `json:"webhook_id"`
+		URL       string  `json:"url"`
+		Pair      string  `json:"pair"`
+		Condition string  `json:"condition"`
+		Threshold float64 `json:"threshold"`
+		Window    int64   `json:"window,omitempty"`
+		CreatedAt int64   `json:"created_at"`
+	}
+
+	webhooks := make([]webhook, 0, len(resp.Webhooks))
+
+	for _, w := range resp.Webhooks {
+		webhooks = append(webhooks, webhook{
+			WebhookID: w.WebhookID,
+			URL:       w.URL,
+			Pair:      w.Pair,
+			Condition: w.Condition.String(),
+			Threshold: w.Threshold,
+			Window:    w.Window,
+			CreatedAt: w.CreatedAt,
+		})
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		Webhooks []webhook `json:"webhooks"`
+	}{webhooks}, nil, http.StatusOK)
+}
+
+func HandleCreateWebhook(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	var body struct {
+		URL       string  `json:"url"`
+		Pair      string  `json:"pair"`
+		Condition string  `json:"condition"`
+		Threshold float64 `json:"threshold"`
+		Window    int64   `json:"window"` // seconds
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		cHttp.HTTPResponse(c, "", fmt.Errorf("invalid request body"), http.StatusBadRequest)
+
+		return
+	}
+
+	resp, err := l.CreateWebhook(ctx, logic.CreateWebhookRequest{
+		URL:       body.URL,
+		Pair:      body.Pair,
+		Condition: model.WebhookConditionFromString(body.Condition),
+		Threshold: body.Threshold,
+		Window:    time.Duration(body.Window) * time.Second,
+	})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		WebhookID string `json:"webhook_id"`
+		Secret    string `json:"secret"`
+	}{
+		WebhookID: resp.WebhookID,
+		Secret:    resp.Secret,
+	}, nil, http.StatusOK)
+}
+
+func HandleDeleteWebhook(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	if _, err := l.DeleteWebhook(ctx, logic.DeleteWebhookRequest{WebhookID: c.Param("webhook")}); err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, nil, nil, http.StatusOK)
+}
+
+func HandleListWebhookDeadLetters(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	resp, err := l.ListWebhookDeadLetters(ctx, logic.ListWebhookDeadLettersRequest{WebhookID: c.Param("webhook")})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		DeadLetters []*model.WebhookDeadLetter `json:"dead-letters"`
+	}{resp.DeadLetters}, nil, http.StatusOK)
+}
+
+func HandleListOrganizations(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	resp, err := l.ListOrganizations(ctx, logic.ListOrganizationsRequest{})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		Organizations []*model.Organization `json:"organizations"`
+	}{resp.Organizations}, nil, http.StatusOK)
+}
+
+func HandleCreateOrganization(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	var body struct {
+		Name string `json:"name"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		cHttp.HTTPResponse(c, "", fmt.Errorf("invalid request body"), http.StatusBadRequest)
+
+		return
+	}
+
+	resp, err := l.CreateOrganization(ctx, logic.CreateOrganizationRequest{Name: body.Name})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		ID string `json:"id"`
+	}{
+		ID: resp.OrganizationID,
+	}, nil, http.StatusOK)
+}
+
+func HandleListOrganizationMembers(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	resp, err := l.ListOrganizationMembers(ctx, logic.ListOrganizationMembersRequest{
+		OrganizationID: c.Param("organization"),
+	})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	type member struct {
+		UserID    string `json:"user_id"`
+		Email     string `json:"email"`
+		Role      string `json:"role"`
+		CreatedAt int64  `json:"created_at"`
+	}
+
+	members := make([]member, 0, len(resp.Members))
+
+	for _, m := range resp.Members {
+		var email string
+		if m.User != nil {
+			email = m.User.Email
+		}
+
+		members = append(members, member{
+			UserID:    m.UserID,
+			Email:     email,
+			Role:      m.Role.String(),
+			CreatedAt: m.CreatedAt,
+		})
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		Members []member `json:"members"`
+	}{members}, nil, http.StatusOK)
+}
+
+func HandleInviteOrganizationMember(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	var body struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		cHttp.HTTPResponse(c, "", fmt.Errorf("invalid request body"), http.StatusBadRequest)
+
+		return
+	}
+
+	role := model.OrganizationRoleFromString(body.Role)
+	if body.Role != "" && role == model.OrganizationRoleUndefined {
+		cHttp.HTTPResponse(c, "", fmt.Errorf("invalid role '%s'", body.Role), http.StatusBadRequest)
+
+		return
+	}
+
+	resp, err := l.InviteOrganizationMember(ctx, logic.InviteOrganizationMemberRequest{
+		OrganizationID: c.Param("organization"),
+		Email:          body.Email,
+		Role:           role,
+	})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		UserID string `json:"user_id"`
+	}{
+		UserID: resp.UserID,
+	}, nil, http.StatusOK)
+}
+
+func HandleRemoveOrganizationMember(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	_, err := l.RemoveOrganizationMember(ctx, logic.RemoveOrganizationMemberRequest{
+		OrganizationID: c.Param("organization"),
+		UserID:         c.Param("user"),
+	})
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	cHttp.HTTPResponse(c, nil, nil, http.StatusOK)
+}
+
+func HandleListAuditLogs(c *gin.Context) {
+	ctx, aRes := authenticate(c)
+	if aRes == nil {
+		redirectToConsent(c, "", "")
+		return
+	}
+
+	req, err := parseListAuditLogsRequest(c)
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, http.StatusBadRequest)
+
+		return
+	}
+
+	resp, err := l.ListAuditLogs(ctx, *req)
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+
+		return
+	}
+
+	type auditLog struct {
+		ActorUserID          string `json:"actor_user_id"`
+		Action               string `json:"action"`
+		TargetUserID         string `json:"target_user_id"`
+		TargetID             string `json:"target_id"`
+		TargetOrganizationID string `json:"target_organization_id,omitempty"`
+		IP                   string `json:"ip"`
+		UserAgent            string `json:"user_agent"`
+		Timestamp            int64  `json:"timestamp"`
+	}
+
+	auditLogs := make([]auditLog, 0, len(resp.AuditLogs))
+
+	for _, al := range resp.AuditLogs {
+		auditLogs = append(auditLogs, auditLog{
+			ActorUserID:          al.ActorUserID,
+			Action:               al.Action.String(),
+			TargetUserID:         al.TargetUserID,
+			TargetID:             al.TargetID,
+			TargetOrganizationID: al.TargetOrganizationID,
+			IP:                   al.IP,
+			UserAgent:            al.UserAgent,
+			Timestamp:            al.Timestamp,
+		})
+	}
+
+	cHttp.HTTPResponse(c, struct {
+		AuditLogs []auditLog `json:"audit-logs"`
+	}{auditLogs}, nil, http.StatusOK)
+}
+
+// parseListAuditLogsRequest : supported query parameters are
+// user_id, action (comma separated list), from, to (unix seconds), offset and limit
+func parseListAuditLogsRequest(c *gin.Context) (*logic.ListAuditLogsRequest, error) {
+	req := &logic.ListAuditLogsRequest{
+		UserID: c.Query("user_id"),
+	}
+
+	if actionsStr := c.Query("action"); actionsStr != "" {
+		for _, actionStr := range strings.Split(actionsStr, ",") {
+			action := model.AuditActionFromString(actionStr)
+			if action == model.AuditActionUndefined {
+				return nil, fmt.Errorf("invalid action '%s'", actionStr)
+			}
+
+			req.Actions = append(req.Actions, action)
+		}
+	}
+
+	intParams := []struct {
+		name  string
+		value *int64
+	}{
+		{"from", &req.From},
+		{"to", &req.To},
+	}
+
+	for _, p := range intParams {
+		if str := c.Query(p.name); str != "" {
+			v, err := strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid '%s' parameter", p.name)
+			}
+
+			*p.value = v
+		}
+	}
+
+	pageParams := []struct {
+		name  string
+		value *int
+	}{
+		{"offset", &req.Offset},
+		{"limit", &req.Limit},
+	}
+
+	for _, p := range pageParams {
+		if str := c.Query(p.name); str != "" {
+			v, err := strconv.Atoi(str)
+			if err != nil {
+				return nil, fmt.Errorf("invalid '%s' parameter", p.name)
+			}
+
+			*p.value = v
+		}
+	}
+
+	return req, nil
+}
+
+// requestContext : context carrying information about the client performing the request. It derives from the
+// context of the HTTP request, cancelled when the client disconnects or the server shuts down, unlike gin.Context.
+func requestContext(c *gin.Context) context.Context {
+	return context.WithValue(c.Request.Context(), logic.ContextKeyClientInfo, &logic.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+}
+
+// authenticate : resolves the session of the request, transparently renewing it if expired. Returns the request
+// context carrying the authenticated user information, or a nil response if the user is not authenticated.
+func authenticate(c *gin.Context) (context.Context, *logic.AuthenticateResponse) {
+	ctx := requestContext(c)
+
+	if token, _ := c.Cookie(sessionCookie); token != "" {
+		if aRes, err := l.Authenticate(ctx, logic.AuthenticateRequest{SessionToken: token}); err == nil {
+			return userContext(ctx, aRes.User), aRes
+		}
+	}
+
+	refreshToken, _ := c.Cookie(refreshCookie)
+	if refreshToken == "" {
+		return ctx, nil
+	}
+
+	rRes, err := l.RefreshSession(ctx, logic.RefreshSessionRequest{RefreshToken: refreshToken})
+	if err != nil {
+		clearSessionCookies(c)
+		return ctx, nil
+	}
+
+	setSessionCookies(c, rRes.Tokens)
+
+	return userContext(ctx, rRes.User), &logic.AuthenticateResponse{Session: rRes.Session, User: rRes.User}
+}
+
+// userContext : request context also carrying the authenticated user information
+func userContext(ctx context.Context, user *model.User) context.Context {
+	return context.WithValue(ctx, auth.ContextUserInfoKey, &auth.UserInfo{
+		Email:      user.Email,
+		GivenName:  user.FirstName,
+		FamilyName: user.LastName,
+	})
+}
+
+// setSessionCookies : session cookies are never readable from scripts. The refresh token is only sent to identity.
+func setSessionCookies(c *gin.Context, tokens logic.SessionTokens) {
+	secure := !insecureCookies
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(sessionCookie, tokens.Token, int(logic.SessionLifetime.Seconds()), "/", "", secure, true)
+	c.SetCookie(
+		refreshCookie, tokens.RefreshToken, int(logic.RefreshLifetime.Seconds()), refreshCookiePath, "", secure, true,
+	)
+
+	// provider tokens are not accepted anymore, get rid of the ones set before sessions were introduced
+	c.SetCookie(legacyAccessCookie, "", -1, "/", "", false, false)
+}
+
+func clearSessionCookies(c *gin.Context) {
+	secure := !insecureCookies
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(sessionCookie, "", -1, "/", "", secure, true)
+	c.SetCookie(refreshCookie, "", -1, refreshCookiePath, "", secure, true)
+}
+
+// redirectToConsent : redirects to the consent page of the provider, or of the default one if empty. After login,
+// the user is sent to redirectURL if set, or back to the current page otherwise.
+func redirectToConsent(c *gin.Context, provider, redirectURL string) {
+	if redirectURL == "" {
+		redirectURL = c.Request.URL.RequestURI()
+	}
+
+	consentURL, signedState, err := authenticator.BeginOIDC(provider, redirectURL)
+	if err != nil {
+		cHttp.HTTPResponse(c, "", err, cHttp.GetHttpStatusFromError(err))
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(
+		oauthStateCookie, signedState, int(auth.StateLifetime.Seconds()), oauthStateCookiePath, "", isSecureRequest(c), true,
+	)
+	c.Redirect(http.StatusFound, consentURL)
+}
 
-	return fullURL
+// isSecureRequest : whether the request reached us, or the load balancer in front of us, through HTTPS
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
 }
@@ -3,7 +3,10 @@
 package mockauth
 
 import (
+	context "context"
+
 	auth "github.com/lruggieri/fxnow/identity/auth"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -20,107 +23,130 @@ func (_m *Authenticator) EXPECT() *Authenticator_Expecter {
 	return &Authenticator_Expecter{mock: &_m.Mock}
 }
 
-// AuthenticateOIDC provides a mock function with given fields: code
-func (_m *Authenticator) AuthenticateOIDC(code string) (string, error) {
-	ret := _m.Called(code)
+// BeginOIDC provides a mock function with given fields: provider, redirectURL
+func (_m *Authenticator) BeginOIDC(provider string, redirectURL string) (string, string, error) {
+	ret := _m.Called(provider, redirectURL)
 
 	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(code)
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, string, error)); ok {
+		return rf(provider, redirectURL)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(code)
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(provider, redirectURL)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(code)
+	if rf, ok := ret.Get(1).(func(string, string) string); ok {
+		r1 = rf(provider, redirectURL)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(provider, redirectURL)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Authenticator_AuthenticateOIDC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthenticateOIDC'
-type Authenticator_AuthenticateOIDC_Call struct {
+// Authenticator_BeginOIDC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginOIDC'
+type Authenticator_BeginOIDC_Call struct {
 	*mock.Call
 }
 
-// AuthenticateOIDC is a helper method to define mock.On call
-//   - code string
-func (_e *Authenticator_Expecter) AuthenticateOIDC(code interface{}) *Authenticator_AuthenticateOIDC_Call {
-	return &Authenticator_AuthenticateOIDC_Call{Call: _e.mock.On("AuthenticateOIDC", code)}
+// BeginOIDC is a helper method to define mock.On call
+//   - provider string
+//   - redirectURL string
+func (_e *Authenticator_Expecter) BeginOIDC(provider interface{}, redirectURL interface{}) *Authenticator_BeginOIDC_Call {
+	return &Authenticator_BeginOIDC_Call{Call: _e.mock.On("BeginOIDC", provider, redirectURL)}
 }
 
-func (_c *Authenticator_AuthenticateOIDC_Call) Run(run func(code string)) *Authenticator_AuthenticateOIDC_Call {
+func (_c *Authenticator_BeginOIDC_Call) Run(run func(provider string, redirectURL string)) *Authenticator_BeginOIDC_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Authenticator_AuthenticateOIDC_Call) Return(_a0 string, _a1 error) *Authenticator_AuthenticateOIDC_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Authenticator_BeginOIDC_Call) Return(consentURL string, signedState string, err error) *Authenticator_BeginOIDC_Call {
+	_c.Call.Return(consentURL, signedState, err)
 	return _c
 }
 
-func (_c *Authenticator_AuthenticateOIDC_Call) RunAndReturn(run func(string) (string, error)) *Authenticator_AuthenticateOIDC_Call {
+func (_c *Authenticator_BeginOIDC_Call) RunAndReturn(run func(string, string) (string, string, error)) *Authenticator_BeginOIDC_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOIDCConsentURL provides a mock function with given fields: redirectURL
-func (_m *Authenticator) GetOIDCConsentURL(redirectURL string) string {
-	ret := _m.Called(redirectURL)
+// CompleteOIDC provides a mock function with given fields: ctx, signedState, returnedState, code
+func (_m *Authenticator) CompleteOIDC(ctx context.Context, signedState string, returnedState string, code string) (*auth.OIDCResult, error) {
+	ret := _m.Called(ctx, signedState, returnedState, code)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(redirectURL)
+	var r0 *auth.OIDCResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*auth.OIDCResult, error)); ok {
+		return rf(ctx, signedState, returnedState, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *auth.OIDCResult); ok {
+		r0 = rf(ctx, signedState, returnedState, code)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.OIDCResult)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, signedState, returnedState, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Authenticator_GetOIDCConsentURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOIDCConsentURL'
-type Authenticator_GetOIDCConsentURL_Call struct {
+// Authenticator_CompleteOIDC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteOIDC'
+type Authenticator_CompleteOIDC_Call struct {
 	*mock.Call
 }
 
-// GetOIDCConsentURL is a helper method to define mock.On call
-//   - redirectURL string
-func (_e *Authenticator_Expecter) GetOIDCConsentURL(redirectURL interface{}) *Authenticator_GetOIDCConsentURL_Call {
-	return &Authenticator_GetOIDCConsentURL_Call{Call: _e.mock.On("GetOIDCConsentURL", redirectURL)}
+// CompleteOIDC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signedState string
+//   - returnedState string
+//   - code string
+func (_e *Authenticator_Expecter) CompleteOIDC(ctx interface{}, signedState interface{}, returnedState interface{}, code interface{}) *Authenticator_CompleteOIDC_Call {
+	return &Authenticator_CompleteOIDC_Call{Call: _e.mock.On("CompleteOIDC", ctx, signedState, returnedState, code)}
 }
 
-func (_c *Authenticator_GetOIDCConsentURL_Call) Run(run func(redirectURL string)) *Authenticator_GetOIDCConsentURL_Call {
+func (_c *Authenticator_CompleteOIDC_Call) Run(run func(ctx context.Context, signedState string, returnedState string, code string)) *Authenticator_CompleteOIDC_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *Authenticator_GetOIDCConsentURL_Call) Return(_a0 string) *Authenticator_GetOIDCConsentURL_Call {
-	_c.Call.Return(_a0)
+func (_c *Authenticator_CompleteOIDC_Call) Return(_a0 *auth.OIDCResult, _a1 error) *Authenticator_CompleteOIDC_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Authenticator_GetOIDCConsentURL_Call) RunAndReturn(run func(string) string) *Authenticator_GetOIDCConsentURL_Call {
+func (_c *Authenticator_CompleteOIDC_Call) RunAndReturn(run func(context.Context, string, string, string) (*auth.OIDCResult, error)) *Authenticator_CompleteOIDC_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserInfo provides a mock function with given fields: token
-func (_m *Authenticator) GetUserInfo(token string) *auth.UserInfo {
-	ret := _m.Called(token)
+// GetUserInfo provides a mock function with given fields: ctx, token
+func (_m *Authenticator) GetUserInfo(ctx context.Context, token string) *auth.UserInfo {
+	ret := _m.Called(ctx, token)
 
 	var r0 *auth.UserInfo
-	if rf, ok := ret.Get(0).(func(string) *auth.UserInfo); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.UserInfo); ok {
+		r0 = rf(ctx, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*auth.UserInfo)
@@ -136,14 +162,15 @@ type Authenticator_GetUserInfo_Call struct {
 }
 
 // GetUserInfo is a helper method to define mock.On call
+//   - ctx context.Context
 //   - token string
-func (_e *Authenticator_Expecter) GetUserInfo(token interface{}) *Authenticator_GetUserInfo_Call {
-	return &Authenticator_GetUserInfo_Call{Call: _e.mock.On("GetUserInfo", token)}
+func (_e *Authenticator_Expecter) GetUserInfo(ctx interface{}, token interface{}) *Authenticator_GetUserInfo_Call {
+	return &Authenticator_GetUserInfo_Call{Call: _e.mock.On("GetUserInfo", ctx, token)}
 }
 
-func (_c *Authenticator_GetUserInfo_Call) Run(run func(token string)) *Authenticator_GetUserInfo_Call {
+func (_c *Authenticator_GetUserInfo_Call) Run(run func(ctx context.Context, token string)) *Authenticator_GetUserInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -153,18 +180,18 @@ func (_c *Authenticator_GetUserInfo_Call) Return(_a0 *auth.UserInfo) *Authentica
 	return _c
 }
 
-func (_c *Authenticator_GetUserInfo_Call) RunAndReturn(run func(string) *auth.UserInfo) *Authenticator_GetUserInfo_Call {
+func (_c *Authenticator_GetUserInfo_Call) RunAndReturn(run func(context.Context, string) *auth.UserInfo) *Authenticator_GetUserInfo_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsJWTValid provides a mock function with given fields: token
-func (_m *Authenticator) IsJWTValid(token string) bool {
-	ret := _m.Called(token)
+// IsJWTValid provides a mock function with given fields: ctx, token
+func (_m *Authenticator) IsJWTValid(ctx context.Context, token string) bool {
+	ret := _m.Called(ctx, token)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -178,14 +205,15 @@ type Authenticator_IsJWTValid_Call struct {
 }
 
 // IsJWTValid is a helper method to define mock.On call
+//   - ctx context.Context
 //   - token string
-func (_e *Authenticator_Expecter) IsJWTValid(token interface{}) *Authenticator_IsJWTValid_Call {
-	return &Authenticator_IsJWTValid_Call{Call: _e.mock.On("IsJWTValid", token)}
+func (_e *Authenticator_Expecter) IsJWTValid(ctx interface{}, token interface{}) *Authenticator_IsJWTValid_Call {
+	return &Authenticator_IsJWTValid_Call{Call: _e.mock.On("IsJWTValid", ctx, token)}
 }
 
-func (_c *Authenticator_IsJWTValid_Call) Run(run func(token string)) *Authenticator_IsJWTValid_Call {
+func (_c *Authenticator_IsJWTValid_Call) Run(run func(ctx context.Context, token string)) *Authenticator_IsJWTValid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -195,7 +223,7 @@ func (_c *Authenticator_IsJWTValid_Call) Return(_a0 bool) *Authenticator_IsJWTVa
 	return _c
 }
 
-func (_c *Authenticator_IsJWTValid_Call) RunAndReturn(run func(string) bool) *Authenticator_IsJWTValid_Call {
+func (_c *Authenticator_IsJWTValid_Call) RunAndReturn(run func(context.Context, string) bool) *Authenticator_IsJWTValid_Call {
 	_c.Call.Return(run)
 	return _c
 }
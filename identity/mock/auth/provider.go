@@ -0,0 +1,241 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mockauth
+
+import (
+	context "context"
+
+	auth "github.com/lruggieri/fxnow/identity/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Provider is an autogenerated mock type for the Provider type
+type Provider struct {
+	mock.Mock
+}
+
+type Provider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Provider) EXPECT() *Provider_Expecter {
+	return &Provider_Expecter{mock: &_m.Mock}
+}
+
+// ConsentURL provides a mock function with given fields: state, codeChallenge
+func (_m *Provider) ConsentURL(state string, codeChallenge string) string {
+	ret := _m.Called(state, codeChallenge)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(state, codeChallenge)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Provider_ConsentURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsentURL'
+type Provider_ConsentURL_Call struct {
+	*mock.Call
+}
+
+// ConsentURL is a helper method to define mock.On call
+//   - state string
+//   - codeChallenge string
+func (_e *Provider_Expecter) ConsentURL(state interface{}, codeChallenge interface{}) *Provider_ConsentURL_Call {
+	return &Provider_ConsentURL_Call{Call: _e.mock.On("ConsentURL", state, codeChallenge)}
+}
+
+func (_c *Provider_ConsentURL_Call) Run(run func(state string, codeChallenge string)) *Provider_ConsentURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Provider_ConsentURL_Call) Return(_a0 string) *Provider_ConsentURL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_ConsentURL_Call) RunAndReturn(run func(string, string) string) *Provider_ConsentURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exchange provides a mock function with given fields: ctx, code, codeVerifier
+func (_m *Provider) Exchange(ctx context.Context, code string, codeVerifier string) (string, *auth.VerifiedToken, error) {
+	ret := _m.Called(ctx, code, codeVerifier)
+
+	var r0 string
+	var r1 *auth.VerifiedToken
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, *auth.VerifiedToken, error)); ok {
+		return rf(ctx, code, codeVerifier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, code, codeVerifier)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *auth.VerifiedToken); ok {
+		r1 = rf(ctx, code, codeVerifier)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*auth.VerifiedToken)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, code, codeVerifier)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Provider_Exchange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exchange'
+type Provider_Exchange_Call struct {
+	*mock.Call
+}
+
+// Exchange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+//   - codeVerifier string
+func (_e *Provider_Expecter) Exchange(ctx interface{}, code interface{}, codeVerifier interface{}) *Provider_Exchange_Call {
+	return &Provider_Exchange_Call{Call: _e.mock.On("Exchange", ctx, code, codeVerifier)}
+}
+
+func (_c *Provider_Exchange_Call) Run(run func(ctx context.Context, code string, codeVerifier string)) *Provider_Exchange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Provider_Exchange_Call) Return(_a0 string, _a1 *auth.VerifiedToken, _a2 error) *Provider_Exchange_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Provider_Exchange_Call) RunAndReturn(run func(context.Context, string, string) (string, *auth.VerifiedToken, error)) *Provider_Exchange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Name provides a mock function with given fields:
+func (_m *Provider) Name() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Provider_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type Provider_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *Provider_Expecter) Name() *Provider_Name_Call {
+	return &Provider_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *Provider_Name_Call) Run(run func()) *Provider_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_Name_Call) Return(_a0 string) *Provider_Name_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_Name_Call) RunAndReturn(run func() string) *Provider_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: ctx, token
+func (_m *Provider) Verify(ctx context.Context, token string) (*auth.VerifiedToken, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *auth.VerifiedToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.VerifiedToken, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.VerifiedToken); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.VerifiedToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Provider_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type Provider_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *Provider_Expecter) Verify(ctx interface{}, token interface{}) *Provider_Verify_Call {
+	return &Provider_Verify_Call{Call: _e.mock.On("Verify", ctx, token)}
+}
+
+func (_c *Provider_Verify_Call) Run(run func(ctx context.Context, token string)) *Provider_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Provider_Verify_Call) Return(_a0 *auth.VerifiedToken, _a1 error) *Provider_Verify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Provider_Verify_Call) RunAndReturn(run func(context.Context, string) (*auth.VerifiedToken, error)) *Provider_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewProvider creates a new instance of Provider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewProvider(t mockConstructorTestingTNewProvider) *Provider {
+	mock := &Provider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -22,6 +22,116 @@ func (_m *Logic) EXPECT() *Logic_Expecter {
 	return &Logic_Expecter{mock: &_m.Mock}
 }
 
+// Authenticate provides a mock function with given fields: _a0, _a1
+func (_m *Logic) Authenticate(_a0 context.Context, _a1 logic.AuthenticateRequest) (*logic.AuthenticateResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.AuthenticateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.AuthenticateRequest) (*logic.AuthenticateResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.AuthenticateRequest) *logic.AuthenticateResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.AuthenticateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.AuthenticateRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type Logic_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.AuthenticateRequest
+func (_e *Logic_Expecter) Authenticate(_a0 interface{}, _a1 interface{}) *Logic_Authenticate_Call {
+	return &Logic_Authenticate_Call{Call: _e.mock.On("Authenticate", _a0, _a1)}
+}
+
+func (_c *Logic_Authenticate_Call) Run(run func(_a0 context.Context, _a1 logic.AuthenticateRequest)) *Logic_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.AuthenticateRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_Authenticate_Call) Return(_a0 *logic.AuthenticateResponse, _a1 error) *Logic_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_Authenticate_Call) RunAndReturn(run func(context.Context, logic.AuthenticateRequest) (*logic.AuthenticateResponse, error)) *Logic_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeAPIKeyPlan provides a mock function with given fields: _a0, _a1
+func (_m *Logic) ChangeAPIKeyPlan(_a0 context.Context, _a1 logic.ChangeAPIKeyPlanRequest) (*logic.ChangeAPIKeyPlanResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.ChangeAPIKeyPlanResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ChangeAPIKeyPlanRequest) (*logic.ChangeAPIKeyPlanResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ChangeAPIKeyPlanRequest) *logic.ChangeAPIKeyPlanResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.ChangeAPIKeyPlanResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.ChangeAPIKeyPlanRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_ChangeAPIKeyPlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeAPIKeyPlan'
+type Logic_ChangeAPIKeyPlan_Call struct {
+	*mock.Call
+}
+
+// ChangeAPIKeyPlan is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.ChangeAPIKeyPlanRequest
+func (_e *Logic_Expecter) ChangeAPIKeyPlan(_a0 interface{}, _a1 interface{}) *Logic_ChangeAPIKeyPlan_Call {
+	return &Logic_ChangeAPIKeyPlan_Call{Call: _e.mock.On("ChangeAPIKeyPlan", _a0, _a1)}
+}
+
+func (_c *Logic_ChangeAPIKeyPlan_Call) Run(run func(_a0 context.Context, _a1 logic.ChangeAPIKeyPlanRequest)) *Logic_ChangeAPIKeyPlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.ChangeAPIKeyPlanRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_ChangeAPIKeyPlan_Call) Return(_a0 *logic.ChangeAPIKeyPlanResponse, _a1 error) *Logic_ChangeAPIKeyPlan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_ChangeAPIKeyPlan_Call) RunAndReturn(run func(context.Context, logic.ChangeAPIKeyPlanRequest) (*logic.ChangeAPIKeyPlanResponse, error)) *Logic_ChangeAPIKeyPlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAPIKey provides a mock function with given fields: _a0, _a1
 func (_m *Logic) CreateAPIKey(_a0 context.Context, _a1 logic.CreateAPIKeyRequest) (*logic.CreateAPIKeyResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -77,6 +187,171 @@ func (_c *Logic_CreateAPIKey_Call) RunAndReturn(run func(context.Context, logic.
 	return _c
 }
 
+// CreateOAuthClient provides a mock function with given fields: _a0, _a1
+func (_m *Logic) CreateOAuthClient(_a0 context.Context, _a1 logic.CreateOAuthClientRequest) (*logic.CreateOAuthClientResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.CreateOAuthClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.CreateOAuthClientRequest) (*logic.CreateOAuthClientResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.CreateOAuthClientRequest) *logic.CreateOAuthClientResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.CreateOAuthClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.CreateOAuthClientRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_CreateOAuthClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOAuthClient'
+type Logic_CreateOAuthClient_Call struct {
+	*mock.Call
+}
+
+// CreateOAuthClient is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.CreateOAuthClientRequest
+func (_e *Logic_Expecter) CreateOAuthClient(_a0 interface{}, _a1 interface{}) *Logic_CreateOAuthClient_Call {
+	return &Logic_CreateOAuthClient_Call{Call: _e.mock.On("CreateOAuthClient", _a0, _a1)}
+}
+
+func (_c *Logic_CreateOAuthClient_Call) Run(run func(_a0 context.Context, _a1 logic.CreateOAuthClientRequest)) *Logic_CreateOAuthClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.CreateOAuthClientRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_CreateOAuthClient_Call) Return(_a0 *logic.CreateOAuthClientResponse, _a1 error) *Logic_CreateOAuthClient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_CreateOAuthClient_Call) RunAndReturn(run func(context.Context, logic.CreateOAuthClientRequest) (*logic.CreateOAuthClientResponse, error)) *Logic_CreateOAuthClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrganization provides a mock function with given fields: _a0, _a1
+func (_m *Logic) CreateOrganization(_a0 context.Context, _a1 logic.CreateOrganizationRequest) (*logic.CreateOrganizationResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.CreateOrganizationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.CreateOrganizationRequest) (*logic.CreateOrganizationResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.CreateOrganizationRequest) *logic.CreateOrganizationResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.CreateOrganizationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.CreateOrganizationRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_CreateOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrganization'
+type Logic_CreateOrganization_Call struct {
+	*mock.Call
+}
+
+// CreateOrganization is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.CreateOrganizationRequest
+func (_e *Logic_Expecter) CreateOrganization(_a0 interface{}, _a1 interface{}) *Logic_CreateOrganization_Call {
+	return &Logic_CreateOrganization_Call{Call: _e.mock.On("CreateOrganization", _a0, _a1)}
+}
+
+func (_c *Logic_CreateOrganization_Call) Run(run func(_a0 context.Context, _a1 logic.CreateOrganizationRequest)) *Logic_CreateOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.CreateOrganizationRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_CreateOrganization_Call) Return(_a0 *logic.CreateOrganizationResponse, _a1 error) *Logic_CreateOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_CreateOrganization_Call) RunAndReturn(run func(context.Context, logic.CreateOrganizationRequest) (*logic.CreateOrganizationResponse, error)) *Logic_CreateOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: _a0, _a1
+func (_m *Logic) CreateWebhook(_a0 context.Context, _a1 logic.CreateWebhookRequest) (*logic.CreateWebhookResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.CreateWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.CreateWebhookRequest) (*logic.CreateWebhookResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.CreateWebhookRequest) *logic.CreateWebhookResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.CreateWebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.CreateWebhookRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type Logic_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.CreateWebhookRequest
+func (_e *Logic_Expecter) CreateWebhook(_a0 interface{}, _a1 interface{}) *Logic_CreateWebhook_Call {
+	return &Logic_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", _a0, _a1)}
+}
+
+func (_c *Logic_CreateWebhook_Call) Run(run func(_a0 context.Context, _a1 logic.CreateWebhookRequest)) *Logic_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.CreateWebhookRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_CreateWebhook_Call) Return(_a0 *logic.CreateWebhookResponse, _a1 error) *Logic_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_CreateWebhook_Call) RunAndReturn(run func(context.Context, logic.CreateWebhookRequest) (*logic.CreateWebhookResponse, error)) *Logic_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteAPIKey provides a mock function with given fields: _a0, _a1
 func (_m *Logic) DeleteAPIKey(_a0 context.Context, _a1 logic.DeleteAPIKeyRequest) (*logic.DeleteAPIKeyResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -132,6 +407,1051 @@ func (_c *Logic_DeleteAPIKey_Call) RunAndReturn(run func(context.Context, logic.
 	return _c
 }
 
+// DeleteOAuthClient provides a mock function with given fields: _a0, _a1
+func (_m *Logic) DeleteOAuthClient(_a0 context.Context, _a1 logic.DeleteOAuthClientRequest) (*logic.DeleteOAuthClientResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.DeleteOAuthClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.DeleteOAuthClientRequest) (*logic.DeleteOAuthClientResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.DeleteOAuthClientRequest) *logic.DeleteOAuthClientResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.DeleteOAuthClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.DeleteOAuthClientRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_DeleteOAuthClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOAuthClient'
+type Logic_DeleteOAuthClient_Call struct {
+	*mock.Call
+}
+
+// DeleteOAuthClient is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.DeleteOAuthClientRequest
+func (_e *Logic_Expecter) DeleteOAuthClient(_a0 interface{}, _a1 interface{}) *Logic_DeleteOAuthClient_Call {
+	return &Logic_DeleteOAuthClient_Call{Call: _e.mock.On("DeleteOAuthClient", _a0, _a1)}
+}
+
+func (_c *Logic_DeleteOAuthClient_Call) Run(run func(_a0 context.Context, _a1 logic.DeleteOAuthClientRequest)) *Logic_DeleteOAuthClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.DeleteOAuthClientRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_DeleteOAuthClient_Call) Return(_a0 *logic.DeleteOAuthClientResponse, _a1 error) *Logic_DeleteOAuthClient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_DeleteOAuthClient_Call) RunAndReturn(run func(context.Context, logic.DeleteOAuthClientRequest) (*logic.DeleteOAuthClientResponse, error)) *Logic_DeleteOAuthClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: _a0, _a1
+func (_m *Logic) DeleteWebhook(_a0 context.Context, _a1 logic.DeleteWebhookRequest) (*logic.DeleteWebhookResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.DeleteWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.DeleteWebhookRequest) (*logic.DeleteWebhookResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.DeleteWebhookRequest) *logic.DeleteWebhookResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.DeleteWebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.DeleteWebhookRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type Logic_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.DeleteWebhookRequest
+func (_e *Logic_Expecter) DeleteWebhook(_a0 interface{}, _a1 interface{}) *Logic_DeleteWebhook_Call {
+	return &Logic_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", _a0, _a1)}
+}
+
+func (_c *Logic_DeleteWebhook_Call) Run(run func(_a0 context.Context, _a1 logic.DeleteWebhookRequest)) *Logic_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.DeleteWebhookRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_DeleteWebhook_Call) Return(_a0 *logic.DeleteWebhookResponse, _a1 error) *Logic_DeleteWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_DeleteWebhook_Call) RunAndReturn(run func(context.Context, logic.DeleteWebhookRequest) (*logic.DeleteWebhookResponse, error)) *Logic_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InviteOrganizationMember provides a mock function with given fields: _a0, _a1
+func (_m *Logic) InviteOrganizationMember(_a0 context.Context, _a1 logic.InviteOrganizationMemberRequest) (*logic.InviteOrganizationMemberResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.InviteOrganizationMemberResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.InviteOrganizationMemberRequest) (*logic.InviteOrganizationMemberResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.InviteOrganizationMemberRequest) *logic.InviteOrganizationMemberResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.InviteOrganizationMemberResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.InviteOrganizationMemberRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_InviteOrganizationMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InviteOrganizationMember'
+type Logic_InviteOrganizationMember_Call struct {
+	*mock.Call
+}
+
+// InviteOrganizationMember is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.InviteOrganizationMemberRequest
+func (_e *Logic_Expecter) InviteOrganizationMember(_a0 interface{}, _a1 interface{}) *Logic_InviteOrganizationMember_Call {
+	return &Logic_InviteOrganizationMember_Call{Call: _e.mock.On("InviteOrganizationMember", _a0, _a1)}
+}
+
+func (_c *Logic_InviteOrganizationMember_Call) Run(run func(_a0 context.Context, _a1 logic.InviteOrganizationMemberRequest)) *Logic_InviteOrganizationMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.InviteOrganizationMemberRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_InviteOrganizationMember_Call) Return(_a0 *logic.InviteOrganizationMemberResponse, _a1 error) *Logic_InviteOrganizationMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_InviteOrganizationMember_Call) RunAndReturn(run func(context.Context, logic.InviteOrganizationMemberRequest) (*logic.InviteOrganizationMemberResponse, error)) *Logic_InviteOrganizationMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IssueClientToken provides a mock function with given fields: _a0, _a1
+func (_m *Logic) IssueClientToken(_a0 context.Context, _a1 logic.IssueClientTokenRequest) (*logic.IssueClientTokenResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.IssueClientTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.IssueClientTokenRequest) (*logic.IssueClientTokenResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.IssueClientTokenRequest) *logic.IssueClientTokenResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.IssueClientTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.IssueClientTokenRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_IssueClientToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueClientToken'
+type Logic_IssueClientToken_Call struct {
+	*mock.Call
+}
+
+// IssueClientToken is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.IssueClientTokenRequest
+func (_e *Logic_Expecter) IssueClientToken(_a0 interface{}, _a1 interface{}) *Logic_IssueClientToken_Call {
+	return &Logic_IssueClientToken_Call{Call: _e.mock.On("IssueClientToken", _a0, _a1)}
+}
+
+func (_c *Logic_IssueClientToken_Call) Run(run func(_a0 context.Context, _a1 logic.IssueClientTokenRequest)) *Logic_IssueClientToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.IssueClientTokenRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_IssueClientToken_Call) Return(_a0 *logic.IssueClientTokenResponse, _a1 error) *Logic_IssueClientToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_IssueClientToken_Call) RunAndReturn(run func(context.Context, logic.IssueClientTokenRequest) (*logic.IssueClientTokenResponse, error)) *Logic_IssueClientToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAPIKeys provides a mock function with given fields: _a0, _a1
+func (_m *Logic) ListAPIKeys(_a0 context.Context, _a1 logic.ListAPIKeysRequest) (*logic.ListAPIKeysResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.ListAPIKeysResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListAPIKeysRequest) (*logic.ListAPIKeysResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListAPIKeysRequest) *logic.ListAPIKeysResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.ListAPIKeysResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.ListAPIKeysRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_ListAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPIKeys'
+type Logic_ListAPIKeys_Call struct {
+	*mock.Call
+}
+
+// ListAPIKeys is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.ListAPIKeysRequest
+func (_e *Logic_Expecter) ListAPIKeys(_a0 interface{}, _a1 interface{}) *Logic_ListAPIKeys_Call {
+	return &Logic_ListAPIKeys_Call{Call: _e.mock.On("ListAPIKeys", _a0, _a1)}
+}
+
+func (_c *Logic_ListAPIKeys_Call) Run(run func(_a0 context.Context, _a1 logic.ListAPIKeysRequest)) *Logic_ListAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.ListAPIKeysRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_ListAPIKeys_Call) Return(_a0 *logic.ListAPIKeysResponse, _a1 error) *Logic_ListAPIKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_ListAPIKeys_Call) RunAndReturn(run func(context.Context, logic.ListAPIKeysRequest) (*logic.ListAPIKeysResponse, error)) *Logic_ListAPIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuditLogs provides a mock function with given fields: _a0, _a1
+func (_m *Logic) ListAuditLogs(_a0 context.Context, _a1 logic.ListAuditLogsRequest) (*logic.ListAuditLogsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.ListAuditLogsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListAuditLogsRequest) (*logic.ListAuditLogsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListAuditLogsRequest) *logic.ListAuditLogsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.ListAuditLogsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.ListAuditLogsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_ListAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditLogs'
+type Logic_ListAuditLogs_Call struct {
+	*mock.Call
+}
+
+// ListAuditLogs is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.ListAuditLogsRequest
+func (_e *Logic_Expecter) ListAuditLogs(_a0 interface{}, _a1 interface{}) *Logic_ListAuditLogs_Call {
+	return &Logic_ListAuditLogs_Call{Call: _e.mock.On("ListAuditLogs", _a0, _a1)}
+}
+
+func (_c *Logic_ListAuditLogs_Call) Run(run func(_a0 context.Context, _a1 logic.ListAuditLogsRequest)) *Logic_ListAuditLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.ListAuditLogsRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_ListAuditLogs_Call) Return(_a0 *logic.ListAuditLogsResponse, _a1 error) *Logic_ListAuditLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_ListAuditLogs_Call) RunAndReturn(run func(context.Context, logic.ListAuditLogsRequest) (*logic.ListAuditLogsResponse, error)) *Logic_ListAuditLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOAuthClients provides a mock function with given fields: _a0, _a1
+func (_m *Logic) ListOAuthClients(_a0 context.Context, _a1 logic.ListOAuthClientsRequest) (*logic.ListOAuthClientsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.ListOAuthClientsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListOAuthClientsRequest) (*logic.ListOAuthClientsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListOAuthClientsRequest) *logic.ListOAuthClientsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.ListOAuthClientsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.ListOAuthClientsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_ListOAuthClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOAuthClients'
+type Logic_ListOAuthClients_Call struct {
+	*mock.Call
+}
+
+// ListOAuthClients is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.ListOAuthClientsRequest
+func (_e *Logic_Expecter) ListOAuthClients(_a0 interface{}, _a1 interface{}) *Logic_ListOAuthClients_Call {
+	return &Logic_ListOAuthClients_Call{Call: _e.mock.On("ListOAuthClients", _a0, _a1)}
+}
+
+func (_c *Logic_ListOAuthClients_Call) Run(run func(_a0 context.Context, _a1 logic.ListOAuthClientsRequest)) *Logic_ListOAuthClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.ListOAuthClientsRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_ListOAuthClients_Call) Return(_a0 *logic.ListOAuthClientsResponse, _a1 error) *Logic_ListOAuthClients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_ListOAuthClients_Call) RunAndReturn(run func(context.Context, logic.ListOAuthClientsRequest) (*logic.ListOAuthClientsResponse, error)) *Logic_ListOAuthClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrganizationMembers provides a mock function with given fields: _a0, _a1
+func (_m *Logic) ListOrganizationMembers(_a0 context.Context, _a1 logic.ListOrganizationMembersRequest) (*logic.ListOrganizationMembersResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.ListOrganizationMembersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListOrganizationMembersRequest) (*logic.ListOrganizationMembersResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListOrganizationMembersRequest) *logic.ListOrganizationMembersResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.ListOrganizationMembersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.ListOrganizationMembersRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_ListOrganizationMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrganizationMembers'
+type Logic_ListOrganizationMembers_Call struct {
+	*mock.Call
+}
+
+// ListOrganizationMembers is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.ListOrganizationMembersRequest
+func (_e *Logic_Expecter) ListOrganizationMembers(_a0 interface{}, _a1 interface{}) *Logic_ListOrganizationMembers_Call {
+	return &Logic_ListOrganizationMembers_Call{Call: _e.mock.On("ListOrganizationMembers", _a0, _a1)}
+}
+
+func (_c *Logic_ListOrganizationMembers_Call) Run(run func(_a0 context.Context, _a1 logic.ListOrganizationMembersRequest)) *Logic_ListOrganizationMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.ListOrganizationMembersRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_ListOrganizationMembers_Call) Return(_a0 *logic.ListOrganizationMembersResponse, _a1 error) *Logic_ListOrganizationMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_ListOrganizationMembers_Call) RunAndReturn(run func(context.Context, logic.ListOrganizationMembersRequest) (*logic.ListOrganizationMembersResponse, error)) *Logic_ListOrganizationMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrganizations provides a mock function with given fields: _a0, _a1
+func (_m *Logic) ListOrganizations(_a0 context.Context, _a1 logic.ListOrganizationsRequest) (*logic.ListOrganizationsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.ListOrganizationsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListOrganizationsRequest) (*logic.ListOrganizationsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListOrganizationsRequest) *logic.ListOrganizationsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.ListOrganizationsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.ListOrganizationsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_ListOrganizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrganizations'
+type Logic_ListOrganizations_Call struct {
+	*mock.Call
+}
+
+// ListOrganizations is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.ListOrganizationsRequest
+func (_e *Logic_Expecter) ListOrganizations(_a0 interface{}, _a1 interface{}) *Logic_ListOrganizations_Call {
+	return &Logic_ListOrganizations_Call{Call: _e.mock.On("ListOrganizations", _a0, _a1)}
+}
+
+func (_c *Logic_ListOrganizations_Call) Run(run func(_a0 context.Context, _a1 logic.ListOrganizationsRequest)) *Logic_ListOrganizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.ListOrganizationsRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_ListOrganizations_Call) Return(_a0 *logic.ListOrganizationsResponse, _a1 error) *Logic_ListOrganizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_ListOrganizations_Call) RunAndReturn(run func(context.Context, logic.ListOrganizationsRequest) (*logic.ListOrganizationsResponse, error)) *Logic_ListOrganizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPlans provides a mock function with given fields: _a0, _a1
+func (_m *Logic) ListPlans(_a0 context.Context, _a1 logic.ListPlansRequest) (*logic.ListPlansResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.ListPlansResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListPlansRequest) (*logic.ListPlansResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListPlansRequest) *logic.ListPlansResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.ListPlansResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.ListPlansRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_ListPlans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPlans'
+type Logic_ListPlans_Call struct {
+	*mock.Call
+}
+
+// ListPlans is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.ListPlansRequest
+func (_e *Logic_Expecter) ListPlans(_a0 interface{}, _a1 interface{}) *Logic_ListPlans_Call {
+	return &Logic_ListPlans_Call{Call: _e.mock.On("ListPlans", _a0, _a1)}
+}
+
+func (_c *Logic_ListPlans_Call) Run(run func(_a0 context.Context, _a1 logic.ListPlansRequest)) *Logic_ListPlans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.ListPlansRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_ListPlans_Call) Return(_a0 *logic.ListPlansResponse, _a1 error) *Logic_ListPlans_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_ListPlans_Call) RunAndReturn(run func(context.Context, logic.ListPlansRequest) (*logic.ListPlansResponse, error)) *Logic_ListPlans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSessions provides a mock function with given fields: _a0, _a1
+func (_m *Logic) ListSessions(_a0 context.Context, _a1 logic.ListSessionsRequest) (*logic.ListSessionsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.ListSessionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListSessionsRequest) (*logic.ListSessionsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListSessionsRequest) *logic.ListSessionsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.ListSessionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.ListSessionsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type Logic_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.ListSessionsRequest
+func (_e *Logic_Expecter) ListSessions(_a0 interface{}, _a1 interface{}) *Logic_ListSessions_Call {
+	return &Logic_ListSessions_Call{Call: _e.mock.On("ListSessions", _a0, _a1)}
+}
+
+func (_c *Logic_ListSessions_Call) Run(run func(_a0 context.Context, _a1 logic.ListSessionsRequest)) *Logic_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.ListSessionsRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_ListSessions_Call) Return(_a0 *logic.ListSessionsResponse, _a1 error) *Logic_ListSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_ListSessions_Call) RunAndReturn(run func(context.Context, logic.ListSessionsRequest) (*logic.ListSessionsResponse, error)) *Logic_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeadLetters provides a mock function with given fields: _a0, _a1
+func (_m *Logic) ListWebhookDeadLetters(_a0 context.Context, _a1 logic.ListWebhookDeadLettersRequest) (*logic.ListWebhookDeadLettersResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.ListWebhookDeadLettersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListWebhookDeadLettersRequest) (*logic.ListWebhookDeadLettersResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListWebhookDeadLettersRequest) *logic.ListWebhookDeadLettersResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.ListWebhookDeadLettersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.ListWebhookDeadLettersRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_ListWebhookDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeadLetters'
+type Logic_ListWebhookDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeadLetters is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.ListWebhookDeadLettersRequest
+func (_e *Logic_Expecter) ListWebhookDeadLetters(_a0 interface{}, _a1 interface{}) *Logic_ListWebhookDeadLetters_Call {
+	return &Logic_ListWebhookDeadLetters_Call{Call: _e.mock.On("ListWebhookDeadLetters", _a0, _a1)}
+}
+
+func (_c *Logic_ListWebhookDeadLetters_Call) Run(run func(_a0 context.Context, _a1 logic.ListWebhookDeadLettersRequest)) *Logic_ListWebhookDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.ListWebhookDeadLettersRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_ListWebhookDeadLetters_Call) Return(_a0 *logic.ListWebhookDeadLettersResponse, _a1 error) *Logic_ListWebhookDeadLetters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_ListWebhookDeadLetters_Call) RunAndReturn(run func(context.Context, logic.ListWebhookDeadLettersRequest) (*logic.ListWebhookDeadLettersResponse, error)) *Logic_ListWebhookDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhooks provides a mock function with given fields: _a0, _a1
+func (_m *Logic) ListWebhooks(_a0 context.Context, _a1 logic.ListWebhooksRequest) (*logic.ListWebhooksResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.ListWebhooksResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListWebhooksRequest) (*logic.ListWebhooksResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.ListWebhooksRequest) *logic.ListWebhooksResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.ListWebhooksResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.ListWebhooksRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
+type Logic_ListWebhooks_Call struct {
+	*mock.Call
+}
+
+// ListWebhooks is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.ListWebhooksRequest
+func (_e *Logic_Expecter) ListWebhooks(_a0 interface{}, _a1 interface{}) *Logic_ListWebhooks_Call {
+	return &Logic_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", _a0, _a1)}
+}
+
+func (_c *Logic_ListWebhooks_Call) Run(run func(_a0 context.Context, _a1 logic.ListWebhooksRequest)) *Logic_ListWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.ListWebhooksRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_ListWebhooks_Call) Return(_a0 *logic.ListWebhooksResponse, _a1 error) *Logic_ListWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_ListWebhooks_Call) RunAndReturn(run func(context.Context, logic.ListWebhooksRequest) (*logic.ListWebhooksResponse, error)) *Logic_ListWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function with given fields: _a0, _a1
+func (_m *Logic) Login(_a0 context.Context, _a1 logic.LoginRequest) (*logic.LoginResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.LoginRequest) (*logic.LoginResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.LoginRequest) *logic.LoginResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.LoginRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type Logic_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.LoginRequest
+func (_e *Logic_Expecter) Login(_a0 interface{}, _a1 interface{}) *Logic_Login_Call {
+	return &Logic_Login_Call{Call: _e.mock.On("Login", _a0, _a1)}
+}
+
+func (_c *Logic_Login_Call) Run(run func(_a0 context.Context, _a1 logic.LoginRequest)) *Logic_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.LoginRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_Login_Call) Return(_a0 *logic.LoginResponse, _a1 error) *Logic_Login_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_Login_Call) RunAndReturn(run func(context.Context, logic.LoginRequest) (*logic.LoginResponse, error)) *Logic_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function with given fields: _a0, _a1
+func (_m *Logic) Logout(_a0 context.Context, _a1 logic.LogoutRequest) (*logic.LogoutResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.LogoutResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.LogoutRequest) (*logic.LogoutResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.LogoutRequest) *logic.LogoutResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.LogoutResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.LogoutRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type Logic_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.LogoutRequest
+func (_e *Logic_Expecter) Logout(_a0 interface{}, _a1 interface{}) *Logic_Logout_Call {
+	return &Logic_Logout_Call{Call: _e.mock.On("Logout", _a0, _a1)}
+}
+
+func (_c *Logic_Logout_Call) Run(run func(_a0 context.Context, _a1 logic.LogoutRequest)) *Logic_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.LogoutRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_Logout_Call) Return(_a0 *logic.LogoutResponse, _a1 error) *Logic_Logout_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_Logout_Call) RunAndReturn(run func(context.Context, logic.LogoutRequest) (*logic.LogoutResponse, error)) *Logic_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshSession provides a mock function with given fields: _a0, _a1
+func (_m *Logic) RefreshSession(_a0 context.Context, _a1 logic.RefreshSessionRequest) (*logic.RefreshSessionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.RefreshSessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.RefreshSessionRequest) (*logic.RefreshSessionResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.RefreshSessionRequest) *logic.RefreshSessionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.RefreshSessionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.RefreshSessionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_RefreshSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshSession'
+type Logic_RefreshSession_Call struct {
+	*mock.Call
+}
+
+// RefreshSession is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.RefreshSessionRequest
+func (_e *Logic_Expecter) RefreshSession(_a0 interface{}, _a1 interface{}) *Logic_RefreshSession_Call {
+	return &Logic_RefreshSession_Call{Call: _e.mock.On("RefreshSession", _a0, _a1)}
+}
+
+func (_c *Logic_RefreshSession_Call) Run(run func(_a0 context.Context, _a1 logic.RefreshSessionRequest)) *Logic_RefreshSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.RefreshSessionRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_RefreshSession_Call) Return(_a0 *logic.RefreshSessionResponse, _a1 error) *Logic_RefreshSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_RefreshSession_Call) RunAndReturn(run func(context.Context, logic.RefreshSessionRequest) (*logic.RefreshSessionResponse, error)) *Logic_RefreshSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveOrganizationMember provides a mock function with given fields: _a0, _a1
+func (_m *Logic) RemoveOrganizationMember(_a0 context.Context, _a1 logic.RemoveOrganizationMemberRequest) (*logic.RemoveOrganizationMemberResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.RemoveOrganizationMemberResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.RemoveOrganizationMemberRequest) (*logic.RemoveOrganizationMemberResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.RemoveOrganizationMemberRequest) *logic.RemoveOrganizationMemberResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.RemoveOrganizationMemberResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.RemoveOrganizationMemberRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_RemoveOrganizationMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveOrganizationMember'
+type Logic_RemoveOrganizationMember_Call struct {
+	*mock.Call
+}
+
+// RemoveOrganizationMember is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.RemoveOrganizationMemberRequest
+func (_e *Logic_Expecter) RemoveOrganizationMember(_a0 interface{}, _a1 interface{}) *Logic_RemoveOrganizationMember_Call {
+	return &Logic_RemoveOrganizationMember_Call{Call: _e.mock.On("RemoveOrganizationMember", _a0, _a1)}
+}
+
+func (_c *Logic_RemoveOrganizationMember_Call) Run(run func(_a0 context.Context, _a1 logic.RemoveOrganizationMemberRequest)) *Logic_RemoveOrganizationMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.RemoveOrganizationMemberRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_RemoveOrganizationMember_Call) Return(_a0 *logic.RemoveOrganizationMemberResponse, _a1 error) *Logic_RemoveOrganizationMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_RemoveOrganizationMember_Call) RunAndReturn(run func(context.Context, logic.RemoveOrganizationMemberRequest) (*logic.RemoveOrganizationMemberResponse, error)) *Logic_RemoveOrganizationMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: _a0, _a1
+func (_m *Logic) RevokeSession(_a0 context.Context, _a1 logic.RevokeSessionRequest) (*logic.RevokeSessionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.RevokeSessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.RevokeSessionRequest) (*logic.RevokeSessionResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.RevokeSessionRequest) *logic.RevokeSessionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.RevokeSessionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.RevokeSessionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type Logic_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.RevokeSessionRequest
+func (_e *Logic_Expecter) RevokeSession(_a0 interface{}, _a1 interface{}) *Logic_RevokeSession_Call {
+	return &Logic_RevokeSession_Call{Call: _e.mock.On("RevokeSession", _a0, _a1)}
+}
+
+func (_c *Logic_RevokeSession_Call) Run(run func(_a0 context.Context, _a1 logic.RevokeSessionRequest)) *Logic_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.RevokeSessionRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_RevokeSession_Call) Return(_a0 *logic.RevokeSessionResponse, _a1 error) *Logic_RevokeSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_RevokeSession_Call) RunAndReturn(run func(context.Context, logic.RevokeSessionRequest) (*logic.RevokeSessionResponse, error)) *Logic_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateAPIKey provides a mock function with given fields: _a0, _a1
+func (_m *Logic) RotateAPIKey(_a0 context.Context, _a1 logic.RotateAPIKeyRequest) (*logic.RotateAPIKeyResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *logic.RotateAPIKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, logic.RotateAPIKeyRequest) (*logic.RotateAPIKeyResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, logic.RotateAPIKeyRequest) *logic.RotateAPIKeyResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*logic.RotateAPIKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, logic.RotateAPIKeyRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logic_RotateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateAPIKey'
+type Logic_RotateAPIKey_Call struct {
+	*mock.Call
+}
+
+// RotateAPIKey is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 logic.RotateAPIKeyRequest
+func (_e *Logic_Expecter) RotateAPIKey(_a0 interface{}, _a1 interface{}) *Logic_RotateAPIKey_Call {
+	return &Logic_RotateAPIKey_Call{Call: _e.mock.On("RotateAPIKey", _a0, _a1)}
+}
+
+func (_c *Logic_RotateAPIKey_Call) Run(run func(_a0 context.Context, _a1 logic.RotateAPIKeyRequest)) *Logic_RotateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logic.RotateAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *Logic_RotateAPIKey_Call) Return(_a0 *logic.RotateAPIKeyResponse, _a1 error) *Logic_RotateAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Logic_RotateAPIKey_Call) RunAndReturn(run func(context.Context, logic.RotateAPIKeyRequest) (*logic.RotateAPIKeyResponse, error)) *Logic_RotateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 type mockConstructorTestingTNewLogic interface {
 	mock.TestingT
 	Cleanup(func())